@@ -334,6 +334,10 @@ export default function MerchantTracker() {
                     currency: transactionData.currency,
                     status: transactionData.status,
                     paid_date: transactionData.paid_date,
+                    customer_name: transactionData.customer_name || prevTransactions[existingIndex].customer_name,
+                    customer_pan: transactionData.customer_pan || prevTransactions[existingIndex].customer_pan,
+                    issuer_name: transactionData.issuer_name || prevTransactions[existingIndex].issuer_name,
+                    rrn: transactionData.rrn || prevTransactions[existingIndex].rrn,
                     updated_at: transactionData.updated_at
                 };
 
@@ -353,7 +357,10 @@ export default function MerchantTracker() {
                     paid_date: transactionData.paid_date,
                     amount: transactionData.amount,
                     currency: transactionData.currency,
-                    customer_name: "",
+                    customer_name: transactionData.customer_name || "",
+                    customer_pan: transactionData.customer_pan,
+                    issuer_name: transactionData.issuer_name,
+                    rrn: transactionData.rrn,
                     description: "",
                     updated_at: transactionData.updated_at
                 };
@@ -420,7 +427,7 @@ export default function MerchantTracker() {
 
             const data: ApiResponse = await response.json();
 
-            if (data.responseCode === '200') {
+            if (data.responseCode?.startsWith('200')) {
                 const normalizedData = data.data.map((transaction: any) => ({
                     ...transaction,
                     // Pastikan ada 'referenceNo' atau 'reference_no'
@@ -5,12 +5,20 @@ export interface ApiResponse {
     responseCode: string;
     responseMessage: string;
     data: Transaction[];
-    pagination: {
+    // Tidak ada jika includeTotal=false (default saat memakai cursor)
+    pagination?: {
         page: number;
         limit: number;
         total: number;
         totalPage: number;
     };
+    // Cursor keyset untuk infinite scroll: kirim next sebagai ?after=, prev sebagai ?before=
+    cursor?: {
+        next?: string;
+        prev?: string;
+        hasNext: boolean;
+        hasPrev: boolean;
+    };
 }
 
 interface WebSocketMessage {
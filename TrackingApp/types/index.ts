@@ -10,7 +10,10 @@ export interface Transaction {
     paid_date: string | null;
     amount: number;
     currency: string; // Currency is empty, so you might want to make this optional or set a default.
-    customer_name: string; // Not available in provided data.
+    customer_name: string; // Nama pembayar dari callback issuer, bisa kosong
+    customer_pan?: string; // PAN pembayar (masked)
+    issuer_name?: string;
+    rrn?: string;
     description: string; // Not available in provided data.
     updated_at: string;
 }
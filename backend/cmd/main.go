@@ -1,19 +1,33 @@
 package main
 
 import (
-	"log"
-	_ "qr-service/docs"
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"qr-service/config"
+	"qr-service/database"
+	"qr-service/docs"
 	"qr-service/internal/handler"
 	"qr-service/internal/repository"
 	"qr-service/internal/router"
 	"qr-service/internal/service"
+	"qr-service/pkg/health"
+	"qr-service/pkg/metrics"
+	"qr-service/pkg/ratelimit"
+	"qr-service/pkg/tracing"
 	ws "qr-service/pkg/websocket"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/fiber/v2/middleware/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"gorm.io/gorm"
 )
 
 // @title QR Payment API
@@ -24,31 +38,220 @@ import (
 // @host localhost:8000
 // @BasePath /api/v1
 func main() {
-	// 1. Setup Project Backend: Koneksi DB
-	db := config.SetupDatabase()
+	// Subcommand CLI: qr-service reconcile ... / qr-service migrate ... / qr-service report ...
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "reconcile":
+			runReconcile(os.Args[2:])
+			return
+		case "migrate":
+			runMigrate(os.Args[2:])
+			return
+		case "report":
+			runReport(os.Args[2:])
+			return
+		}
+	}
+
+	// 1. Konfigurasi (env + CONFIG_FILE opsional), gagal start jika tidak valid
+	cfg := config.MustLoad()
+	docs.SwaggerInfo.Host = cfg.Server.Host()
+
+	// Log JSON terstruktur (LOG_LEVEL, LOG_FORMAT), termasuk query GORM
+	config.SetupLogging(cfg.Log)
+
+	// Setup Project Backend: Koneksi DB
+	db := config.SetupDatabase(cfg.Database, cfg.Log)
+
+	// Tracing OpenTelemetry: span request, service, query GORM dan broadcast hub
+	flushTraces, err := tracing.Setup(context.Background(), tracing.Options{
+		Endpoint:    cfg.Tracing.Endpoint,
+		ServiceName: cfg.Tracing.ServiceName,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		fatal("Failed to set up tracing", "error", err)
+	}
+	if err := db.Use(tracing.GormPlugin{}); err != nil {
+		fatal("Failed to register GORM tracing plugin", "error", err)
+	}
+
+	// 2. Pastikan schema sudah di-migrate ke versi yang diharapkan binary ini
+	migrator := requireSchemaVersion(database.NewMigrator(db))
 
 	wsHub := ws.NewHub()
 	go wsHub.Run()
 
 	// Inisialisasi komponen MVC
 	wsHandler := handler.NewWebSocketHandler(wsHub)
+	merchantRepo := repository.NewMerchantRepository(db)
+	merchantService := service.NewMerchantService(merchantRepo)
+	merchantHandler := handler.MerchantHandler{Service: merchantService}
+	ledgerRepo := repository.NewLedgerRepository(db)
+	ledgerService := service.NewLedgerService(ledgerRepo)
+	ledgerHandler := handler.LedgerHandler{Service: ledgerService}
+	riskRepo := repository.NewRiskRepository(db)
+	riskService := service.NewRiskService(riskRepo)
+	riskHandler := handler.RiskHandler{Service: riskService}
 	transactionRepo := repository.NewTransactionRepository(db)
-	transactionService := service.NewTransactionService(transactionRepo, wsHub)
+	transactionService := service.NewTransactionService(transactionRepo, merchantService, riskService, ledgerService, wsHub)
+	transactionService.RefGenerator = config.SetupReferenceGenerator(cfg.Reference)
+	transactionService.StatusMapper = config.SetupStatusMapper(cfg.StatusMapping)
+	transactionService.Providers = config.SetupProviders(cfg.Providers, cfg.Security)
 	transactionHandler := handler.TransactionHandler{Service: transactionService}
+	reconciliationRepo := repository.NewReconciliationRepository(db)
+	reconciliationService := service.NewReconciliationService(reconciliationRepo, transactionService)
+	reconciliationHandler := handler.ReconciliationHandler{Service: reconciliationService}
+	settlementRepo := repository.NewSettlementRepository(db)
+	settlementService := service.NewSettlementService(settlementRepo, ledgerService)
+	settlementHandler := handler.SettlementHandler{Service: settlementService}
+	reportRepo := repository.NewReportRepository(db)
+	reportService := service.NewReportService(reportRepo, transactionRepo, merchantRepo, config.SetupMailSender(cfg.Mail))
+	reportHandler := handler.ReportHandler{Service: reportService}
+
+	// Background worker dihentikan lewat workersCtx saat shutdown, lalu ditunggu lewat workers
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
 
-	app := fiber.New()
+	// Pemeriksaan readiness (/readyz): database, versi schema, hub WebSocket dan heartbeat worker
+	checker := health.NewChecker(cfg.Server.HealthCheckTimeout)
+	checker.Add("database", func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	})
+	checker.Add("migrations", func(ctx context.Context) error {
+		return migrator.WithContext(ctx).CheckVersion()
+	})
+	checker.Add("websocket_hub", wsHub.Ping)
+	healthHandler := handler.NewHealthHandler(checker)
+
+	// Scheduler laporan harian merchant (REPORT_RUN_AT, default 00:05 WIB)
+	if runAt, enabled, _ := cfg.Report.Schedule(); enabled {
+		heartbeat := health.NewHeartbeat(service.ReportHeartbeatMaxAge)
+		checker.Add("worker_daily_report", heartbeat.Check)
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			reportService.RunScheduler(workersCtx, runAt, heartbeat)
+		}()
+	}
+
+	// Rate limit per partner/merchant/IP. Store postgres dipakai jika ada beberapa instance;
+	// bucket yang sudah penuh kembali dibersihkan berkala.
+	var limiter *ratelimit.Limiter
+	if cfg.RateLimit.Enabled {
+		var store ratelimit.Store = ratelimit.NewMemoryStore()
+		if cfg.RateLimit.Store == "postgres" {
+			store = repository.NewRateLimitRepository(db)
+		}
+		limiter = ratelimit.NewLimiter(store, cfg.RateLimit.Rules())
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			limiter.RunCleanup(workersCtx, time.Minute)
+		}()
+	}
+
+	// Metric yang dibaca saat scrape /metrics
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{Name: "qr_websocket_clients", Help: "Jumlah client WebSocket yang terhubung."}, func() float64 {
+		return float64(wsHub.GetClientCount())
+	})
+	promauto.NewCounterFunc(prometheus.CounterOpts{Name: "qr_websocket_dropped_messages_total", Help: "Jumlah message WebSocket yang dibuang karena buffer client penuh."}, func() float64 {
+		return float64(wsHub.DroppedMessages())
+	})
+	if sqlDB, err := db.DB(); err == nil {
+		metrics.RegisterDBStats(sqlDB)
+	}
+
+	// Semua error dari handler dan middleware dibungkus oleh handler.ErrorHandler
+	app := fiber.New(fiber.Config{ErrorHandler: handler.ErrorHandler})
 
 	app.Use(cors.New(cors.Config{
-		AllowOrigins:     "http://localhost:3000, http://127.0.0.1:3000, http://localhost:5173, http://127.0.0.1:5173, http://0.0.0.0:8081", // Frontend URLs
+		AllowOrigins:     strings.Join(cfg.Server.CORSOrigins, ", "), // Frontend URLs
 		AllowMethods:     "GET,POST,PUT,DELETE,OPTIONS",
-		AllowHeaders:     "Origin, Content-Type, Accept, Authorization, X-Signature, X-Requested-With",
+		AllowHeaders:     "Origin, Content-Type, Accept, Authorization, X-Signature, X-Requested-With, X-TIMESTAMP, X-PARTNER-ID, X-EXTERNAL-ID, CHANNEL-ID, X-Request-ID",
+		ExposeHeaders:    "X-Request-ID, traceparent, Retry-After, X-RateLimit-Limit, X-RateLimit-Remaining",
 		AllowCredentials: true,
 		MaxAge:           86400,
 	}))
 
-	app.Use(logger.New())
+	app.Use(handler.Tracing())
+	app.Use(handler.RequestID())
+	app.Use(handler.AccessLog())
+	app.Use(handler.Metrics())
+
+	router.SetupRoutes(app, cfg, &transactionHandler, &merchantHandler, &riskHandler, &reconciliationHandler, &settlementHandler, &ledgerHandler, &reportHandler, healthHandler, wsHandler, limiter)
 
-	router.SetupRoutes(app, &transactionHandler, wsHandler)
+	// Jalankan server sampai SIGINT/SIGTERM (docker stop), lalu shutdown bertahap
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stopSignals()
+
+	listenErr := make(chan error, 1)
+	go func() {
+		listenErr <- app.Listen(cfg.Server.Addr())
+	}()
+
+	select {
+	case err := <-listenErr:
+		fatal("Server stopped", "error", err)
+	case <-signalCtx.Done():
+		stopSignals() // signal kedua langsung menghentikan proses
+	}
+
+	gracefulShutdown(cfg.Server.ShutdownTimeout, app, wsHub, wsHandler, stopWorkers, &workers, flushTraces, db)
+}
+
+// gracefulShutdown berhenti menerima request baru dan menunggu request yang sedang berjalan,
+// lalu menutup koneksi WebSocket dengan close frame, menghentikan background worker, mengirim
+// span yang tersisa ke collector dan terakhir menutup pool database. Semua langkah berbagi satu deadline.
+func gracefulShutdown(timeout time.Duration, app *fiber.App, hub *ws.Hub, wsHandler *handler.WebSocketHandler, stopWorkers context.CancelFunc, workers *sync.WaitGroup, flushTraces func(context.Context) error, db *gorm.DB) {
+	slog.Info("Shutting down, draining in-flight requests", "timeout", timeout.String())
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// 1. HTTP: tolak koneksi baru, tunggu request yang sedang berjalan (callback tetap bisa broadcast ke hub)
+	if err := app.ShutdownWithContext(ctx); err != nil {
+		slog.Warn("HTTP server shutdown", "error", err)
+	}
+
+	// 2. WebSocket: hub berhenti, setiap client menerima SERVER_SHUTDOWN dan close frame 1012
+	hub.Stop()
+	if err := wsHandler.Wait(ctx); err != nil {
+		slog.Warn("WebSocket clients not closed before deadline", "error", err)
+	}
+
+	// 3. Background worker (scheduler laporan), laporan yang sedang dibuat diselesaikan dulu
+	stopWorkers()
+	workersDone := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(workersDone)
+	}()
+	select {
+	case <-workersDone:
+	case <-ctx.Done():
+		slog.Warn("Background workers still running at deadline", "error", ctx.Err())
+	}
+
+	// 4. Span yang masih di buffer dikirim ke collector
+	if err := flushTraces(ctx); err != nil {
+		slog.Warn("Failed to flush traces", "error", err)
+	}
+
+	// 5. Pool koneksi database
+	if sqlDB, err := db.DB(); err == nil {
+		if err := sqlDB.Close(); err != nil {
+			slog.Warn("Failed to close database pool", "error", err)
+		}
+	}
+	slog.Info("Shutdown complete")
+}
 
-	log.Fatal(app.Listen(":8000"))
+// fatal mencatat error lalu menghentikan proses dengan exit code 1
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
 }
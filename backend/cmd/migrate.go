@@ -0,0 +1,104 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"qr-service/config"
+	"qr-service/database"
+	"qr-service/pkg/migrate"
+	"qr-service/pkg/util"
+)
+
+// runMigrate menjalankan migration schema dari command line:
+//
+//	qr-service migrate up
+//	qr-service migrate down [-steps 1]
+//	qr-service migrate status
+func runMigrate(args []string) {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	steps := fs.Int("steps", 1, "jumlah migration yang dibatalkan untuk perintah down")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: qr-service migrate up|down|status [-steps N]")
+		fs.PrintDefaults()
+	}
+
+	if len(args) == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	command := args[0]
+	fs.Parse(args[1:])
+
+	cfg := config.MustLoad()
+	config.SetupLogging(cfg.Log)
+	db := config.SetupDatabase(cfg.Database, cfg.Log)
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		fatal("Failed to load migrations", "error", err)
+	}
+
+	switch command {
+	case "up":
+		done, err := migrator.Up()
+		printMigrations("Applied", done)
+		if err != nil {
+			fatal("Migration failed", "error", err)
+		}
+		if len(done) == 0 {
+			fmt.Println("Schema is up to date")
+		}
+	case "down":
+		if *steps <= 0 {
+			fatal("steps must be greater than 0")
+		}
+		done, err := migrator.Down(*steps)
+		printMigrations("Rolled back", done)
+		if err != nil {
+			fatal("Rollback failed", "error", err)
+		}
+	case "status":
+		printMigrationStatus(migrator)
+	default:
+		fs.Usage()
+		os.Exit(2)
+	}
+}
+
+func printMigrations(action string, migrations []migrate.Migration) {
+	for _, m := range migrations {
+		fmt.Printf("%s %04d_%s\n", action, m.Version, m.Name)
+	}
+}
+
+func printMigrationStatus(migrator *migrate.Migrator) {
+	statuses, err := migrator.Status()
+	if err != nil {
+		fatal("Failed to read migration status", "error", err)
+	}
+	version, err := migrator.Version()
+	if err != nil {
+		fatal("Failed to read schema version", "error", err)
+	}
+
+	fmt.Printf("Schema version: %d (expected %d)\n", version, migrator.Latest())
+	for _, s := range statuses {
+		appliedAt := "pending"
+		if s.Applied {
+			appliedAt = s.AppliedAt.In(util.WIB).Format("2006-01-02 15:04:05 WIB")
+		}
+		fmt.Printf("  %04d_%-28s %s\n", s.Version, s.Name, appliedAt)
+	}
+}
+
+// requireSchemaVersion menolak jalan jika schema database tidak sama dengan versi migration di binary
+func requireSchemaVersion(migrator *migrate.Migrator, err error) *migrate.Migrator {
+	if err != nil {
+		fatal("Failed to load migrations", "error", err)
+	}
+	if err := migrator.CheckVersion(); err != nil {
+		fatal("Refusing to start", "error", err)
+	}
+	return migrator
+}
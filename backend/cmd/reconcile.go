@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"qr-service/config"
+	"qr-service/database"
+	"qr-service/internal/model"
+	"qr-service/internal/repository"
+	"qr-service/internal/service"
+	"qr-service/pkg/settlement"
+)
+
+// runReconcile menjalankan rekonsiliasi dari command line:
+//
+//	qr-service reconcile -file settlement.csv [-format csv] [-date 2025-09-21] [-correct]
+func runReconcile(args []string) {
+	fs := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	filePath := fs.String("file", "", "path ke file settlement")
+	format := fs.String("format", "csv", fmt.Sprintf("format file settlement %v", settlement.Formats()))
+	date := fs.String("date", "", "tanggal settlement YYYY-MM-DD (WIB), default dari paid_time di file")
+	correct := fs.Bool("correct", false, "koreksi status PAID untuk transaksi yang callback-nya tidak pernah datang")
+	fs.Parse(args)
+
+	if *filePath == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	file, err := os.Open(*filePath)
+	if err != nil {
+		fatal("Failed to open settlement file", "error", err)
+	}
+	defer file.Close()
+
+	cfg := config.MustLoad()
+	config.SetupLogging(cfg.Log)
+	db := config.SetupDatabase(cfg.Database, cfg.Log)
+	requireSchemaVersion(database.NewMigrator(db))
+	ledgerService := service.NewLedgerService(repository.NewLedgerRepository(db))
+	transactionService := service.NewTransactionService(repository.NewTransactionRepository(db), nil, nil, ledgerService, nil)
+	transactionService.StatusMapper = config.SetupStatusMapper(cfg.StatusMapping)
+	transactionService.Providers = config.SetupProviders(cfg.Providers, cfg.Security)
+	reconciliationService := service.NewReconciliationService(repository.NewReconciliationRepository(db), transactionService)
+
+	resp, err := reconciliationService.Reconcile(context.Background(), file, model.ReconcileRequest{
+		FileName: file.Name(),
+		Format:   *format,
+		Date:     *date,
+		Correct:  *correct,
+	})
+	if err != nil {
+		fatal("Reconciliation failed", "error", err)
+	}
+
+	run := resp.Data
+	fmt.Printf("Reconciliation #%d (%s, %d records)\n", run.ID, run.Format, run.TotalRecords)
+	fmt.Printf("  %-22s %d\n", "matched", run.Matched)
+	fmt.Printf("  %-22s %d\n", "missing on our side", run.MissingOurs)
+	fmt.Printf("  %-22s %d\n", "missing on their side", run.MissingTheirs)
+	fmt.Printf("  %-22s %d\n", "amount mismatch", run.AmountMismatch)
+	fmt.Printf("  %-22s %d\n", "currency mismatch", run.CurrencyMismatch)
+	fmt.Printf("  %-22s %d\n", "duplicate", run.Duplicate)
+	fmt.Printf("  %-22s %d\n", "corrected", run.Corrected)
+
+	for _, item := range run.Items {
+		if item.Category == model.ReconMatched && !item.Corrected {
+			continue
+		}
+		fmt.Printf("  [%s] %s line=%d ours=%s theirs=%s corrected=%t\n",
+			item.Category, item.ReferenceNo, item.Line, formatAmount(item.OurAmount), formatAmount(item.TheirAmount), item.Corrected)
+	}
+}
+
+func formatAmount(amount *float64) string {
+	if amount == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", *amount)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"qr-service/config"
+	"qr-service/database"
+	"qr-service/internal/model"
+	"qr-service/internal/repository"
+	"qr-service/internal/service"
+)
+
+// runReport membuat laporan harian dari command line:
+//
+//	qr-service report [-date 2025-09-21] [-merchant M001] [-email]
+func runReport(args []string) {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	date := fs.String("date", "", "tanggal laporan YYYY-MM-DD (WIB), default kemarin")
+	merchantID := fs.String("merchant", "", "hanya untuk merchant ini, default semua yang bertransaksi")
+	sendEmail := fs.Bool("email", false, "kirim laporan ke report_email merchant (butuh SMTP_HOST)")
+	fs.Parse(args)
+
+	cfg := config.MustLoad()
+	config.SetupLogging(cfg.Log)
+	db := config.SetupDatabase(cfg.Database, cfg.Log)
+	requireSchemaVersion(database.NewMigrator(db))
+	reportService := service.NewReportService(
+		repository.NewReportRepository(db),
+		repository.NewTransactionRepository(db),
+		repository.NewMerchantRepository(db),
+		config.SetupMailSender(cfg.Mail),
+	)
+
+	resp, err := reportService.GenerateDailyReports(model.RunDailyReportsRequest{
+		Date:       *date,
+		MerchantID: *merchantID,
+		SendEmail:  *sendEmail,
+	})
+	if err != nil {
+		fatal("Daily report failed", "error", err)
+	}
+
+	fmt.Printf("%d daily reports generated\n", len(resp.Data))
+	for _, report := range resp.Data {
+		emailed := "-"
+		switch {
+		case report.EmailError != "":
+			emailed = "failed: " + report.EmailError
+		case report.EmailedAt != nil:
+			emailed = report.EmailedTo
+		}
+		fmt.Printf("  #%-5d %-12s %s  paid %d (%.2f)  failed %d  expired %d  email %s\n",
+			report.ID, report.MerchantID, report.ReportDate, report.PaidCount, report.PaidAmount,
+			report.FailedCount, report.ExpiredCount, emailed)
+	}
+	if len(resp.Failed) > 0 {
+		for _, failure := range resp.Failed {
+			fmt.Printf("  FAILED %-12s %s\n", failure.MerchantID, failure.Error)
+		}
+		fatal("Daily report failed for some merchants", "failed", len(resp.Failed))
+	}
+}
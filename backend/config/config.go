@@ -0,0 +1,535 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"qr-service/pkg/provider"
+	"qr-service/pkg/ratelimit"
+	"qr-service/pkg/util"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config seluruh konfigurasi aplikasi. Urutan prioritas: nilai default, lalu file YAML
+// dari CONFIG_FILE (opsional), lalu environment variable. Binary yang sama bisa dipakai
+// di dev, staging dan prod cukup dengan file/env yang berbeda.
+type Config struct {
+	Server    ServerConfig    `yaml:"server"`
+	Database  DatabaseConfig  `yaml:"database"`
+	Security  SecurityConfig  `yaml:"security"`
+	Mail      MailConfig      `yaml:"mail"`
+	Report    ReportConfig    `yaml:"report"`
+	Tracing   TracingConfig   `yaml:"tracing"`
+	Log       LogConfig       `yaml:"log"`
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Reference ReferenceConfig `yaml:"reference"`
+	Providers ProvidersConfig `yaml:"providers"`
+
+	// StatusMapping tabel status per payment gateway, key nama provider (lihat Providers).
+	// Tabel gateway melengkapi tabel bawaan; gateway "default" mengubah tabel bawaan.
+	StatusMapping map[string]map[string]string `yaml:"status_mapping"`
+}
+
+type ServerConfig struct {
+	Port        int      `yaml:"port"`         // PORT
+	PublicURL   string   `yaml:"public_url"`   // PUBLIC_URL, dipakai untuk host Swagger dan URL WebSocket
+	CORSOrigins []string `yaml:"cors_origins"` // CORS_ORIGINS, dipisah koma
+
+	// ShutdownTimeout batas waktu menyelesaikan request yang sedang berjalan saat SIGTERM (SHUTDOWN_TIMEOUT)
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+
+	// HealthCheckTimeout batas waktu setiap pemeriksaan komponen di /readyz (HEALTH_CHECK_TIMEOUT)
+	HealthCheckTimeout time.Duration `yaml:"health_check_timeout"`
+}
+
+type DatabaseConfig struct {
+	URL           string        `yaml:"url"`            // DATABASE_URL
+	MaxRetries    int           `yaml:"max_retries"`    // DB_MAX_RETRIES
+	RetryInterval time.Duration `yaml:"retry_interval"` // DB_RETRY_INTERVAL, contoh "5s"
+}
+
+type SecurityConfig struct {
+	HMACSecret  string `yaml:"hmac_secret"`   // HMAC_SECRET
+	AdminAPIKey string `yaml:"admin_api_key"` // ADMIN_API_KEY, header X-API-KEY route admin; kosong = route admin ditolak
+
+	// MerchantAPIKeys key X-API-KEY per merchant (merchantId -> key) untuk laporan dan inquiry
+	// status transaksi merchant sendiri
+	MerchantAPIKeys map[string]string `yaml:"merchant_api_keys"`
+}
+
+// MailConfig SMTP untuk laporan harian. Host kosong berarti email dimatikan.
+type MailConfig struct {
+	Host     string `yaml:"host"`     // SMTP_HOST
+	Port     string `yaml:"port"`     // SMTP_PORT
+	Username string `yaml:"username"` // SMTP_USERNAME
+	Password string `yaml:"password"` // SMTP_PASSWORD
+	From     string `yaml:"from"`     // SMTP_FROM
+}
+
+// TracingConfig OpenTelemetry. Endpoint kosong berarti span tidak diekspor, tapi trace
+// context dari request tetap diteruskan.
+type TracingConfig struct {
+	Endpoint    string  `yaml:"otlp_endpoint"` // OTEL_EXPORTER_OTLP_ENDPOINT, contoh http://localhost:4318
+	ServiceName string  `yaml:"service_name"`  // OTEL_SERVICE_NAME
+	SampleRatio float64 `yaml:"sample_ratio"`  // OTEL_TRACES_SAMPLER_ARG, 0..1 untuk root span
+}
+
+// LogConfig log terstruktur (slog)
+type LogConfig struct {
+	Level  string `yaml:"level"`  // LOG_LEVEL: debug, info, warn atau error
+	Format string `yaml:"format"` // LOG_FORMAT: json atau text (lebih mudah dibaca saat development)
+}
+
+// RateLimitConfig token bucket per group route. Key menentukan siapa yang berbagi budget:
+// partner (X-PARTNER-ID), merchant (merchantId di path, query atau body) atau ip.
+// Request tanpa partner/merchant memakai IP.
+type RateLimitConfig struct {
+	Enabled bool                     `yaml:"enabled"` // RATE_LIMIT_ENABLED
+	Store   string                   `yaml:"store"`   // RATE_LIMIT_STORE: memory (per instance) atau postgres (bersama)
+	Groups  map[string]RateLimitRule `yaml:"groups"`  // qr, callbacks, transactions, api (route /api/v1 lainnya)
+}
+
+type RateLimitRule struct {
+	Key               string  `yaml:"key"`
+	RequestsPerMinute float64 `yaml:"requests_per_minute"`
+	Burst             int     `yaml:"burst"`
+}
+
+// Group route yang bisa diberi rate limit
+var RateLimitGroups = []string{"qr", "callbacks", "transactions", "api"}
+
+// Rules rule per group untuk ratelimit.Limiter
+func (r RateLimitConfig) Rules() map[string]ratelimit.Rule {
+	rules := make(map[string]ratelimit.Rule, len(r.Groups))
+	for group, rule := range r.Groups {
+		rules[group] = ratelimit.PerMinute(rule.RequestsPerMinute, rule.Burst)
+	}
+	return rules
+}
+
+// ReferenceConfig pembuatan ReferenceNo internal. Setiap instance yang berjalan bersamaan
+// harus memakai node_id berbeda agar ReferenceNo tidak bentrok.
+type ReferenceConfig struct {
+	NodeID           int               `yaml:"node_id"`           // REFERENCE_NODE_ID, 0..1023
+	Prefix           string            `yaml:"prefix"`            // REFERENCE_PREFIX
+	CheckDigit       bool              `yaml:"check_digit"`       // REFERENCE_CHECK_DIGIT, digit Luhn di akhir
+	MerchantPrefixes map[string]string `yaml:"merchant_prefixes"` // merchantId -> prefix
+	ChannelPrefixes  map[string]string `yaml:"channel_prefixes"`  // CHANNEL-ID -> prefix
+}
+
+// ProvidersConfig acquirer/payment gateway yang aktif. Callback provider diterima di
+// POST /api/v1/callbacks/{nama}; /api/v1/qr/payment tetap melayani provider default.
+type ProvidersConfig struct {
+	Default   string                    `yaml:"default"`   // PAYMENT_PROVIDER
+	Gateways  map[string]ProviderConfig `yaml:"gateways"`  // nama -> konfigurasi adapter
+	Merchants map[string]string         `yaml:"merchants"` // merchantId -> nama provider untuk QR baru
+}
+
+type ProviderConfig struct {
+	Type       string `yaml:"type"`        // adapter, saat ini hanya snap
+	HMACSecret string `yaml:"hmac_secret"` // secret callback dan inquiry, kosong: security.hmac_secret
+	QRTemplate string `yaml:"qr_template"` // payload QR sampai tag 61 dengan %s untuk merchant ID, kosong: template bawaan
+	InquiryURL string `yaml:"inquiry_url"` // endpoint Query Payment, kosong: inquiry tidak didukung
+	PartnerID  string `yaml:"partner_id"`  // X-PARTNER-ID saat inquiry
+}
+
+// ProviderTypes adapter provider yang tersedia
+var ProviderTypes = []string{provider.TypeSNAP}
+
+// providerName nama provider dipakai di path callback
+var providerName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+type ReportConfig struct {
+	RunAt string `yaml:"run_at"` // REPORT_RUN_AT, HH:MM WIB atau "off"
+}
+
+// Default konfigurasi untuk development lokal (docker-compose)
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Port:      8000,
+			PublicURL: "http://localhost:8000",
+			CORSOrigins: []string{
+				"http://localhost:3000", "http://127.0.0.1:3000",
+				"http://localhost:5173", "http://127.0.0.1:5173",
+				"http://0.0.0.0:8081",
+			},
+			ShutdownTimeout:    15 * time.Second,
+			HealthCheckTimeout: 2 * time.Second,
+		},
+		Database: DatabaseConfig{
+			MaxRetries:    5,
+			RetryInterval: 5 * time.Second,
+		},
+		Mail: MailConfig{
+			Port: "587",
+			From: "reports@qr-service.local",
+		},
+		Report: ReportConfig{
+			RunAt: "00:05",
+		},
+		Tracing: TracingConfig{
+			ServiceName: "qr-service",
+			SampleRatio: 1,
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "json",
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: true,
+			Store:   "memory",
+			Groups: map[string]RateLimitRule{
+				"qr":           {Key: ratelimit.KeyPartner, RequestsPerMinute: 600, Burst: 60},
+				"callbacks":    {Key: ratelimit.KeyIP, RequestsPerMinute: 600, Burst: 60},
+				"transactions": {Key: ratelimit.KeyIP, RequestsPerMinute: 120, Burst: 30},
+				"api":          {Key: ratelimit.KeyIP, RequestsPerMinute: 300, Burst: 60},
+			},
+		},
+		Reference: ReferenceConfig{
+			Prefix: "A",
+		},
+		Providers: ProvidersConfig{
+			Default: provider.DefaultName,
+			Gateways: map[string]ProviderConfig{
+				provider.DefaultName: {Type: provider.TypeSNAP},
+			},
+		},
+	}
+}
+
+// Load membaca konfigurasi dari CONFIG_FILE (jika diset) dan environment variable, lalu memvalidasinya
+func Load() (*Config, error) {
+	cfg := Default()
+
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if err := cfg.loadEnv(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// MustLoad seperti Load, tapi menghentikan proses jika konfigurasi tidak valid
+func MustLoad() *Config {
+	cfg, err := Load()
+	if err != nil {
+		slog.Error("Invalid configuration", "error", err)
+		os.Exit(1)
+	}
+	return cfg
+}
+
+func (cfg *Config) loadFile(path string) error {
+	if ext := strings.ToLower(filepath.Ext(path)); ext != ".yaml" && ext != ".yml" {
+		return fmt.Errorf("config file %s: unsupported format, use .yaml or .yml", path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true) // typo di nama key langsung ketahuan
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+func (cfg *Config) loadEnv() error {
+	setString(&cfg.Server.PublicURL, "PUBLIC_URL")
+	setString(&cfg.Database.URL, "DATABASE_URL")
+	setString(&cfg.Security.HMACSecret, "HMAC_SECRET")
+	setString(&cfg.Security.AdminAPIKey, "ADMIN_API_KEY")
+	setString(&cfg.Mail.Host, "SMTP_HOST")
+	setString(&cfg.Mail.Port, "SMTP_PORT")
+	setString(&cfg.Mail.Username, "SMTP_USERNAME")
+	setString(&cfg.Mail.Password, "SMTP_PASSWORD")
+	setString(&cfg.Mail.From, "SMTP_FROM")
+	setString(&cfg.Report.RunAt, "REPORT_RUN_AT")
+	setString(&cfg.Tracing.Endpoint, "OTEL_EXPORTER_OTLP_ENDPOINT")
+	setString(&cfg.Tracing.ServiceName, "OTEL_SERVICE_NAME")
+	setString(&cfg.Log.Level, "LOG_LEVEL")
+	setString(&cfg.Log.Format, "LOG_FORMAT")
+	setString(&cfg.RateLimit.Store, "RATE_LIMIT_STORE")
+	setString(&cfg.Reference.Prefix, "REFERENCE_PREFIX")
+	setString(&cfg.Providers.Default, "PAYMENT_PROVIDER")
+
+	if value := os.Getenv("CORS_ORIGINS"); value != "" {
+		cfg.Server.CORSOrigins = nil
+		for _, origin := range strings.Split(value, ",") {
+			if origin = strings.TrimSpace(origin); origin != "" {
+				cfg.Server.CORSOrigins = append(cfg.Server.CORSOrigins, origin)
+			}
+		}
+	}
+
+	var errs []error
+	if value := os.Getenv("PORT"); value != "" {
+		port, err := strconv.Atoi(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("PORT: %w", err))
+		}
+		cfg.Server.Port = port
+	}
+	if value := os.Getenv("DB_MAX_RETRIES"); value != "" {
+		retries, err := strconv.Atoi(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("DB_MAX_RETRIES: %w", err))
+		}
+		cfg.Database.MaxRetries = retries
+	}
+	if value := os.Getenv("DB_RETRY_INTERVAL"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("DB_RETRY_INTERVAL: %w", err))
+		}
+		cfg.Database.RetryInterval = interval
+	}
+	if value := os.Getenv("OTEL_TRACES_SAMPLER_ARG"); value != "" {
+		ratio, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("OTEL_TRACES_SAMPLER_ARG: %w", err))
+		}
+		cfg.Tracing.SampleRatio = ratio
+	}
+	if value := os.Getenv("SHUTDOWN_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("SHUTDOWN_TIMEOUT: %w", err))
+		}
+		cfg.Server.ShutdownTimeout = timeout
+	}
+	if value := os.Getenv("RATE_LIMIT_ENABLED"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("RATE_LIMIT_ENABLED: %w", err))
+		}
+		cfg.RateLimit.Enabled = enabled
+	}
+	if value := os.Getenv("HEALTH_CHECK_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("HEALTH_CHECK_TIMEOUT: %w", err))
+		}
+		cfg.Server.HealthCheckTimeout = timeout
+	}
+	if value := os.Getenv("REFERENCE_NODE_ID"); value != "" {
+		nodeID, err := strconv.Atoi(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("REFERENCE_NODE_ID: %w", err))
+		}
+		cfg.Reference.NodeID = nodeID
+	}
+	if value := os.Getenv("REFERENCE_CHECK_DIGIT"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("REFERENCE_CHECK_DIGIT: %w", err))
+		}
+		cfg.Reference.CheckDigit = enabled
+	}
+	return errors.Join(errs...)
+}
+
+func setString(target *string, key string) {
+	if value := os.Getenv(key); value != "" {
+		*target = value
+	}
+}
+
+// Validate mengumpulkan semua kesalahan konfigurasi sekaligus
+func (cfg *Config) Validate() error {
+	var errs []error
+	if cfg.Server.Port <= 0 || cfg.Server.Port > 65535 {
+		errs = append(errs, fmt.Errorf("server.port must be between 1 and 65535, got %d", cfg.Server.Port))
+	}
+	if u, err := url.Parse(cfg.Server.PublicURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, fmt.Errorf("server.public_url must be an absolute http(s) URL, got %q", cfg.Server.PublicURL))
+	}
+	if cfg.Server.ShutdownTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("server.shutdown_timeout must be positive, got %s", cfg.Server.ShutdownTimeout))
+	}
+	if cfg.Server.HealthCheckTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("server.health_check_timeout must be positive, got %s", cfg.Server.HealthCheckTimeout))
+	}
+	if cfg.Database.URL == "" {
+		errs = append(errs, errors.New("DATABASE_URL is required"))
+	}
+	if cfg.Database.MaxRetries < 1 {
+		errs = append(errs, fmt.Errorf("database.max_retries must be at least 1, got %d", cfg.Database.MaxRetries))
+	}
+	if cfg.Database.RetryInterval < 0 {
+		errs = append(errs, fmt.Errorf("database.retry_interval must not be negative, got %s", cfg.Database.RetryInterval))
+	}
+	if cfg.Security.HMACSecret == "" {
+		errs = append(errs, errors.New("HMAC_SECRET is required"))
+	}
+	if cfg.Tracing.Endpoint != "" {
+		if u, err := url.Parse(cfg.Tracing.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("tracing.otlp_endpoint must be an absolute http(s) URL, got %q", cfg.Tracing.Endpoint))
+		}
+	}
+	if cfg.Tracing.SampleRatio < 0 || cfg.Tracing.SampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("tracing.sample_ratio must be between 0 and 1, got %v", cfg.Tracing.SampleRatio))
+	}
+	if _, err := cfg.Log.SlogLevel(); err != nil {
+		errs = append(errs, fmt.Errorf("log.level must be debug, info, warn or error, got %q", cfg.Log.Level))
+	}
+	if cfg.Log.Format != "json" && cfg.Log.Format != "text" {
+		errs = append(errs, fmt.Errorf("log.format must be json or text, got %q", cfg.Log.Format))
+	}
+	if err := cfg.RateLimit.validate(); err != nil {
+		errs = append(errs, err)
+	}
+	for gateway, mapping := range cfg.StatusMapping {
+		for external, internal := range mapping {
+			if !slices.Contains(util.InternalStatuses, internal) {
+				errs = append(errs, fmt.Errorf("status_mapping.%s.%s must be one of %v, got %q", gateway, external, util.InternalStatuses, internal))
+			}
+		}
+	}
+	if err := cfg.Providers.validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := cfg.Reference.validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if _, _, err := cfg.Report.Schedule(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func (r RateLimitConfig) validate() error {
+	var errs []error
+	if r.Store != "memory" && r.Store != "postgres" {
+		errs = append(errs, fmt.Errorf("rate_limit.store must be memory or postgres, got %q", r.Store))
+	}
+	for group, rule := range r.Groups {
+		if !slices.Contains(RateLimitGroups, group) {
+			errs = append(errs, fmt.Errorf("rate_limit.groups: unknown group %q, use one of %v", group, RateLimitGroups))
+			continue
+		}
+		if rule.Key != ratelimit.KeyPartner && rule.Key != ratelimit.KeyMerchant && rule.Key != ratelimit.KeyIP {
+			errs = append(errs, fmt.Errorf("rate_limit.groups.%s.key must be partner, merchant or ip, got %q", group, rule.Key))
+		}
+		if rule.RequestsPerMinute <= 0 || rule.Burst < 1 {
+			errs = append(errs, fmt.Errorf("rate_limit.groups.%s needs requests_per_minute > 0 and burst >= 1", group))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p ProvidersConfig) validate() error {
+	var errs []error
+	if _, ok := p.Gateways[p.Default]; !ok {
+		errs = append(errs, fmt.Errorf("providers.default %q is not defined in providers.gateways", p.Default))
+	}
+	for name, gateway := range p.Gateways {
+		if !providerName.MatchString(name) {
+			errs = append(errs, fmt.Errorf("providers.gateways: invalid name %q, use lowercase letters, digits, - or _", name))
+		}
+		if !slices.Contains(ProviderTypes, gateway.Type) {
+			errs = append(errs, fmt.Errorf("providers.gateways.%s.type must be one of %v, got %q", name, ProviderTypes, gateway.Type))
+		}
+		if gateway.QRTemplate != "" && strings.Count(gateway.QRTemplate, "%s") != 1 {
+			errs = append(errs, fmt.Errorf("providers.gateways.%s.qr_template must contain exactly one %%s for the merchant ID", name))
+		}
+		if gateway.InquiryURL != "" {
+			if u, err := url.Parse(gateway.InquiryURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				errs = append(errs, fmt.Errorf("providers.gateways.%s.inquiry_url must be an absolute http(s) URL, got %q", name, gateway.InquiryURL))
+			}
+		}
+	}
+	for merchantID, name := range p.Merchants {
+		if _, ok := p.Gateways[name]; !ok {
+			errs = append(errs, fmt.Errorf("providers.merchants.%s: provider %q is not defined in providers.gateways", merchantID, name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// referencePrefix 1-4 huruf besar/angka dan diawali huruf, agar ReferenceNo muat di
+// QR tag 62 (maks. 25 karakter) dan tidak tertukar dengan bagian numerik
+var referencePrefix = regexp.MustCompile(`^[A-Z][A-Z0-9]{0,3}$`)
+
+func (r ReferenceConfig) validate() error {
+	var errs []error
+	if r.NodeID < 0 || r.NodeID > util.MaxReferenceNodeID {
+		errs = append(errs, fmt.Errorf("reference.node_id must be between 0 and %d, got %d", util.MaxReferenceNodeID, r.NodeID))
+	}
+	if !referencePrefix.MatchString(r.Prefix) {
+		errs = append(errs, fmt.Errorf("reference.prefix must be 1-4 uppercase letters or digits starting with a letter, got %q", r.Prefix))
+	}
+	for merchantID, prefix := range r.MerchantPrefixes {
+		if !referencePrefix.MatchString(prefix) {
+			errs = append(errs, fmt.Errorf("reference.merchant_prefixes.%s: invalid prefix %q", merchantID, prefix))
+		}
+	}
+	for channelID, prefix := range r.ChannelPrefixes {
+		if !referencePrefix.MatchString(prefix) {
+			errs = append(errs, fmt.Errorf("reference.channel_prefixes.%s: invalid prefix %q", channelID, prefix))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Addr alamat listen HTTP, contoh ":8000"
+func (s ServerConfig) Addr() string {
+	return ":" + strconv.Itoa(s.Port)
+}
+
+// Host host:port dari PublicURL, dipakai sebagai host Swagger
+func (s ServerConfig) Host() string {
+	u, _ := url.Parse(s.PublicURL)
+	return u.Host
+}
+
+// WebSocketURL endpoint WebSocket publik, contoh "ws://localhost:8000/ws"
+func (s ServerConfig) WebSocketURL() string {
+	u, _ := url.Parse(s.PublicURL)
+	scheme := "ws"
+	if u.Scheme == "https" {
+		scheme = "wss"
+	}
+	return scheme + "://" + u.Host + strings.TrimSuffix(u.Path, "/") + "/ws"
+}
+
+// SlogLevel level log sebagai slog.Level
+func (l LogConfig) SlogLevel() (slog.Level, error) {
+	var level slog.Level
+	err := level.UnmarshalText([]byte(l.Level))
+	return level, err
+}
+
+// Schedule jam scheduler laporan harian (durasi sejak 00:00 WIB). enabled false jika RunAt "off".
+func (r ReportConfig) Schedule() (runAt time.Duration, enabled bool, err error) {
+	if r.RunAt == "off" {
+		return 0, false, nil
+	}
+	t, err := time.Parse("15:04", r.RunAt)
+	if err != nil {
+		return 0, false, fmt.Errorf("report.run_at must be HH:MM or off, got %q", r.RunAt)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, true, nil
+}
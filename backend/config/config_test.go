@@ -0,0 +1,120 @@
+package config
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadEnvOverridesFile(t *testing.T) {
+	for _, key := range []string{"PORT", "PUBLIC_URL", "HMAC_SECRET", "REPORT_RUN_AT", "DB_MAX_RETRIES", "DB_RETRY_INTERVAL", "SHUTDOWN_TIMEOUT", "HEALTH_CHECK_TIMEOUT", "OTEL_TRACES_SAMPLER_ARG", "LOG_FORMAT", "REFERENCE_PREFIX", "PAYMENT_PROVIDER"} {
+		t.Setenv(key, "")
+	}
+	t.Setenv("CONFIG_FILE", writeConfigFile(t, `
+server:
+  port: 9000
+  public_url: https://qr.example.com
+database:
+  url: host=file-db
+  retry_interval: 2s
+security:
+  hmac_secret: from-file
+report:
+  run_at: "01:30"
+log:
+  format: text
+status_mapping:
+  GW-1:
+    SETTLED: PAID
+    "99": FAILED
+providers:
+  gateways:
+    acme:
+      type: snap
+      inquiry_url: https://acme.example.com/v1.0/qr/qr-mpm-query
+  merchants:
+    M002: acme
+reference:
+  prefix: Q
+  merchant_prefixes:
+    M001: MX
+`))
+	t.Setenv("DATABASE_URL", "host=env-db")
+	t.Setenv("LOG_LEVEL", "debug")
+	t.Setenv("CORS_ORIGINS", "https://a.example.com, https://b.example.com")
+	t.Setenv("REFERENCE_NODE_ID", "3")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Server.Addr() != ":9000" || cfg.Database.URL != "host=env-db" || cfg.Security.HMACSecret != "from-file" {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+	if cfg.Database.RetryInterval != 2*time.Second || cfg.Database.MaxRetries != 5 {
+		t.Fatalf("database = %+v", cfg.Database)
+	}
+	if len(cfg.Server.CORSOrigins) != 2 || cfg.Server.CORSOrigins[1] != "https://b.example.com" {
+		t.Fatalf("cors origins = %v", cfg.Server.CORSOrigins)
+	}
+	if cfg.Server.Host() != "qr.example.com" || cfg.Server.WebSocketURL() != "wss://qr.example.com/ws" {
+		t.Fatalf("host %q, websocket %q", cfg.Server.Host(), cfg.Server.WebSocketURL())
+	}
+	if runAt, enabled, _ := cfg.Report.Schedule(); !enabled || runAt != 90*time.Minute {
+		t.Fatalf("schedule = %s %v", runAt, enabled)
+	}
+	if level, _ := cfg.Log.SlogLevel(); level != slog.LevelDebug || cfg.Log.Format != "text" {
+		t.Fatalf("log = %+v", cfg.Log)
+	}
+	if cfg.StatusMapping["GW-1"]["99"] != "FAILED" {
+		t.Fatalf("status mapping = %v", cfg.StatusMapping)
+	}
+	if cfg.Providers.Default != "manjo" || cfg.Providers.Gateways["acme"].Type != "snap" || cfg.Providers.Merchants["M002"] != "acme" {
+		t.Fatalf("providers = %+v", cfg.Providers)
+	}
+	if cfg.Reference.NodeID != 3 || cfg.Reference.Prefix != "Q" || cfg.Reference.MerchantPrefixes["M001"] != "MX" {
+		t.Fatalf("reference = %+v", cfg.Reference)
+	}
+}
+
+func TestLoadRejectsInvalidConfig(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("DATABASE_URL", "host=db")
+	t.Setenv("HMAC_SECRET", "")
+	t.Setenv("REPORT_RUN_AT", "25:99")
+	t.Setenv("LOG_LEVEL", "verbose")
+	t.Setenv("RATE_LIMIT_STORE", "redis")
+	t.Setenv("REFERENCE_NODE_ID", "1024")
+	t.Setenv("REFERENCE_PREFIX", "a-1")
+	t.Setenv("PAYMENT_PROVIDER", "acme")
+
+	_, err := Load()
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	for _, want := range []string{"HMAC_SECRET is required", "report.run_at", "log.level", "rate_limit.store", "reference.node_id", "reference.prefix", "providers.default"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
+
+func TestLoadRejectsUnknownFileKey(t *testing.T) {
+	t.Setenv("CONFIG_FILE", writeConfigFile(t, "server:\n  prot: 9000\n"))
+
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "prot") {
+		t.Fatalf("expected unknown key error, got %v", err)
+	}
+}
@@ -1,35 +1,37 @@
 package config
 
 import (
-	"log"
+	"log/slog"
 	"os"
 	"time"
 
+	"qr-service/pkg/logging"
+
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-func SetupDatabase() *gorm.DB {
-	dbURL := os.Getenv("DATABASE_URL")
-	if dbURL == "" {
-		log.Fatal("DATABASE_URL not set in environment")
-	}
+// SetupDatabase membuka koneksi Postgres dengan retry. Query dicatat lewat slog default
+// (lihat SetupLogging): semua query di level debug, selain itu hanya query lambat dan error.
+func SetupDatabase(cfg DatabaseConfig, logCfg LogConfig) *gorm.DB {
+	level, _ := logCfg.SlogLevel()
+	gormConfig := &gorm.Config{Logger: logging.GormLogger(slog.Default(), level)}
 
 	// Coba koneksi berulang kali (penting untuk Docker Compose)
 	var db *gorm.DB
 	var err error
-	maxRetries := 5
-	
-	for i := 0; i < maxRetries; i++ {
-		db, err = gorm.Open(postgres.Open(dbURL), &gorm.Config{})
+
+	for i := 0; i < cfg.MaxRetries; i++ {
+		db, err = gorm.Open(postgres.Open(cfg.URL), gormConfig)
 		if err == nil {
-			log.Println("Database connection successful")
+			slog.Info("Database connection successful")
 			return db
 		}
-		log.Printf("Failed to connect to database (Attempt %d/%d): %v", i+1, maxRetries, err)
-		time.Sleep(5 * time.Second) // Tunggu 5 detik sebelum coba lagi
+		slog.Warn("Failed to connect to database", "attempt", i+1, "max_retries", cfg.MaxRetries, "error", err)
+		time.Sleep(cfg.RetryInterval) // Tunggu sebelum coba lagi
 	}
 
-	log.Fatalf("Exceeded max database connection retries. Final error: %v", err)
+	slog.Error("Exceeded max database connection retries", "error", err)
+	os.Exit(1)
 	return nil // Tidak akan tercapai
-}
\ No newline at end of file
+}
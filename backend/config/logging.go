@@ -0,0 +1,14 @@
+package config
+
+import (
+	"log/slog"
+
+	"qr-service/pkg/logging"
+)
+
+// SetupLogging memasang slog default sesuai LogConfig. Dipanggil sebelum SetupDatabase
+// agar logger GORM ikut memakai logger ini.
+func SetupLogging(cfg LogConfig) *slog.Logger {
+	level, _ := cfg.SlogLevel() // sudah divalidasi di Load
+	return logging.Setup(logging.Options{Level: level, Format: cfg.Format})
+}
@@ -0,0 +1,17 @@
+package config
+
+import (
+	"log/slog"
+
+	"qr-service/pkg/mail"
+)
+
+// SetupMailSender membuat SMTP sender dari MailConfig.
+// Mengembalikan nil jika host kosong, artinya laporan tidak dikirim via email.
+func SetupMailSender(cfg MailConfig) mail.Sender {
+	if cfg.Host == "" {
+		slog.Info("SMTP_HOST not set, daily report emails are disabled")
+		return nil
+	}
+	return mail.NewSMTPSender(cfg.Host+":"+cfg.Port, cfg.Username, cfg.Password, cfg.From)
+}
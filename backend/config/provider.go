@@ -0,0 +1,28 @@
+package config
+
+import "qr-service/pkg/provider"
+
+// SetupProviders membuat registry provider dari ProvidersConfig. Provider tanpa hmac_secret
+// memakai security.hmac_secret, sama dengan /api/v1/qr/payment.
+func SetupProviders(cfg ProvidersConfig, security SecurityConfig) *provider.Registry {
+	registry := provider.NewRegistry()
+	for name, gateway := range cfg.Gateways {
+		secret := gateway.HMACSecret
+		if secret == "" {
+			secret = security.HMACSecret
+		}
+		// Type sudah divalidasi, snap satu-satunya adapter
+		registry.Register(provider.NewSNAP(provider.SNAPOptions{
+			Name:       name,
+			HMACSecret: secret,
+			QRTemplate: gateway.QRTemplate,
+			InquiryURL: gateway.InquiryURL,
+			PartnerID:  gateway.PartnerID,
+		}))
+	}
+	registry.SetDefault(cfg.Default)
+	for merchantID, name := range cfg.Merchants {
+		registry.Route(merchantID, name)
+	}
+	return registry
+}
@@ -0,0 +1,14 @@
+package config
+
+import "qr-service/pkg/util"
+
+// SetupReferenceGenerator membuat generator ReferenceNo dari ReferenceConfig
+func SetupReferenceGenerator(cfg ReferenceConfig) util.ReferenceGenerator {
+	return util.NewReferenceGenerator(util.ReferenceOptions{
+		NodeID:           cfg.NodeID,
+		Prefix:           cfg.Prefix,
+		MerchantPrefixes: cfg.MerchantPrefixes,
+		ChannelPrefixes:  cfg.ChannelPrefixes,
+		CheckDigit:       cfg.CheckDigit,
+	})
+}
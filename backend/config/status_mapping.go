@@ -0,0 +1,12 @@
+package config
+
+import "qr-service/pkg/util"
+
+// SetupStatusMapper membuat pemetaan status callback per gateway dari konfigurasi status_mapping
+func SetupStatusMapper(gateways map[string]map[string]string) util.StatusMapper {
+	mappings := make(map[string]util.StatusMapping, len(gateways))
+	for gateway, mapping := range gateways {
+		mappings[gateway] = mapping
+	}
+	return util.NewStatusMapper(mappings)
+}
@@ -0,0 +1,17 @@
+// Package database berisi migration schema Postgres yang di-embed ke binary.
+package database
+
+import (
+	"embed"
+	"qr-service/pkg/migrate"
+
+	"gorm.io/gorm"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// NewMigrator membuat migrator dari file di database/migrations
+func NewMigrator(db *gorm.DB) (*migrate.Migrator, error) {
+	return migrate.New(db, migrationFiles, "migrations")
+}
@@ -0,0 +1,21 @@
+-- Tabel transaksi QR. IF NOT EXISTS agar database lama hasil AutoMigrate bisa diadopsi.
+CREATE TABLE IF NOT EXISTS transactions (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    merchant_id TEXT NOT NULL,
+    amount NUMERIC NOT NULL,
+    trx_id TEXT NOT NULL,
+    partner_reference_no TEXT NOT NULL,
+    reference_no TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'PENDING',
+    transaction_date TIMESTAMPTZ,
+    paid_date TIMESTAMPTZ,
+    currency TEXT NOT NULL DEFAULT 'IDR',
+    CONSTRAINT uni_transactions_trx_id UNIQUE (trx_id),
+    CONSTRAINT uni_transactions_partner_reference_no UNIQUE (partner_reference_no),
+    CONSTRAINT uni_transactions_reference_no UNIQUE (reference_no)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_deleted_at ON transactions (deleted_at);
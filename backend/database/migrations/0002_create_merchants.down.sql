@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_transactions_terminal_id;
+DROP INDEX IF EXISTS idx_transactions_outlet_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS terminal_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS outlet_id;
+
+DROP TABLE IF EXISTS terminals;
+DROP TABLE IF EXISTS outlets;
+DROP TABLE IF EXISTS merchants;
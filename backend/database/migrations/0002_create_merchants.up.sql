@@ -0,0 +1,47 @@
+CREATE TABLE IF NOT EXISTS merchants (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    merchant_id TEXT NOT NULL,
+    name TEXT,
+    category TEXT NOT NULL DEFAULT 'UMI',
+    CONSTRAINT uni_merchants_merchant_id UNIQUE (merchant_id)
+);
+CREATE INDEX IF NOT EXISTS idx_merchants_deleted_at ON merchants (deleted_at);
+
+CREATE TABLE IF NOT EXISTS outlets (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    outlet_id TEXT NOT NULL,
+    merchant_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    address TEXT,
+    CONSTRAINT uni_outlets_outlet_id UNIQUE (outlet_id)
+);
+CREATE INDEX IF NOT EXISTS idx_outlets_deleted_at ON outlets (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_outlets_merchant_id ON outlets (merchant_id);
+
+CREATE TABLE IF NOT EXISTS terminals (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    terminal_id TEXT NOT NULL,
+    outlet_id TEXT NOT NULL,
+    merchant_id TEXT NOT NULL,
+    name TEXT,
+    CONSTRAINT uni_terminals_terminal_id UNIQUE (terminal_id),
+    CONSTRAINT fk_outlets_terminals FOREIGN KEY (outlet_id) REFERENCES outlets (outlet_id)
+);
+CREATE INDEX IF NOT EXISTS idx_terminals_deleted_at ON terminals (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_terminals_outlet_id ON terminals (outlet_id);
+CREATE INDEX IF NOT EXISTS idx_terminals_merchant_id ON terminals (merchant_id);
+
+-- Hierarki merchant > outlet > terminal di transaksi
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS outlet_id TEXT;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS terminal_id TEXT;
+CREATE INDEX IF NOT EXISTS idx_transactions_outlet_id ON transactions (outlet_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_terminal_id ON transactions (terminal_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS risk_rule_hits;
+DROP TABLE IF EXISTS risk_limits;
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS risk_limits (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    merchant_id TEXT NOT NULL,
+    min_amount NUMERIC,
+    max_amount NUMERIC,
+    daily_volume_cap NUMERIC,
+    monthly_volume_cap NUMERIC,
+    max_generates_per_minute BIGINT,
+    max_terminal_generates_per_minute BIGINT,
+    monitor_only BOOLEAN,
+    CONSTRAINT uni_risk_limits_merchant_id UNIQUE (merchant_id)
+);
+CREATE INDEX IF NOT EXISTS idx_risk_limits_deleted_at ON risk_limits (deleted_at);
+
+CREATE TABLE IF NOT EXISTS risk_rule_hits (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    merchant_id TEXT NOT NULL,
+    outlet_id TEXT,
+    terminal_id TEXT,
+    partner_reference_no TEXT,
+    amount NUMERIC,
+    rule TEXT NOT NULL,
+    detail TEXT,
+    enforced BOOLEAN
+);
+CREATE INDEX IF NOT EXISTS idx_risk_rule_hits_deleted_at ON risk_rule_hits (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_risk_rule_hits_merchant_id ON risk_rule_hits (merchant_id);
+CREATE INDEX IF NOT EXISTS idx_risk_rule_hits_rule ON risk_rule_hits (rule);
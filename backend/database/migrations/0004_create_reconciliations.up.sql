@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS reconciliation_runs (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    file_name TEXT,
+    format TEXT NOT NULL,
+    period_start TIMESTAMPTZ,
+    period_end TIMESTAMPTZ,
+    total_records BIGINT,
+    matched BIGINT,
+    missing_ours BIGINT,
+    missing_theirs BIGINT,
+    amount_mismatch BIGINT,
+    corrected BIGINT
+);
+CREATE INDEX IF NOT EXISTS idx_reconciliation_runs_deleted_at ON reconciliation_runs (deleted_at);
+
+CREATE TABLE IF NOT EXISTS reconciliation_items (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    run_id BIGINT NOT NULL,
+    category TEXT NOT NULL,
+    line BIGINT,
+    reference_no TEXT,
+    partner_reference_no TEXT,
+    our_amount NUMERIC,
+    their_amount NUMERIC,
+    our_status TEXT,
+    their_status TEXT,
+    paid_time TIMESTAMPTZ,
+    corrected BOOLEAN,
+    CONSTRAINT fk_reconciliation_runs_items FOREIGN KEY (run_id) REFERENCES reconciliation_runs (id)
+);
+CREATE INDEX IF NOT EXISTS idx_reconciliation_items_deleted_at ON reconciliation_items (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_reconciliation_items_run_id ON reconciliation_items (run_id);
+CREATE INDEX IF NOT EXISTS idx_reconciliation_items_category ON reconciliation_items (category);
+CREATE INDEX IF NOT EXISTS idx_reconciliation_items_reference_no ON reconciliation_items (reference_no);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_transactions_settlement_batch_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS settled_at;
+ALTER TABLE transactions DROP COLUMN IF EXISTS settlement_batch_id;
+
+DROP TABLE IF EXISTS settlement_lines;
+DROP TABLE IF EXISTS settlement_batches;
+DROP TABLE IF EXISTS fee_rules;
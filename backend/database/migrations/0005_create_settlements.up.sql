@@ -0,0 +1,69 @@
+CREATE TABLE IF NOT EXISTS fee_rules (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    category TEXT NOT NULL,
+    mdr_percent NUMERIC,
+    fixed_fee NUMERIC,
+    exempt_up_to NUMERIC,
+    CONSTRAINT uni_fee_rules_category UNIQUE (category)
+);
+CREATE INDEX IF NOT EXISTS idx_fee_rules_deleted_at ON fee_rules (deleted_at);
+
+-- MDR QRIS default per kategori merchant, tanpa menimpa konfigurasi yang sudah ada
+INSERT INTO fee_rules (created_at, updated_at, category, mdr_percent, fixed_fee, exempt_up_to) VALUES
+    (NOW(), NOW(), 'UMI', 0.3, 0, 0),
+    (NOW(), NOW(), 'UKE', 0.7, 0, 0),
+    (NOW(), NOW(), 'UME', 0.7, 0, 0),
+    (NOW(), NOW(), 'UBE', 0.7, 0, 0),
+    (NOW(), NOW(), 'EDU', 0.6, 0, 0),
+    (NOW(), NOW(), 'SPBU', 0.4, 0, 0),
+    (NOW(), NOW(), 'GOV', 0, 0, 0)
+ON CONFLICT (category) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS settlement_batches (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    batch_no TEXT NOT NULL,
+    merchant_id TEXT NOT NULL,
+    category TEXT,
+    period_start TIMESTAMPTZ,
+    cutoff TIMESTAMPTZ,
+    transaction_count BIGINT,
+    gross_amount NUMERIC,
+    fee_amount NUMERIC,
+    net_amount NUMERIC,
+    currency TEXT NOT NULL DEFAULT 'IDR',
+    CONSTRAINT uni_settlement_batches_batch_no UNIQUE (batch_no)
+);
+CREATE INDEX IF NOT EXISTS idx_settlement_batches_deleted_at ON settlement_batches (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_settlement_batches_merchant_id ON settlement_batches (merchant_id);
+CREATE INDEX IF NOT EXISTS idx_settlement_batches_cutoff ON settlement_batches (cutoff);
+
+CREATE TABLE IF NOT EXISTS settlement_lines (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    batch_id BIGINT NOT NULL,
+    transaction_id BIGINT NOT NULL,
+    reference_no TEXT,
+    terminal_id TEXT,
+    paid_date TIMESTAMPTZ,
+    gross_amount NUMERIC,
+    mdr_percent NUMERIC,
+    fee_amount NUMERIC,
+    net_amount NUMERIC,
+    CONSTRAINT fk_settlement_batches_lines FOREIGN KEY (batch_id) REFERENCES settlement_batches (id)
+);
+CREATE INDEX IF NOT EXISTS idx_settlement_lines_deleted_at ON settlement_lines (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_settlement_lines_batch_id ON settlement_lines (batch_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_settlement_lines_transaction_id ON settlement_lines (transaction_id);
+
+-- Penanda transaksi yang sudah masuk batch settlement
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS settlement_batch_id BIGINT;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS settled_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_transactions_settlement_batch_id ON transactions (settlement_batch_id);
@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS ledger_accounts (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    code TEXT NOT NULL,
+    type TEXT NOT NULL,
+    merchant_id TEXT,
+    CONSTRAINT uni_ledger_accounts_code UNIQUE (code)
+);
+CREATE INDEX IF NOT EXISTS idx_ledger_accounts_deleted_at ON ledger_accounts (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_ledger_accounts_merchant_id ON ledger_accounts (merchant_id);
+
+-- Journal entry dan posting bersifat append-only (tidak ada updated_at/deleted_at)
+CREATE TABLE IF NOT EXISTS journal_entries (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    entry_type TEXT NOT NULL,
+    reference TEXT NOT NULL,
+    merchant_id TEXT,
+    description TEXT,
+    CONSTRAINT uni_journal_entries_reference UNIQUE (reference)
+);
+CREATE INDEX IF NOT EXISTS idx_journal_entries_entry_type ON journal_entries (entry_type);
+CREATE INDEX IF NOT EXISTS idx_journal_entries_merchant_id ON journal_entries (merchant_id);
+
+CREATE TABLE IF NOT EXISTS postings (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    entry_id BIGINT NOT NULL,
+    account_code TEXT NOT NULL,
+    direction TEXT NOT NULL,
+    amount NUMERIC(18,2) NOT NULL,
+    CONSTRAINT fk_journal_entries_postings FOREIGN KEY (entry_id) REFERENCES journal_entries (id)
+);
+CREATE INDEX IF NOT EXISTS idx_postings_entry_id ON postings (entry_id);
+CREATE INDEX IF NOT EXISTS idx_postings_account_code ON postings (account_code);
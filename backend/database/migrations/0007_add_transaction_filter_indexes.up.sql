@@ -0,0 +1,5 @@
+-- Index untuk filter dan sort GET /api/v1/transactions
+CREATE INDEX IF NOT EXISTS idx_transactions_merchant_created_at ON transactions (merchant_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_transactions_created_at ON transactions (created_at);
+CREATE INDEX IF NOT EXISTS idx_transactions_paid_date ON transactions (paid_date);
+CREATE INDEX IF NOT EXISTS idx_transactions_status ON transactions (status);
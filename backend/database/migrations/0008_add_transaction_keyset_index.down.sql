@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS idx_transactions_created_at ON transactions (created_at);
+DROP INDEX IF EXISTS idx_transactions_created_at_id;
@@ -0,0 +1,3 @@
+-- Keyset pagination memakai (created_at, id); index tunggal created_at jadi tidak diperlukan
+CREATE INDEX IF NOT EXISTS idx_transactions_created_at_id ON transactions (created_at, id);
+DROP INDEX IF EXISTS idx_transactions_created_at;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_transactions_rrn;
+DROP INDEX IF EXISTS idx_transactions_customer_pan;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS approval_code;
+ALTER TABLE transactions DROP COLUMN IF EXISTS rrn;
+ALTER TABLE transactions DROP COLUMN IF EXISTS customer_name;
+ALTER TABLE transactions DROP COLUMN IF EXISTS customer_pan;
+ALTER TABLE transactions DROP COLUMN IF EXISTS issuer_name;
+ALTER TABLE transactions DROP COLUMN IF EXISTS issuer_id;
@@ -0,0 +1,10 @@
+-- Data pembayar dari callback issuer (PAN selalu disimpan masked)
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS issuer_id TEXT;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS issuer_name TEXT;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS customer_pan TEXT;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS customer_name TEXT;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS rrn TEXT;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS approval_code TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_customer_pan ON transactions (customer_pan);
+CREATE INDEX IF NOT EXISTS idx_transactions_rrn ON transactions (rrn);
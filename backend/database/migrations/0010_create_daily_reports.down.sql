@@ -0,0 +1,3 @@
+ALTER TABLE merchants DROP COLUMN IF EXISTS report_email;
+
+DROP TABLE IF EXISTS daily_reports;
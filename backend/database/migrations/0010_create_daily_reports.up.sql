@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS daily_reports (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    merchant_id TEXT NOT NULL,
+    report_date TEXT NOT NULL,
+    total_count BIGINT,
+    total_amount NUMERIC,
+    paid_count BIGINT,
+    paid_amount NUMERIC,
+    failed_count BIGINT,
+    failed_amount NUMERIC,
+    expired_count BIGINT,
+    expired_amount NUMERIC,
+    pending_count BIGINT,
+    pending_amount NUMERIC,
+    success_rate NUMERIC,
+    top_hours TEXT,
+    html TEXT,
+    csv TEXT,
+    emailed_to TEXT,
+    emailed_at TIMESTAMPTZ,
+    email_error TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_daily_reports_deleted_at ON daily_reports (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_daily_reports_merchant_date ON daily_reports (merchant_id, report_date);
+
+-- Tujuan email laporan harian per merchant
+ALTER TABLE merchants ADD COLUMN IF NOT EXISTS report_email TEXT;
@@ -0,0 +1,8 @@
+-- Token bucket rate limit bersama untuk semua instance (RATE_LIMIT_STORE=postgres)
+CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+    bucket_key TEXT PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    allowed BOOLEAN NOT NULL,
+    refilled_at BIGINT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_rate_limit_buckets_refilled_at ON rate_limit_buckets (refilled_at);
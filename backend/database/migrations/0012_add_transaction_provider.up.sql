@@ -0,0 +1,2 @@
+-- Acquirer/payment gateway yang membuat QR; kosong untuk transaksi lama (provider default)
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS provider TEXT NOT NULL DEFAULT '';
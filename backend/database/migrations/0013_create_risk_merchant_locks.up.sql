@@ -0,0 +1,6 @@
+-- Satu baris per merchant yang dikunci selama evaluasi rule risiko dan insert transaksi,
+-- supaya generate QR paralel tidak bisa sama-sama lolos limit volume/velocity
+CREATE TABLE IF NOT EXISTS risk_merchant_locks (
+    merchant_id TEXT PRIMARY KEY,
+    locked_at TIMESTAMPTZ NOT NULL
+);
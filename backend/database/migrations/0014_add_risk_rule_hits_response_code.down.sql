@@ -0,0 +1 @@
+ALTER TABLE risk_rule_hits DROP COLUMN IF EXISTS response_code;
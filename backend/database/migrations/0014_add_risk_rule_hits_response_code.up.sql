@@ -0,0 +1,2 @@
+-- Response code SNAP yang dikembalikan saat rule terpicu (model.RiskRuleHit.ResponseCode)
+ALTER TABLE risk_rule_hits ADD COLUMN IF NOT EXISTS response_code TEXT;
@@ -0,0 +1,4 @@
+ALTER TABLE reconciliation_items DROP COLUMN IF EXISTS their_currency;
+ALTER TABLE reconciliation_items DROP COLUMN IF EXISTS our_currency;
+ALTER TABLE reconciliation_runs DROP COLUMN IF EXISTS currency_mismatch;
+ALTER TABLE reconciliation_runs DROP COLUMN IF EXISTS duplicate;
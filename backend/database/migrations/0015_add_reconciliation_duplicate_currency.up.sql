@@ -0,0 +1,5 @@
+-- Baris duplikat di file settlement dan perbedaan currency (model.ReconDuplicate, model.ReconCurrencyMismatch)
+ALTER TABLE reconciliation_runs ADD COLUMN IF NOT EXISTS duplicate BIGINT;
+ALTER TABLE reconciliation_runs ADD COLUMN IF NOT EXISTS currency_mismatch BIGINT;
+ALTER TABLE reconciliation_items ADD COLUMN IF NOT EXISTS our_currency TEXT;
+ALTER TABLE reconciliation_items ADD COLUMN IF NOT EXISTS their_currency TEXT;
@@ -0,0 +1,179 @@
+package database
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"qr-service/internal/model"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// tableModels semua model yang disimpan lewat GORM, satu per tabel hasil migration
+var tableModels = []interface{}{
+	&model.Transaction{},
+	&model.Merchant{},
+	&model.Outlet{},
+	&model.Terminal{},
+	&model.RiskLimit{},
+	&model.RiskRuleHit{},
+	&model.RiskMerchantLock{},
+	&model.ReconciliationRun{},
+	&model.ReconciliationItem{},
+	&model.FeeRule{},
+	&model.SettlementBatch{},
+	&model.SettlementLine{},
+	&model.LedgerAccount{},
+	&model.JournalEntry{},
+	&model.Posting{},
+	&model.DailyReport{},
+	&model.RateLimitBucket{},
+}
+
+var (
+	createTable = regexp.MustCompile(`(?is)CREATE TABLE IF NOT EXISTS (\w+) \((.*?)\n\);`)
+	addColumn   = regexp.MustCompile(`(?i)ALTER TABLE (\w+) ADD COLUMN IF NOT EXISTS (\w+)`)
+	dropColumn  = regexp.MustCompile(`(?i)ALTER TABLE (\w+) DROP COLUMN IF EXISTS (\w+)`)
+)
+
+// migratedColumns kolom per tabel setelah semua migration up dijalankan berurutan
+func migratedColumns(t *testing.T) map[string]map[string]bool {
+	t.Helper()
+	files, err := fs.Glob(migrationFiles, "migrations/*.up.sql")
+	if err != nil {
+		t.Fatalf("glob migrations: %v", err)
+	}
+
+	tables := map[string]map[string]bool{}
+	for _, file := range files { // fs.Glob terurut, sama dengan urutan versi
+		content, err := fs.ReadFile(migrationFiles, file)
+		if err != nil {
+			t.Fatalf("read %s: %v", file, err)
+		}
+		sql := string(content)
+		for _, match := range createTable.FindAllStringSubmatch(sql, -1) {
+			columns := map[string]bool{}
+			for _, line := range strings.Split(match[2], "\n") {
+				fields := strings.Fields(line)
+				if len(fields) == 0 || strings.EqualFold(fields[0], "CONSTRAINT") || strings.HasPrefix(fields[0], "--") {
+					continue
+				}
+				columns[fields[0]] = true
+			}
+			tables[match[1]] = columns
+		}
+		for _, match := range addColumn.FindAllStringSubmatch(sql, -1) {
+			if tables[match[1]] == nil {
+				t.Fatalf("%s: ALTER TABLE %s before CREATE TABLE", file, match[1])
+			}
+			tables[match[1]][match[2]] = true
+		}
+		for _, match := range dropColumn.FindAllStringSubmatch(sql, -1) {
+			delete(tables[match[1]], match[2])
+		}
+	}
+	return tables
+}
+
+func TestMigrationsCoverModelColumns(t *testing.T) {
+	tables := migratedColumns(t)
+	cache := &sync.Map{}
+	for _, m := range tableModels {
+		s, err := schema.Parse(m, cache, schema.NamingStrategy{})
+		if err != nil {
+			t.Fatalf("parse %T: %v", m, err)
+		}
+		columns, ok := tables[s.Table]
+		if !ok {
+			t.Errorf("%T: table %s not created by any migration", m, s.Table)
+			continue
+		}
+		for _, field := range s.Fields {
+			if field.DBName != "" && !columns[field.DBName] {
+				t.Errorf("%T: column %s.%s not created by any migration", m, s.Table, field.DBName)
+			}
+		}
+	}
+}
+
+// TestMigrationsAcceptModelInserts menjalankan migration di Postgres (TEST_DATABASE_URL, schema
+// sementara) lalu meng-insert setiap model, jadi perbedaan tipe dan constraint ikut terdeteksi
+func TestMigrationsAcceptModelInserts(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open postgres: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("sql db: %v", err)
+	}
+	// Satu koneksi agar search_path berlaku untuk semua query
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	schemaName := fmt.Sprintf("migration_test_%d", time.Now().UnixNano())
+	if err := db.Exec("CREATE SCHEMA " + schemaName).Error; err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() { db.Exec("DROP SCHEMA " + schemaName + " CASCADE") })
+	if err := db.Exec("SET search_path TO " + schemaName).Error; err != nil {
+		t.Fatalf("set search_path: %v", err)
+	}
+
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatalf("NewMigrator: %v", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("migrate up: %v", err)
+	}
+
+	// Baris induk foreign key dibuat lebih dulu
+	outlet := model.Outlet{OutletID: "O001", MerchantID: "M001", Name: "Outlet"}
+	run := model.ReconciliationRun{}
+	batch := model.SettlementBatch{BatchNo: "B001"}
+	entry := model.JournalEntry{Reference: "PAYMENT:A001"}
+	rows := []interface{}{
+		&model.Transaction{MerchantID: "M001", TrxID: "TRX-P001", PartnerReferenceNo: "P001", ReferenceNo: "A001"},
+		&model.Merchant{MerchantID: "M001"},
+		&outlet,
+		&model.Terminal{TerminalID: "T001", OutletID: outlet.OutletID, MerchantID: "M001"},
+		&model.RiskLimit{MerchantID: "M001"},
+		&model.RiskRuleHit{MerchantID: "M001", Rule: "AMOUNT_LIMIT", ResponseCode: "4034702"},
+		&model.RiskMerchantLock{MerchantID: "M001", LockedAt: time.Now()},
+		&run,
+		&model.FeeRule{Category: "UMI"},
+		&batch,
+		&model.LedgerAccount{Code: "ACQUIRER_CLEARING"},
+		&entry,
+		&model.DailyReport{MerchantID: "M001"},
+		&model.RateLimitBucket{BucketKey: "qr:partner:P1"},
+	}
+	for _, row := range rows {
+		if err := db.Create(row).Error; err != nil {
+			t.Errorf("insert %T: %v", row, err)
+		}
+	}
+	children := []interface{}{
+		&model.ReconciliationItem{RunID: run.ID},
+		&model.SettlementLine{BatchID: batch.ID, TransactionID: 1},
+		&model.Posting{EntryID: entry.ID, AccountCode: "ACQUIRER_CLEARING"},
+	}
+	for _, row := range children {
+		if err := db.Create(row).Error; err != nil {
+			t.Errorf("insert %T: %v", row, err)
+		}
+	}
+}
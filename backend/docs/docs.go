@@ -18,9 +18,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/qr/generate": {
+        "/callbacks/{provider}": {
             "post": {
-                "description": "Endpoint untuk menghasilkan QR code baru dan menyimpan transaksi ke database dengan status PENDING.",
+                "description": "Endpoint callback untuk acquirer/payment gateway yang dikonfigurasi di providers. Autentikasi (contoh X-Signature HMAC) dan format body ditentukan adapter provider; adapter snap memakai format yang sama dengan /qr/payment.\nSetelah autentikasi, header SNAP diperiksa dan X-EXTERNAL-ID yang sudah dipakai hari ini ditolak agar callback tidak bisa di-replay.",
                 "consumes": [
                     "application/json"
                 ],
@@ -30,22 +30,56 @@ const docTemplate = `{
                 "tags": [
                     "QR"
                 ],
-                "summary": "Generate QR Code",
+                "summary": "Process Provider Payment Callback",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "HMAC-SHA256 Signature (Body Hash)",
+                        "description": "Nama provider (contoh manjo)",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Signature sesuai provider (adapter snap: HMAC-SHA256 dari X-TIMESTAMP:X-EXTERNAL-ID:body dengan secret provider)",
                         "name": "X-Signature",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Waktu request ISO 8601, maksimal 5 menit dari jam server (contoh: 2025-09-21T10:00:00+07:00)",
+                        "name": "X-TIMESTAMP",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "description": "Data Transaksi yang dibutuhkan",
+                        "type": "string",
+                        "description": "Partner ID (maks. 36 karakter)",
+                        "name": "X-PARTNER-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID numerik unik per partner per hari (maks. 36 digit)",
+                        "name": "X-EXTERNAL-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Channel ID numerik (maks. 5 digit)",
+                        "name": "CHANNEL-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data callback (adapter snap)",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/qr-service_internal_model.GenerateQRRequest"
+                            "$ref": "#/definitions/qr-service_internal_model.PaymentCallbackRequest"
                         }
                     }
                 ],
@@ -53,33 +87,77 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/qr-service_internal_model.GenerateQRResponse"
+                            "$ref": "#/definitions/qr-service_internal_model.PaymentCallbackResponse"
                         }
                     },
                     "400": {
-                        "description": "Validasi input gagal (misalnya Amount \u003c= 0 atau field kosong)",
+                        "description": "Body callback atau header SNAP tidak valid",
                         "schema": {
-                            "$ref": "#/definitions/fiber.Map"
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Signature Hash tidak valid (Unauthorized)",
+                        "description": "Autentikasi callback gagal",
                         "schema": {
-                            "$ref": "#/definitions/fiber.Map"
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Provider tidak dikenal, transaksi tidak ditemukan, data mismatch atau status tidak dikenal",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "X-EXTERNAL-ID sudah dipakai hari ini",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit per IP terlampaui, lihat header Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Gagal menyimpan data transaksi ke database",
+                        "description": "Gagal mengupdate status transaksi",
                         "schema": {
-                            "$ref": "#/definitions/fiber.Map"
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/qr/payment": {
-            "post": {
-                "description": "Endpoint callback dari payment gateway untuk mengupdate status transaksi.",
+        "/fee-rules": {
+            "get": {
+                "description": "Endpoint untuk melihat aturan MDR per kategori merchant.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settlement"
+                ],
+                "summary": "Get Fee Rules",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.FeeRulesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fee-rules/{category}": {
+            "put": {
+                "description": "Endpoint untuk membuat atau mengganti aturan MDR sebuah kategori merchant.",
                 "consumes": [
                     "application/json"
                 ],
@@ -87,24 +165,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "QR"
+                    "Settlement"
                 ],
-                "summary": "Process Payment Callback",
+                "summary": "Save Fee Rule",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "HMAC-SHA256 Signature (Body Hash)",
-                        "name": "X-Signature",
+                        "description": "API key admin",
+                        "name": "X-API-KEY",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "description": "Data Callback Payment",
+                        "type": "string",
+                        "description": "Kategori merchant (UMI, UKE, UME, UBE, EDU, SPBU, GOV)",
+                        "name": "category",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Aturan MDR",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/qr-service_internal_model.PaymentCallbackRequest"
+                            "$ref": "#/definitions/qr-service_internal_model.SaveFeeRuleRequest"
                         }
                     }
                 ],
@@ -112,145 +197,3417 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/qr-service_internal_model.PaymentCallbackResponse"
+                            "$ref": "#/definitions/qr-service_internal_model.FeeRulesResponse"
                         }
                     },
                     "400": {
-                        "description": "Input validasi gagal atau data mismatch",
+                        "description": "Validasi input gagal",
                         "schema": {
-                            "$ref": "#/definitions/fiber.Map"
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Signature Hash tidak valid",
+                        "description": "API key admin tidak valid",
                         "schema": {
-                            "$ref": "#/definitions/fiber.Map"
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Reference Number tidak ditemukan",
+                    "500": {
+                        "description": "Gagal menyimpan fee rule",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ledger/check": {
+            "get": {
+                "description": "Endpoint untuk memastikan total debit sama dengan total kredit di seluruh ledger dan per journal entry.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ledger"
+                ],
+                "summary": "Check Ledger Consistency",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/fiber.Map"
+                            "$ref": "#/definitions/qr-service_internal_model.LedgerCheckResponse"
                         }
                     },
                     "500": {
-                        "description": "Gagal mengupdate status transaksi",
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/fiber.Map"
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "fiber.Map": {
-            "type": "object",
-            "additionalProperties": true
         },
-        "qr-service_internal_model.Amount": {
-            "type": "object",
-            "required": [
-                "currency",
-                "value"
-            ],
-            "properties": {
-                "currency": {
-                    "type": "string"
-                },
-                "value": {
-                    "type": "string"
+        "/ledger/entries": {
+            "get": {
+                "description": "Endpoint untuk melihat journal entry ledger beserta posting debit/kredit-nya.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ledger"
+                ],
+                "summary": "Get Journal Entries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by Merchant ID",
+                        "name": "merchantId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Entry Type (PAYMENT, REFUND, FEE, SETTLEMENT)",
+                        "name": "entryType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit per page (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.JournalEntriesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "qr-service_internal_model.GenerateQRRequest": {
-            "type": "object",
-            "required": [
-                "amount",
-                "merchantId",
-                "partnerReferenceNo"
-            ],
-            "properties": {
-                "amount": {
-                    "$ref": "#/definitions/qr-service_internal_model.Amount"
-                },
-                "merchantId": {
-                    "type": "string"
-                },
-                "partnerReferenceNo": {
-                    "type": "string"
+        "/merchants/{merchantId}": {
+            "get": {
+                "description": "Endpoint untuk melihat profil merchant.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Merchant"
+                ],
+                "summary": "Get Merchant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Merchant ID",
+                        "name": "merchantId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.MerchantResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Merchant tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
                 }
-            }
-        },
-        "qr-service_internal_model.GenerateQRResponse": {
-            "type": "object",
-            "properties": {
-                "partnerReferenceNo": {
-                    "type": "string"
-                },
-                "qrContent": {
-                    "type": "string"
-                },
-                "referenceNo": {
-                    "type": "string"
-                },
-                "responseCode": {
-                    "type": "string"
-                },
-                "responseMessage": {
-                    "type": "string"
+            },
+            "put": {
+                "description": "Endpoint untuk membuat atau mengupdate profil merchant. Category menentukan MDR saat settlement.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Merchant"
+                ],
+                "summary": "Save Merchant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key admin",
+                        "name": "X-API-KEY",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merchant ID",
+                        "name": "merchantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Profil Merchant",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.SaveMerchantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.MerchantResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validasi input gagal",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "API key admin tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Gagal menyimpan merchant",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "qr-service_internal_model.PaymentCallbackRequest": {
-            "type": "object",
-            "required": [
-                "amount",
-                "originalPartnerReferenceNo",
-                "originalReferenceNo",
-                "paidTime",
-                "transactionStatusDesc"
-            ],
-            "properties": {
-                "amount": {
-                    "description": "{value: \"10000.00\", currency: \"IDR\"}",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/qr-service_internal_model.Amount"
+        "/merchants/{merchantId}/balance": {
+            "get": {
+                "description": "Endpoint untuk melihat saldo merchant yang dihitung dari double-entry ledger.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ledger"
+                ],
+                "summary": "Get Merchant Balance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Merchant ID",
+                        "name": "merchantId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.MerchantBalanceResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/merchants/{merchantId}/limits": {
+            "get": {
+                "description": "Endpoint untuk melihat limit risiko yang berlaku untuk merchant (fallback ke limit default \"*\").",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Risk"
+                ],
+                "summary": "Get Merchant Risk Limit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Merchant ID (gunakan * untuk limit default)",
+                        "name": "merchantId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.RiskLimitResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Limit tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Endpoint untuk membuat atau mengganti limit risiko merchant. Nilai 0 berarti tanpa batas.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Risk"
+                ],
+                "summary": "Set Merchant Risk Limit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key admin",
+                        "name": "X-API-KEY",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merchant ID (gunakan * untuk limit default)",
+                        "name": "merchantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Konfigurasi limit",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.SetRiskLimitRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.RiskLimitResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validasi input gagal",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "API key admin tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Gagal menyimpan limit",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/merchants/{merchantId}/outlets": {
+            "get": {
+                "description": "Endpoint untuk mendapatkan semua outlet milik merchant beserta terminalnya.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Merchant"
+                ],
+                "summary": "Get Outlets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Merchant ID",
+                        "name": "merchantId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.GetOutletsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Endpoint untuk mendaftarkan outlet (toko/cabang) baru milik merchant.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Merchant"
+                ],
+                "summary": "Create Outlet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key admin",
+                        "name": "X-API-KEY",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merchant ID",
+                        "name": "merchantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data Outlet",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.CreateOutletRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.OutletResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validasi input gagal",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "API key admin tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Outlet ID sudah dipakai",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Gagal menyimpan outlet",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/merchants/{merchantId}/outlets/{outletId}/terminals": {
+            "post": {
+                "description": "Endpoint untuk mendaftarkan terminal (kasir) baru di sebuah outlet. Terminal ID akan di-encode ke QR.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Merchant"
+                ],
+                "summary": "Create Terminal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key admin",
+                        "name": "X-API-KEY",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Merchant ID",
+                        "name": "merchantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Outlet ID",
+                        "name": "outletId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data Terminal",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.CreateTerminalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.TerminalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validasi input gagal",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "API key admin tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Outlet tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Terminal ID sudah dipakai",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Gagal menyimpan terminal",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/qr/generate": {
+            "post": {
+                "description": "Endpoint untuk menghasilkan QR code baru dan menyimpan transaksi ke database dengan status PENDING.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "QR"
+                ],
+                "summary": "Generate QR Code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 dari X-TIMESTAMP:X-EXTERNAL-ID:body",
+                        "name": "X-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Waktu request ISO 8601, maksimal 5 menit dari jam server (contoh: 2025-09-21T10:00:00+07:00)",
+                        "name": "X-TIMESTAMP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Partner ID (maks. 36 karakter)",
+                        "name": "X-PARTNER-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID numerik unik per partner per hari (maks. 36 digit)",
+                        "name": "X-EXTERNAL-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Channel ID numerik (maks. 5 digit)",
+                        "name": "CHANNEL-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data Transaksi yang dibutuhkan",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.GenerateQRRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.GenerateQRResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validasi input gagal (misalnya Amount \u003c= 0 atau field kosong)",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Signature Hash tidak valid (Unauthorized)",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Ditolak rule risiko (limit amount, volume, atau velocity)",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Partner reference atau X-EXTERNAL-ID sudah dipakai",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit per partner terlampaui, lihat header Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Gagal menyimpan data transaksi ke database",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/qr/payment": {
+            "post": {
+                "description": "Endpoint callback dari payment gateway untuk mengupdate status transaksi.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "QR"
+                ],
+                "summary": "Process Payment Callback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 dari X-TIMESTAMP:X-EXTERNAL-ID:body",
+                        "name": "X-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Waktu request ISO 8601, maksimal 5 menit dari jam server (contoh: 2025-09-21T10:00:00+07:00)",
+                        "name": "X-TIMESTAMP",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Partner ID (maks. 36 karakter)",
+                        "name": "X-PARTNER-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID numerik unik per partner per hari (maks. 36 digit)",
+                        "name": "X-EXTERNAL-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Channel ID numerik (maks. 5 digit)",
+                        "name": "CHANNEL-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Data Callback Payment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.PaymentCallbackRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.PaymentCallbackResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Input validasi gagal atau data mismatch",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Signature Hash tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Reference Number tidak ditemukan, amount tidak sesuai atau status tidak dikenal",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "X-EXTERNAL-ID sudah dipakai hari ini",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit per partner terlampaui, lihat header Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Gagal mengupdate status transaksi",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reconciliations": {
+            "post": {
+                "description": "Endpoint untuk upload file settlement harian dari acquirer dan mencocokkannya dengan transaksi (by reference, amount dan currency). Reference yang muncul lebih dari sekali di file dicatat sebagai DUPLICATE.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reconciliation"
+                ],
+                "summary": "Upload Settlement File",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key admin",
+                        "name": "X-API-KEY",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File settlement",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format file (default: csv)",
+                        "name": "format",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal settlement (format: YYYY-MM-DD, WIB). Default diturunkan dari paid_time di file",
+                        "name": "date",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Koreksi status menjadi PAID untuk transaksi yang callback-nya tidak pernah datang (hanya baris dengan status dibayar)",
+                        "name": "correct",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ReconciliationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "File atau parameter tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "API key admin tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Gagal memproses rekonsiliasi",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reconciliations/{id}": {
+            "get": {
+                "description": "Endpoint untuk melihat laporan rekonsiliasi (matched, missing-on-our-side, missing-on-their-side, amount-mismatch, currency-mismatch, duplicate).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reconciliation"
+                ],
+                "summary": "Get Reconciliation Report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key admin",
+                        "name": "X-API-KEY",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Reconciliation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ReconciliationResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "API key admin tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Laporan tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/daily": {
+            "get": {
+                "description": "Endpoint untuk melihat daftar laporan harian yang sudah dibuat (tanpa isi file).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get Daily Reports",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key admin, atau key merchant untuk laporan merchant sendiri",
+                        "name": "X-API-KEY",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Merchant ID",
+                        "name": "merchantId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal laporan awal (format: YYYY-MM-DD)",
+                        "name": "startDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal laporan akhir (format: YYYY-MM-DD)",
+                        "name": "endDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit per page (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.DailyReportsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter parameters",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "API key tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/daily/run": {
+            "post": {
+                "description": "Endpoint untuk membuat (ulang) laporan harian per merchant: jumlah dan nominal PAID, FAILED, EXPIRED serta jam tersibuk. Scheduler menjalankan ini otomatis setiap hari untuk tanggal kemarin. Merchant yang gagal tidak menghentikan merchant lain dan dicantumkan di failed (responseMessage Partial Success).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Run Daily Reports",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key admin",
+                        "name": "X-API-KEY",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Tanggal (default kemarin), merchant dan opsi kirim email",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.RunDailyReportsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.DailyReportsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Tanggal tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "API key admin tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Laporan semua merchant gagal dibuat",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/daily/{id}": {
+            "get": {
+                "description": "Endpoint untuk melihat ringkasan satu laporan harian beserta status pengiriman email.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get Daily Report Detail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key admin, atau key merchant untuk laporan merchant sendiri",
+                        "name": "X-API-KEY",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Report ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.DailyReportResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "API key tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Laporan tidak ditemukan atau milik merchant lain",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/daily/{id}/download": {
+            "get": {
+                "description": "Endpoint untuk mengunduh file laporan harian dalam format HTML atau CSV.",
+                "produces": [
+                    "text/html",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Download Daily Report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key admin, atau key merchant untuk laporan merchant sendiri",
+                        "name": "X-API-KEY",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Report ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format file: html (default) atau csv",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "File laporan",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Format tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "API key tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Laporan tidak ditemukan atau milik merchant lain",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/risk/hits": {
+            "get": {
+                "description": "Endpoint untuk melihat catatan rule risiko yang terpicu, untuk tuning threshold.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Risk"
+                ],
+                "summary": "Get Risk Rule Hits",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by Merchant ID",
+                        "name": "merchantId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Rule (AMOUNT_LIMIT, DAILY_VOLUME_CAP, MONTHLY_VOLUME_CAP, MERCHANT_VELOCITY, TERMINAL_VELOCITY)",
+                        "name": "rule",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start Date (format: YYYY-MM-DD)",
+                        "name": "startDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit per page (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.GetRiskHitsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter parameters",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/settlements": {
+            "get": {
+                "description": "Endpoint untuk mendapatkan batch settlement (gross, fee, net) beserta detail per transaksi.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settlement"
+                ],
+                "summary": "Get Settlements",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key admin",
+                        "name": "X-API-KEY",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Merchant ID",
+                        "name": "merchantId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cut-off Start Date (format: YYYY-MM-DD)",
+                        "name": "startDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cut-off End Date (format: YYYY-MM-DD)",
+                        "name": "endDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit per page (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.SettlementsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter parameters",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "API key admin tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/settlements/run": {
+            "post": {
+                "description": "Endpoint untuk mengelompokkan transaksi PAID yang belum di-settle ke batch per merchant sampai jam cut-off, lengkap dengan MDR.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settlement"
+                ],
+                "summary": "Run Settlement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key admin",
+                        "name": "X-API-KEY",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Cut-off dan filter merchant",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.RunSettlementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.SettlementsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Cut-off tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "API key admin tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Transaksi sudah di-settle oleh batch lain",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Gagal membuat batch atau posting ledger, batch merchant tersebut dibatalkan",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/settlements/{id}": {
+            "get": {
+                "description": "Endpoint untuk melihat satu batch settlement beserta line item-nya.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settlement"
+                ],
+                "summary": "Get Settlement Detail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key admin",
+                        "name": "X-API-KEY",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Settlement Batch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.SettlementResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "API key admin tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Batch tidak ditemukan",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/status-mappings": {
+            "get": {
+                "description": "Endpoint admin untuk melihat tabel pemetaan status gateway ke status internal yang sedang berlaku. Tabel \"default\" dipakai gateway tanpa tabel sendiri; status di luar tabel ditolak.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "QR"
+                ],
+                "summary": "Get Status Mappings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.StatusMappingsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions": {
+            "get": {
+                "description": "Endpoint untuk mendapatkan semua transaksi dengan filter, sorting dan pagination.\nPagination bisa memakai page/limit atau cursor after/before (keyset created_at,id) yang stabil saat transaksi baru masuk.\nTanggal YYYY-MM-DD dibaca di zona waktu ` + "`" + `timezone` + "`" + ` (default Asia/Jakarta), batas akhir inklusif.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "QR"
+                ],
+                "summary": "Get All Transactions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by Reference Number",
+                        "name": "referenceNumber",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cara mencocokkan referenceNumber: exact (default) atau prefix",
+                        "name": "referenceMatch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by PAN pembayar (masked atau lengkap, dicocokkan dalam bentuk masked)",
+                        "name": "customerId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by RRN dari issuer",
+                        "name": "rrn",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Issuer ID",
+                        "name": "issuerId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Merchant ID",
+                        "name": "merchantId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Outlet ID",
+                        "name": "outletId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Terminal ID",
+                        "name": "terminalId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Status, bisa lebih dari satu dipisah koma (contoh: PAID,PENDING)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Amount minimum",
+                        "name": "minAmount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Amount maksimum",
+                        "name": "maxAmount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Currency (contoh: IDR)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created date awal (YYYY-MM-DD atau RFC3339)",
+                        "name": "startDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created date akhir (YYYY-MM-DD atau RFC3339)",
+                        "name": "endDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Paid date awal (YYYY-MM-DD atau RFC3339)",
+                        "name": "paidStartDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Paid date akhir (YYYY-MM-DD atau RFC3339)",
+                        "name": "paidEndDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Zona waktu IANA untuk tanggal (default: Asia/Jakarta)",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cari di reference, partner reference, merchant ID, trx ID, RRN, PAN, nama pembayar atau issuer",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kolom sort: created_at (default), amount, paid_date, status",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Arah sort: desc (default) atau asc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit per page (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor dari cursor.next, ambil halaman berikutnya (hanya untuk sort created_at)",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor dari cursor.prev, ambil halaman sebelumnya (hanya untuk sort created_at)",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Hitung total (default: true untuk page, false untuk cursor)",
+                        "name": "includeTotal",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.GetTransactionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter parameters",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit per IP terlampaui, lihat header Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/export": {
+            "get": {
+                "description": "Endpoint untuk export transaksi ke CSV atau XLSX dengan filter yang sama seperti GET /transactions. Hasil di-stream dari database, tanggal dalam WIB (atau timezone), dan diakhiri footer total.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Export Transactions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Format file: csv (default) atau xlsx",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Reference Number",
+                        "name": "referenceNumber",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cara mencocokkan referenceNumber: exact (default) atau prefix",
+                        "name": "referenceMatch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by PAN pembayar (masked atau lengkap, dicocokkan dalam bentuk masked)",
+                        "name": "customerId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by RRN dari issuer",
+                        "name": "rrn",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Issuer ID",
+                        "name": "issuerId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Merchant ID",
+                        "name": "merchantId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Outlet ID",
+                        "name": "outletId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Terminal ID",
+                        "name": "terminalId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Status, bisa lebih dari satu dipisah koma (contoh: PAID,PENDING)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Amount minimum",
+                        "name": "minAmount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Amount maksimum",
+                        "name": "maxAmount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Currency (contoh: IDR)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created date awal (YYYY-MM-DD atau RFC3339)",
+                        "name": "startDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created date akhir (YYYY-MM-DD atau RFC3339)",
+                        "name": "endDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Paid date awal (YYYY-MM-DD atau RFC3339)",
+                        "name": "paidStartDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Paid date akhir (YYYY-MM-DD atau RFC3339)",
+                        "name": "paidEndDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Zona waktu IANA untuk filter tanggal dan isi file (default: Asia/Jakarta)",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cari di reference, partner reference, merchant ID, trx ID, RRN, PAN, nama pembayar atau issuer",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kolom sort: created_at (default), amount, paid_date, status",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Arah sort: desc (default) atau asc",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "File CSV atau XLSX",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter parameters",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit per IP terlampaui, lihat header Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/stats": {
+            "get": {
+                "description": "Endpoint untuk statistik dashboard yang dihitung di database: jumlah dan nominal per status, success rate, rata-rata ticket dan time series per jam/hari/minggu.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get Transaction Statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by Merchant ID",
+                        "name": "merchantId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Outlet ID",
+                        "name": "outletId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Terminal ID",
+                        "name": "terminalId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created date awal (YYYY-MM-DD atau RFC3339, default: hari ini)",
+                        "name": "startDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created date akhir (YYYY-MM-DD atau RFC3339)",
+                        "name": "endDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Zona waktu IANA untuk tanggal dan bucket (default: Asia/Jakarta)",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bucket time series: hour, day atau week (default: hour untuk \u003c= 2 hari, selain itu day)",
+                        "name": "interval",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.TransactionStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit per IP terlampaui, lihat header Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{referenceNo}/inquiry": {
+            "post": {
+                "description": "Endpoint untuk menanyakan status transaksi ke provider yang membuat QR-nya, lalu menerapkan hasilnya seperti callback. Berguna jika callback tidak pernah diterima.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Inquire Transaction Status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key admin, atau key merchant untuk transaksi merchant sendiri",
+                        "name": "X-API-KEY",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reference Number internal",
+                        "name": "referenceNo",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.StatusInquiryResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "API key tidak valid",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Transaksi tidak ditemukan, milik merchant lain, atau status dari provider tidak dikenal",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Provider tidak mendukung inquiry status",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Inquiry ke provider gagal",
+                        "schema": {
+                            "$ref": "#/definitions/qr-service_internal_model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ws": {
+            "get": {
+                "description": "WebSocket endpoint for realtime transaction updates",
+                "tags": [
+                    "WebSocket"
+                ],
+                "summary": "WebSocket Connection",
+                "responses": {}
+            }
+        }
+    },
+    "definitions": {
+        "gorm.DeletedAt": {
+            "type": "object",
+            "properties": {
+                "time": {
+                    "type": "string"
+                },
+                "valid": {
+                    "description": "Valid is true if Time is not NULL",
+                    "type": "boolean"
+                }
+            }
+        },
+        "qr-service_internal_model.Amount": {
+            "type": "object",
+            "required": [
+                "currency",
+                "value"
+            ],
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.CreateOutletRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "outletId"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "outletId": {
+                    "type": "string",
+                    "maxLength": 25
+                }
+            }
+        },
+        "qr-service_internal_model.CreateTerminalRequest": {
+            "type": "object",
+            "required": [
+                "terminalId"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "terminalId": {
+                    "type": "string",
+                    "maxLength": 25
+                }
+            }
+        },
+        "qr-service_internal_model.CursorInfo": {
+            "type": "object",
+            "properties": {
+                "hasNext": {
+                    "type": "boolean"
+                },
+                "hasPrev": {
+                    "type": "boolean"
+                },
+                "next": {
+                    "description": "kirim sebagai ?after=",
+                    "type": "string"
+                },
+                "prev": {
+                    "description": "kirim sebagai ?before=",
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.DailyReport": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "email_error": {
+                    "type": "string"
+                },
+                "emailed_at": {
+                    "type": "string"
+                },
+                "emailed_to": {
+                    "type": "string"
+                },
+                "expired_amount": {
+                    "type": "number"
+                },
+                "expired_count": {
+                    "type": "integer"
+                },
+                "failed_amount": {
+                    "type": "number"
+                },
+                "failed_count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "merchant_id": {
+                    "type": "string"
+                },
+                "paid_amount": {
+                    "type": "number"
+                },
+                "paid_count": {
+                    "type": "integer"
+                },
+                "pending_amount": {
+                    "type": "number"
+                },
+                "pending_count": {
+                    "type": "integer"
+                },
+                "report_date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "success_rate": {
+                    "description": "PAID / semua transaksi, 0..1",
+                    "type": "number"
+                },
+                "top_hours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/qr-service_internal_model.HourStat"
+                    }
+                },
+                "total_amount": {
+                    "type": "number"
+                },
+                "total_count": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.DailyReportFailure": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "merchant_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.DailyReportResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/qr-service_internal_model.DailyReport"
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.DailyReportsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/qr-service_internal_model.DailyReport"
+                    }
+                },
+                "failed": {
+                    "description": "merchant yang laporannya gagal dibuat",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/qr-service_internal_model.DailyReportFailure"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/qr-service_internal_model.PaginationInfo"
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.FeeRule": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "exempt_up_to": {
+                    "description": "transaksi \u003c= nilai ini bebas MDR (0 = tidak ada pengecualian)",
+                    "type": "number"
+                },
+                "fixed_fee": {
+                    "description": "biaya tetap per transaksi",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mdr_percent": {
+                    "description": "contoh: 0.3 untuk 0.3%",
+                    "type": "number"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.FeeRulesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/qr-service_internal_model.FeeRule"
+                    }
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.GatewayStatusMapping": {
+            "type": "object",
+            "properties": {
+                "gateway": {
+                    "type": "string"
+                },
+                "mapping": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "qr-service_internal_model.GenerateQRRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "merchantId",
+                "partnerReferenceNo"
+            ],
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/qr-service_internal_model.Amount"
+                },
+                "merchantId": {
+                    "type": "string"
+                },
+                "outletId": {
+                    "description": "Opsional, diturunkan dari terminal jika kosong",
+                    "type": "string"
+                },
+                "partnerReferenceNo": {
+                    "type": "string"
+                },
+                "terminalId": {
+                    "description": "Opsional, di-encode ke QR tag 62 sub-tag 07 (maks. 25)",
+                    "type": "string",
+                    "maxLength": 25
+                }
+            }
+        },
+        "qr-service_internal_model.GenerateQRResponse": {
+            "type": "object",
+            "properties": {
+                "partnerReferenceNo": {
+                    "type": "string"
+                },
+                "qrContent": {
+                    "type": "string"
+                },
+                "referenceNo": {
+                    "type": "string"
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.GetOutletsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/qr-service_internal_model.Outlet"
+                    }
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.GetRiskHitsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/qr-service_internal_model.RiskRuleHit"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/qr-service_internal_model.PaginationInfo"
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.GetTransactionsResponse": {
+            "type": "object",
+            "properties": {
+                "cursor": {
+                    "$ref": "#/definitions/qr-service_internal_model.CursorInfo"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/qr-service_internal_model.TransactionResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/qr-service_internal_model.PaginationInfo"
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.HourStat": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "hour": {
+                    "description": "0-23",
+                    "type": "integer"
+                },
+                "paid_amount": {
+                    "type": "number"
+                },
+                "paid_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "qr-service_internal_model.JournalEntriesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/qr-service_internal_model.JournalEntry"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/qr-service_internal_model.PaginationInfo"
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.JournalEntry": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "entry_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "merchant_id": {
+                    "type": "string"
+                },
+                "postings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/qr-service_internal_model.Posting"
+                    }
+                },
+                "reference": {
+                    "description": "idempotency key, contoh: PAYMENT:A0000581937281024001",
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.LedgerCheck": {
+            "type": "object",
+            "properties": {
+                "balanced": {
+                    "type": "boolean"
+                },
+                "entry_count": {
+                    "type": "integer"
+                },
+                "total_credit": {
+                    "type": "number"
+                },
+                "total_debit": {
+                    "type": "number"
+                },
+                "unbalanced_entries": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "qr-service_internal_model.LedgerCheckResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/qr-service_internal_model.LedgerCheck"
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.Merchant": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "merchant_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "report_email": {
+                    "description": "tujuan email laporan harian, kosong = tidak dikirim",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.MerchantBalance": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "description": "hutang ke merchant yang belum dibayarkan",
+                    "type": "number"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "merchant_id": {
+                    "type": "string"
+                },
+                "total_fees": {
+                    "type": "number"
+                },
+                "total_payments": {
+                    "type": "number"
+                },
+                "total_refunds": {
+                    "type": "number"
+                },
+                "total_settled": {
+                    "type": "number"
+                }
+            }
+        },
+        "qr-service_internal_model.MerchantBalanceResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/qr-service_internal_model.MerchantBalance"
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.MerchantResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/qr-service_internal_model.Merchant"
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.Outlet": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "merchant_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "outlet_id": {
+                    "type": "string"
+                },
+                "terminals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/qr-service_internal_model.Terminal"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.OutletResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/qr-service_internal_model.Outlet"
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.PaginationInfo": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "totalPage": {
+                    "type": "integer"
+                }
+            }
+        },
+        "qr-service_internal_model.PaymentAdditionalInfo": {
+            "type": "object",
+            "properties": {
+                "approvalCode": {
+                    "type": "string",
+                    "maxLength": 16
+                },
+                "customerName": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "customerPan": {
+                    "description": "PAN pembayar, akan di-mask jika belum",
+                    "type": "string",
+                    "maxLength": 32
+                },
+                "issuerId": {
+                    "description": "contoh: 93600014 (NNS issuer)",
+                    "type": "string",
+                    "maxLength": 32
+                },
+                "issuerName": {
+                    "description": "contoh: BCA, GoPay",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "rrn": {
+                    "type": "string",
+                    "maxLength": 32
+                }
+            }
+        },
+        "qr-service_internal_model.PaymentCallbackRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "originalPartnerReferenceNo",
+                "originalReferenceNo",
+                "paidTime",
+                "transactionStatusDesc"
+            ],
+            "properties": {
+                "additionalInfo": {
+                    "description": "Opsional, data pembayar dari issuer",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/qr-service_internal_model.PaymentAdditionalInfo"
+                        }
+                    ]
+                },
+                "amount": {
+                    "description": "{value: \"10000.00\", currency: \"IDR\"}",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/qr-service_internal_model.Amount"
                         }
                     ]
                 },
-                "originalPartnerReferenceNo": {
-                    "description": "PartnerReferenceNo (DIRECT-API-NMS-whhq7gvx58)",
+                "latestTransactionStatus": {
+                    "description": "Opsional, kode SNAP 00-07; didahulukan dari transactionStatusDesc",
+                    "type": "string"
+                },
+                "originalPartnerReferenceNo": {
+                    "description": "PartnerReferenceNo (DIRECT-API-NMS-whhq7gvx58)",
+                    "type": "string"
+                },
+                "originalReferenceNo": {
+                    "description": "ReferenceNo internal (A0000581937281024001)",
+                    "type": "string"
+                },
+                "paidTime": {
+                    "description": "2025-09-21T09:25:00+07:00",
+                    "type": "string"
+                },
+                "transactionStatusDesc": {
+                    "description": "Success, Failed, dll",
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.PaymentCallbackResponse": {
+            "type": "object",
+            "properties": {
+                "responseCode": {
+                    "description": "2005200",
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "description": "Successful",
+                    "type": "string"
+                },
+                "transactionStatusDesc": {
+                    "description": "Success",
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.Posting": {
+            "type": "object",
+            "properties": {
+                "account_code": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "entry_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "qr-service_internal_model.ReconciliationItem": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "corrected": {
+                    "description": "status transaksi dikoreksi menjadi PAID",
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "line": {
+                    "description": "nomor baris di file settlement (0 jika tidak ada di file)",
+                    "type": "integer"
+                },
+                "our_amount": {
+                    "type": "number"
+                },
+                "our_currency": {
+                    "type": "string"
+                },
+                "our_status": {
+                    "type": "string"
+                },
+                "paid_time": {
+                    "type": "string"
+                },
+                "partner_reference_no": {
+                    "type": "string"
+                },
+                "reference_no": {
+                    "type": "string"
+                },
+                "run_id": {
+                    "type": "integer"
+                },
+                "their_amount": {
+                    "type": "number"
+                },
+                "their_currency": {
+                    "type": "string"
+                },
+                "their_status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.ReconciliationResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/qr-service_internal_model.ReconciliationRun"
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.ReconciliationRun": {
+            "type": "object",
+            "properties": {
+                "amount_mismatch": {
+                    "type": "integer"
+                },
+                "corrected": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "currency_mismatch": {
+                    "type": "integer"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "duplicate": {
+                    "type": "integer"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/qr-service_internal_model.ReconciliationItem"
+                    }
+                },
+                "matched": {
+                    "type": "integer"
+                },
+                "missing_on_our_side": {
+                    "type": "integer"
+                },
+                "missing_on_their_side": {
+                    "type": "integer"
+                },
+                "period_end": {
+                    "type": "string"
+                },
+                "period_start": {
+                    "type": "string"
+                },
+                "total_records": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.RiskLimit": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "daily_volume_cap": {
+                    "description": "total amount PENDING + PAID per hari (WIB)",
+                    "type": "number"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "max_amount": {
+                    "type": "number"
+                },
+                "max_generates_per_minute": {
+                    "description": "per merchant",
+                    "type": "integer"
+                },
+                "max_terminal_generates_per_minute": {
+                    "description": "per terminal",
+                    "type": "integer"
+                },
+                "merchant_id": {
+                    "type": "string"
+                },
+                "min_amount": {
+                    "type": "number"
+                },
+                "monitor_only": {
+                    "description": "true = hanya dicatat, tidak ditolak",
+                    "type": "boolean"
+                },
+                "monthly_volume_cap": {
+                    "description": "total amount PENDING + PAID per bulan (WIB)",
+                    "type": "number"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.RiskLimitResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/qr-service_internal_model.RiskLimit"
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.RiskRuleHit": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "enforced": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "merchant_id": {
+                    "type": "string"
+                },
+                "outlet_id": {
+                    "type": "string"
+                },
+                "partner_reference_no": {
+                    "type": "string"
+                },
+                "response_code": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
+                },
+                "terminal_id": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.RunDailyReportsRequest": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "YYYY-MM-DD (WIB), default kemarin",
+                    "type": "string"
+                },
+                "merchantId": {
+                    "description": "kosong = semua merchant yang bertransaksi",
+                    "type": "string"
+                },
+                "sendEmail": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "qr-service_internal_model.RunSettlementRequest": {
+            "type": "object",
+            "properties": {
+                "cutoff": {
+                    "description": "RFC3339, default jam cut-off hari ini (WIB)",
+                    "type": "string"
+                },
+                "merchantId": {
+                    "description": "kosong = semua merchant",
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.SaveFeeRuleRequest": {
+            "type": "object",
+            "properties": {
+                "exemptUpTo": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "fixedFee": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "mdrPercent": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                }
+            }
+        },
+        "qr-service_internal_model.SaveMerchantRequest": {
+            "type": "object",
+            "required": [
+                "category"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "enum": [
+                        "UMI",
+                        "UKE",
+                        "UME",
+                        "UBE",
+                        "EDU",
+                        "SPBU",
+                        "GOV"
+                    ]
+                },
+                "name": {
                     "type": "string"
                 },
-                "originalReferenceNo": {
-                    "description": "ReferenceNo internal (A0000000577)",
+                "reportEmail": {
                     "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.SetRiskLimitRequest": {
+            "type": "object",
+            "properties": {
+                "dailyVolumeCap": {
+                    "type": "number",
+                    "minimum": 0
                 },
-                "paidTime": {
-                    "description": "2025-09-21T09:25:00+07:00",
+                "maxAmount": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "maxGeneratesPerMinute": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "maxTerminalGeneratesPerMinute": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "minAmount": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "monitorOnly": {
+                    "type": "boolean"
+                },
+                "monthlyVolumeCap": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "qr-service_internal_model.SettlementBatch": {
+            "type": "object",
+            "properties": {
+                "batch_no": {
                     "type": "string"
                 },
-                "transactionStatusDesc": {
-                    "description": "Success, Failed, dll",
+                "category": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "cutoff": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "fee_amount": {
+                    "type": "number"
+                },
+                "gross_amount": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/qr-service_internal_model.SettlementLine"
+                    }
+                },
+                "merchant_id": {
+                    "type": "string"
+                },
+                "net_amount": {
+                    "type": "number"
+                },
+                "period_start": {
+                    "type": "string"
+                },
+                "transaction_count": {
+                    "type": "integer"
+                },
+                "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "qr-service_internal_model.PaymentCallbackResponse": {
+        "qr-service_internal_model.SettlementLine": {
+            "type": "object",
+            "properties": {
+                "batch_id": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "fee_amount": {
+                    "type": "number"
+                },
+                "gross_amount": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mdr_percent": {
+                    "type": "number"
+                },
+                "net_amount": {
+                    "type": "number"
+                },
+                "paid_date": {
+                    "type": "string"
+                },
+                "reference_no": {
+                    "type": "string"
+                },
+                "terminal_id": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.SettlementResponse": {
             "type": "object",
             "properties": {
+                "data": {
+                    "$ref": "#/definitions/qr-service_internal_model.SettlementBatch"
+                },
                 "responseCode": {
-                    "description": "2005100",
                     "type": "string"
                 },
                 "responseMessage": {
-                    "description": "Successful",
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.SettlementsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/qr-service_internal_model.SettlementBatch"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/qr-service_internal_model.PaginationInfo"
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.StatsBucket": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "paid_amount": {
+                    "type": "number"
+                },
+                "paid_count": {
+                    "type": "integer"
+                },
+                "start": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.StatusInquiryResponse": {
+            "type": "object",
+            "properties": {
+                "provider": {
+                    "type": "string"
+                },
+                "referenceNo": {
+                    "type": "string"
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "status internal setelah inquiry",
                     "type": "string"
                 },
                 "transactionStatusDesc": {
-                    "description": "Success",
+                    "description": "status dari provider",
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.StatusMappingsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/qr-service_internal_model.GatewayStatusMapping"
+                    }
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.StatusStats": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.Terminal": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "merchant_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "outlet_id": {
+                    "type": "string"
+                },
+                "terminal_id": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.TerminalResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/qr-service_internal_model.Terminal"
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.TransactionResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "approval_code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "customer_name": {
+                    "type": "string"
+                },
+                "customer_pan": {
+                    "description": "contoh: 936008**********1234",
+                    "type": "string"
+                },
+                "issuer_id": {
+                    "type": "string"
+                },
+                "issuer_name": {
+                    "type": "string"
+                },
+                "merchant_id": {
+                    "type": "string"
+                },
+                "outlet_id": {
+                    "type": "string"
+                },
+                "paid_date": {
+                    "type": "string"
+                },
+                "partner_reference_no": {
+                    "type": "string"
+                },
+                "reference_no": {
+                    "description": "Internal Ref",
+                    "type": "string"
+                },
+                "rrn": {
+                    "description": "Retrieval Reference Number",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "terminal_id": {
+                    "type": "string"
+                },
+                "transaction_date": {
+                    "type": "string"
+                },
+                "trx_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "qr-service_internal_model.TransactionStats": {
+            "type": "object",
+            "properties": {
+                "average_ticket": {
+                    "description": "rata-rata nominal transaksi PAID",
+                    "type": "number"
+                },
+                "by_status": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/qr-service_internal_model.StatusStats"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "interval": {
+                    "type": "string"
+                },
+                "merchant_id": {
+                    "type": "string"
+                },
+                "outlet_id": {
+                    "type": "string"
+                },
+                "paid_amount": {
+                    "type": "number"
+                },
+                "paid_count": {
+                    "type": "integer"
+                },
+                "series": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/qr-service_internal_model.StatsBucket"
+                    }
+                },
+                "success_rate": {
+                    "description": "PAID / semua transaksi, 0..1",
+                    "type": "number"
+                },
+                "terminal_id": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "total_amount": {
+                    "type": "number"
+                },
+                "total_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "qr-service_internal_model.TransactionStatsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/qr-service_internal_model.TransactionStats"
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
                     "type": "string"
                 }
             }
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"crypto/subtle"
+
+	"qr-service/pkg/apperror"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// HeaderAPIKey header key API untuk route admin dan data per merchant
+const HeaderAPIKey = "X-API-KEY"
+
+// callerMerchantKey key Locals untuk merchant pemilik key API, kosong untuk key admin
+const callerMerchantKey = "callerMerchant"
+
+// AdminAuth middleware route admin (master data, limit risk, settlement, rekonsiliasi):
+// X-API-KEY harus sama dengan adminKey. adminKey kosong berarti route admin selalu ditolak.
+func AdminAuth(adminKey string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !matchAPIKey(adminKey, c.Get(HeaderAPIKey)) {
+			return apperror.New(apperror.Unauthorized, "Invalid API Key")
+		}
+		return c.Next()
+	}
+}
+
+// MerchantAuth middleware route data per merchant: key admin bisa mengakses semua merchant,
+// key di merchantKeys (merchantId -> key) hanya data merchant-nya sendiri (lihat callerMerchant).
+func MerchantAuth(adminKey string, merchantKeys map[string]string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(HeaderAPIKey)
+		if matchAPIKey(adminKey, key) {
+			c.Locals(callerMerchantKey, "")
+			return c.Next()
+		}
+		for merchantID, merchantKey := range merchantKeys {
+			if matchAPIKey(merchantKey, key) {
+				c.Locals(callerMerchantKey, merchantID)
+				return c.Next()
+			}
+		}
+		return apperror.New(apperror.Unauthorized, "Invalid API Key")
+	}
+}
+
+// callerMerchant merchant pemilik key API request ini; kosong untuk key admin (semua merchant)
+func callerMerchant(c *fiber.Ctx) string {
+	merchantID, _ := c.Locals(callerMerchantKey).(string)
+	return merchantID
+}
+
+// matchAPIKey membandingkan key dalam waktu konstan; key kosong tidak pernah cocok
+func matchAPIKey(want string, got string) bool {
+	return want != "" && subtle.ConstantTimeCompare([]byte(want), []byte(got)) == 1
+}
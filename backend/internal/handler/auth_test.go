@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestAdminAuthRequiresMatchingAPIKey(t *testing.T) {
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) }
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Post("/api/v1/reconciliations", AdminAuth("admin-key"), ok)
+	app.Post("/api/v1/disabled", AdminAuth(""), ok)
+
+	cases := []struct {
+		name   string
+		target string
+		key    string
+		status int
+	}{
+		{"valid key", "/api/v1/reconciliations", "admin-key", 200},
+		{"missing key", "/api/v1/reconciliations", "", 401},
+		{"wrong key", "/api/v1/reconciliations", "admin-key2", 401},
+		{"no admin key configured", "/api/v1/disabled", "", 401},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, resp := doRequest(t, app, "POST", tc.target, "", map[string]string{HeaderAPIKey: tc.key})
+			if status != tc.status {
+				t.Fatalf("status = %d %+v, want %d", status, resp, tc.status)
+			}
+		})
+	}
+}
+
+func TestMerchantAuthScopesCallerMerchant(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Get("/api/v1/reports/daily", MerchantAuth("admin-key", map[string]string{"M001": "m001-key"}), func(c *fiber.Ctx) error {
+		return c.SendString("caller=" + callerMerchant(c))
+	})
+
+	cases := []struct {
+		key    string
+		status int
+		body   string
+	}{
+		{"admin-key", 200, "caller="},
+		{"m001-key", 200, "caller=M001"},
+		{"m002-key", 401, ""},
+		{"", 401, ""},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest("GET", "/api/v1/reports/daily", nil)
+		req.Header.Set(HeaderAPIKey, tc.key)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != tc.status || (tc.status == 200 && string(body) != tc.body) {
+			t.Fatalf("key %q: got %d %q, want %d %q", tc.key, resp.StatusCode, body, tc.status, tc.body)
+		}
+	}
+}
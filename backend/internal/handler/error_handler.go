@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"qr-service/internal/model"
+	"qr-service/pkg/apperror"
+	"qr-service/pkg/snap"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+var validate = newValidator()
+
+// newValidator memakai nama field JSON di pesan error ("amount.value", bukan "Amount.Value")
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			return field.Name
+		}
+		return name
+	})
+	return v
+}
+
+// ErrorHandler error handler Fiber: semua error dari handler dan middleware
+// dikirim dalam bentuk model.ErrorResponse dengan response code SNAP. Service code
+// diambil dari registry snap.Services berdasarkan route yang sedang diproses.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	service := snap.ServiceFor(c.Method(), c.Route().Path)
+
+	// Error bawaan Fiber (route tidak ada, method salah, body terlalu besar, ...)
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return c.Status(fiberErr.Code).JSON(model.ErrorResponse{
+			ResponseCode:    service.ResponseCode(fiberErr.Code, "00"),
+			ResponseMessage: fiberErr.Message,
+		})
+	}
+
+	appErr := apperror.From(err)
+	if appErr.Code == apperror.Internal {
+		slog.ErrorContext(c.UserContext(), "Request failed", "method", c.Method(), "path", c.Path(), "error", err)
+	}
+	return c.Status(appErr.Code.HTTPStatus()).JSON(model.ErrorResponse{
+		ResponseCode:    appErr.Code.ResponseCode(service.Code),
+		ResponseMessage: appErr.Message,
+	})
+}
+
+// parseBody membaca body JSON ke req lalu menjalankan validasi struct
+func parseBody(c *fiber.Ctx, req any) error {
+	if err := c.BodyParser(req); err != nil {
+		return apperror.Wrap(err, apperror.BadRequest, "Invalid request body format")
+	}
+	return validateStruct(req)
+}
+
+// validateStruct field wajib yang kosong menjadi InvalidMandatoryField, selain itu
+// InvalidFieldFormat, dengan pesan SNAP untuk field pertama yang gagal
+// (contoh "Invalid Mandatory Field amount.value").
+func validateStruct(req any) error {
+	err := validate.Struct(req)
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) || len(fieldErrs) == 0 {
+		return apperror.New(apperror.InvalidFieldFormat, "Validation failed: "+err.Error())
+	}
+
+	for _, fieldErr := range fieldErrs {
+		if fieldErr.Tag() == "required" {
+			return apperror.Field(apperror.InvalidMandatoryField, fieldName(fieldErr))
+		}
+	}
+	return apperror.Field(apperror.InvalidFieldFormat, fieldName(fieldErrs[0]))
+}
+
+// fieldName path field tanpa nama struct request, contoh "GenerateQRRequest.amount.value" -> "amount.value"
+func fieldName(fieldErr validator.FieldError) string {
+	_, name, _ := strings.Cut(fieldErr.Namespace(), ".")
+	return name
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"qr-service/internal/model"
+	"qr-service/internal/repository"
+	"qr-service/internal/service"
+	"qr-service/pkg/apperror"
+	"qr-service/pkg/provider"
+	"qr-service/pkg/snap"
+	"qr-service/pkg/util"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const testHMACSecret = "test-secret"
+
+func newTestApp() *fiber.App {
+	transactionService := service.NewTransactionService(repository.NewMemoryTransactionStore(), nil, nil, nil, nil)
+	transactionService.Providers = provider.NewRegistry(provider.NewSNAP(provider.SNAPOptions{Name: provider.DefaultName, HMACSecret: testHMACSecret}))
+	transactionHandler := TransactionHandler{Service: transactionService}
+	validateHMAC := ValidateHMAC(testHMACSecret)
+	snapHeaders := SNAPHeaders(snap.NewExternalIDStore())
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Get("/api/v1/transactions", transactionHandler.GetTransactions)
+	app.Post("/api/v1/qr/generate", validateHMAC, snapHeaders, transactionHandler.GenerateQR)
+	app.Post("/api/v1/qr/payment", validateHMAC, snapHeaders, transactionHandler.ProcessPaymentCallback)
+	app.Post("/api/v1/callbacks/:provider", transactionHandler.AuthenticateProviderCallback, snapHeaders, transactionHandler.ProviderCallback)
+	return app
+}
+
+// snapRequestHeaders header SNAP valid dengan X-TIMESTAMP sekarang
+func snapRequestHeaders(body string, externalID string) map[string]string {
+	return signedSNAPHeaders(body, externalID, time.Now().In(util.WIB).Format(time.RFC3339))
+}
+
+// signedSNAPHeaders header SNAP dengan X-Signature dari snap.StringToSign(timestamp, externalID, body)
+func signedSNAPHeaders(body string, externalID string, timestamp string) map[string]string {
+	return map[string]string{
+		"X-Signature":         util.GenerateHMACSHA256(testHMACSecret, snap.StringToSign(timestamp, externalID, []byte(body))),
+		snap.HeaderTimestamp:  timestamp,
+		snap.HeaderPartnerID:  "PARTNER01",
+		snap.HeaderExternalID: externalID,
+		snap.HeaderChannelID:  "95221",
+	}
+}
+
+func doRequest(t *testing.T, app *fiber.App, method string, target string, body string, headers map[string]string) (int, model.ErrorResponse) {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test returned error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var errResp model.ErrorResponse
+	json.NewDecoder(resp.Body).Decode(&errResp)
+	return resp.StatusCode, errResp
+}
+
+func TestErrorHandlerMapsTypedErrors(t *testing.T) {
+	app := newTestApp()
+
+	paymentBody := `{"originalReferenceNo":"A404","originalPartnerReferenceNo":"P404","transactionStatusDesc":"Success","paidTime":"2025-09-21T10:00:00+07:00","amount":{"value":"10000.00","currency":"IDR"}}`
+	badTimestamp := signedSNAPHeaders("{}", "1002", "21-09-2025 10:00")
+	missingTimestamp := signedSNAPHeaders("{}", "1003", "")
+	delete(missingTimestamp, snap.HeaderTimestamp)
+	staleTimestamp := signedSNAPHeaders("{}", "1011", time.Now().Add(-10*time.Minute).Format(time.RFC3339))
+	swappedExternalID := snapRequestHeaders("{}", "1012")
+	swappedExternalID[snap.HeaderExternalID] = "1013"
+	longTerminalBody := `{"partnerReferenceNo":"P1","amount":{"value":"10000.00","currency":"IDR"},"merchantId":"M001","terminalId":"` + strings.Repeat("T", 26) + `"}`
+	callbackMissingExternalID := snapRequestHeaders(paymentBody, "")
+	delete(callbackMissingExternalID, snap.HeaderExternalID)
+
+	cases := []struct {
+		name        string
+		method      string
+		target      string
+		body        string
+		headers     map[string]string
+		status      int
+		code        string
+		messagePart string
+	}{
+		{"invalid status filter", "GET", "/api/v1/transactions?status=BOGUS", "", nil, 400, "4000001", "invalid status: BOGUS"},
+		{"missing signature", "POST", "/api/v1/qr/generate", "{}", nil, 401, "4014700", "Signature header missing"},
+		{"missing timestamp header", "POST", "/api/v1/qr/generate", "{}", missingTimestamp, 400, "4004702", "Invalid Mandatory Field X-TIMESTAMP"},
+		{"invalid timestamp header", "POST", "/api/v1/qr/generate", "{}", badTimestamp, 400, "4004701", "Invalid Field Format X-TIMESTAMP"},
+		{"stale timestamp", "POST", "/api/v1/qr/generate", "{}", staleTimestamp, 401, "4014700", "X-TIMESTAMP outside allowed window"},
+		{"unsigned external id", "POST", "/api/v1/qr/generate", "{}", swappedExternalID, 401, "4014700", "Invalid Signature Hash"},
+		{"terminal id too long", "POST", "/api/v1/qr/generate", longTerminalBody, snapRequestHeaders(longTerminalBody, "1010"), 400, "4004701", "Invalid Field Format terminalId"},
+		{"missing mandatory field", "POST", "/api/v1/qr/payment", `{"originalReferenceNo":"A1"}`,
+			snapRequestHeaders(`{"originalReferenceNo":"A1"}`, "1004"), 400, "4005202", "Invalid Mandatory Field originalPartnerReferenceNo"},
+		{"malformed body", "POST", "/api/v1/qr/payment", `{`, snapRequestHeaders(`{`, "1005"), 400, "4005200", "Invalid request body format"},
+		{"transaction not found", "POST", "/api/v1/qr/payment", paymentBody, snapRequestHeaders(paymentBody, "1006"), 404, "4045201", "transaction not found"},
+		{"duplicate external id", "POST", "/api/v1/qr/payment", paymentBody, snapRequestHeaders(paymentBody, "1006"), 409, "4095200", "X-EXTERNAL-ID already used today"},
+		{"unknown provider", "POST", "/api/v1/callbacks/acme", paymentBody, snapRequestHeaders(paymentBody, "1007"), 404, "4045202", "unknown payment provider: acme"},
+		{"provider missing signature", "POST", "/api/v1/callbacks/manjo", paymentBody, nil, 401, "4015200", "Signature header missing"},
+		{"provider missing mandatory field", "POST", "/api/v1/callbacks/manjo", `{"originalReferenceNo":"A1"}`,
+			snapRequestHeaders(`{"originalReferenceNo":"A1"}`, "1008"), 400, "4005202", "Invalid Mandatory Field originalPartnerReferenceNo"},
+		{"provider transaction not found", "POST", "/api/v1/callbacks/manjo", paymentBody, snapRequestHeaders(paymentBody, "1009"), 404, "4045201", "transaction not found"},
+		{"provider missing external id", "POST", "/api/v1/callbacks/manjo", paymentBody, callbackMissingExternalID, 400, "4005202", "Invalid Mandatory Field X-EXTERNAL-ID"},
+		{"provider replayed external id", "POST", "/api/v1/callbacks/manjo", paymentBody, snapRequestHeaders(paymentBody, "1009"), 409, "4095200", "X-EXTERNAL-ID already used today"},
+		{"unknown route", "GET", "/nope", "", nil, 404, "4040000", "Cannot GET /nope"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, resp := doRequest(t, app, tc.method, tc.target, tc.body, tc.headers)
+			if status != tc.status || resp.ResponseCode != tc.code || !strings.Contains(resp.ResponseMessage, tc.messagePart) {
+				t.Fatalf("got %d %+v, want %d %s %q", status, resp, tc.status, tc.code, tc.messagePart)
+			}
+		})
+	}
+}
+
+func TestSNAPHeadersReleasesExternalIDOnServerError(t *testing.T) {
+	status := fiber.StatusInternalServerError
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Post("/api/v1/qr/generate", ValidateHMAC(testHMACSecret), SNAPHeaders(snap.NewExternalIDStore()), func(c *fiber.Ctx) error {
+		if status == fiber.StatusInternalServerError {
+			return apperror.New(apperror.Internal, "database unavailable")
+		}
+		return c.SendStatus(status)
+	})
+
+	// 5xx melepas X-EXTERNAL-ID sehingga retry diterima; setelah sukses ID tetap terpakai
+	for _, want := range []int{500, 200, 409} {
+		if got, resp := doRequest(t, app, "POST", "/api/v1/qr/generate", "{}", snapRequestHeaders("{}", "2001")); got != want {
+			t.Fatalf("status = %d %+v, want %d", got, resp, want)
+		}
+		status = fiber.StatusOK
+	}
+}
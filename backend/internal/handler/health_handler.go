@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"time"
+
+	"qr-service/pkg/health"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// HealthHandler probe liveness dan readiness untuk orchestrator dan uptime check
+type HealthHandler struct {
+	Checker   *health.Checker
+	StartedAt time.Time
+}
+
+func NewHealthHandler(checker *health.Checker) *HealthHandler {
+	return &HealthHandler{Checker: checker, StartedAt: time.Now()}
+}
+
+// Livez GET /livez: proses hidup dan event loop HTTP merespons. Tidak memeriksa dependency,
+// jadi database yang mati tidak membuat container di-restart.
+func (h *HealthHandler) Livez(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.JSON(fiber.Map{
+		"status":         health.StatusOK,
+		"uptime_seconds": int64(time.Since(h.StartedAt).Seconds()),
+	})
+}
+
+// Readyz GET /readyz: menjalankan semua pemeriksaan komponen (database, versi schema,
+// hub WebSocket, heartbeat worker). 200 jika semua ok, 503 jika ada yang gagal,
+// dengan hasil per komponen di body.
+func (h *HealthHandler) Readyz(c *fiber.Ctx) error {
+	report := h.Checker.Run(c.UserContext())
+
+	status := fiber.StatusOK
+	if !report.OK() {
+		status = fiber.StatusServiceUnavailable
+	}
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Status(status).JSON(report)
+}
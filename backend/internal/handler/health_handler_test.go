@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"qr-service/pkg/health"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestReadyzReportsFailingComponent(t *testing.T) {
+	checker := health.NewChecker(time.Second)
+	checker.Add("database", func(ctx context.Context) error { return errors.New("connection refused") })
+	checker.Add("websocket_hub", func(ctx context.Context) error { return nil })
+	healthHandler := NewHealthHandler(checker)
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Get("/livez", healthHandler.Livez)
+	app.Get("/readyz", healthHandler.Readyz)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/livez", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("livez status = %d, want 200 even when a dependency is down", resp.StatusCode)
+	}
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/readyz", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != fiber.StatusServiceUnavailable {
+		t.Fatalf("readyz status = %d, want 503", resp.StatusCode)
+	}
+	var report health.Report
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Status != health.StatusFail || report.Checks["database"].Error != "connection refused" || report.Checks["websocket_hub"].Status != health.StatusOK {
+		t.Fatalf("report = %+v", report)
+	}
+}
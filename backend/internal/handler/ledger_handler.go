@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"qr-service/internal/model"
+	"qr-service/internal/service"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type LedgerHandler struct {
+	Service *service.LedgerService
+}
+
+// @Summary Get Merchant Balance
+// @Description Endpoint untuk melihat saldo merchant yang dihitung dari double-entry ledger.
+// @Tags Ledger
+// @Produce json
+// @Param merchantId path string true "Merchant ID"
+// @Success 200 {object} model.MerchantBalanceResponse
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /merchants/{merchantId}/balance [get]
+func (h *LedgerHandler) GetMerchantBalance(c *fiber.Ctx) error {
+	resp, err := h.Service.GetMerchantBalance(c.Params("merchantId"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// @Summary Get Journal Entries
+// @Description Endpoint untuk melihat journal entry ledger beserta posting debit/kredit-nya.
+// @Tags Ledger
+// @Produce json
+// @Param merchantId query string false "Filter by Merchant ID"
+// @Param entryType query string false "Filter by Entry Type (PAYMENT, REFUND, FEE, SETTLEMENT)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Limit per page (default: 10, max: 100)"
+// @Success 200 {object} model.JournalEntriesResponse
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /ledger/entries [get]
+func (h *LedgerHandler) GetEntries(c *fiber.Ctx) error {
+	var req model.GetJournalEntriesRequest
+
+	req.MerchantID = c.Query("merchantId")
+	req.EntryType = c.Query("entryType")
+	req.Page, _ = strconv.Atoi(c.Query("page", "1"))
+	req.Limit, _ = strconv.Atoi(c.Query("limit", "10"))
+
+	resp, err := h.Service.GetEntries(req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// @Summary Check Ledger Consistency
+// @Description Endpoint untuk memastikan total debit sama dengan total kredit di seluruh ledger dan per journal entry.
+// @Tags Ledger
+// @Produce json
+// @Success 200 {object} model.LedgerCheckResponse
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /ledger/check [get]
+func (h *LedgerHandler) Check(c *fiber.Ctx) error {
+	resp, err := h.Service.Check()
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
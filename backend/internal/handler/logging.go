@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+
+	"qr-service/pkg/logging"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxRequestIDLength batas panjang X-Request-ID dari client
+const maxRequestIDLength = 128
+
+// RequestID middleware memakai X-Request-ID dari client (jika valid) atau membuat yang baru,
+// lalu menyimpannya di c.UserContext() agar ikut ke log service dan repository. Request ID
+// dikembalikan di response dan dicatat di span request.
+func RequestID() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		requestID := c.Get(logging.RequestIDHeader)
+		if validRequestID(requestID) {
+			requestID = strings.Clone(requestID) // header Fiber memakai buffer yang dipakai ulang
+		} else {
+			requestID = logging.NewRequestID()
+		}
+
+		ctx := logging.WithRequestID(c.UserContext(), requestID)
+		trace.SpanFromContext(ctx).SetAttributes(attribute.String("http.request_id", requestID))
+		c.SetUserContext(ctx)
+		c.Set(logging.RequestIDHeader, requestID)
+		return c.Next()
+	}
+}
+
+// validRequestID menolak request ID kosong, terlalu panjang atau berisi karakter selain
+// huruf, angka, "-", "_", "." dan ":" agar tidak bisa menyisipkan baris palsu ke log.
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range requestID {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// AccessLog middleware mencatat satu baris log per request (pengganti logger Fiber).
+// Level mengikuti status: 5xx ERROR, 4xx WARN, selain itu INFO. Query string dan body
+// tidak dicatat karena bisa berisi data sensitif.
+func AccessLog() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		if err := c.Next(); err != nil {
+			if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+				c.Status(fiber.StatusInternalServerError)
+			}
+		}
+
+		status := c.Response().StatusCode()
+		level := slog.LevelInfo
+		switch {
+		case status >= fiber.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= fiber.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+		slog.LogAttrs(c.UserContext(), level, "HTTP request",
+			slog.String("method", c.Method()),
+			slog.String("path", c.Path()),
+			slog.String("route", routeLabel(c)),
+			slog.Int("status", status),
+			slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.Int("bytes", len(c.Response().Body())),
+			slog.String("ip", c.IP()),
+		)
+		return nil
+	}
+}
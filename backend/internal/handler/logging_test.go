@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"qr-service/pkg/logging"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRequestIDReachesLogsAndResponse(t *testing.T) {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(logging.New(logging.Options{Level: slog.LevelInfo, Output: &buf}))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Use(RequestID())
+	app.Use(AccessLog())
+	app.Get("/api/v1/transactions", func(c *fiber.Ctx) error {
+		slog.InfoContext(c.UserContext(), "handler called")
+		return nil
+	})
+
+	req := httptest.NewRequest("GET", "/api/v1/transactions", nil)
+	req.Header.Set(logging.RequestIDHeader, "client-req-42")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.Header.Get(logging.RequestIDHeader); got != "client-req-42" {
+		t.Fatalf("response %s = %q, want client-req-42", logging.RequestIDHeader, got)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected handler and access log lines, got %q", buf.String())
+	}
+	for _, line := range lines {
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid JSON log %q: %v", line, err)
+		}
+		if record["request_id"] != "client-req-42" {
+			t.Errorf("log %s missing request_id", line)
+		}
+	}
+
+	// Request ID dengan karakter tidak valid diganti dengan yang baru
+	req = httptest.NewRequest("GET", "/api/v1/transactions", nil)
+	req.Header.Set(logging.RequestIDHeader, "bad id")
+	resp, err = app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.Header.Get(logging.RequestIDHeader); got == "" || got == "bad id" {
+		t.Fatalf("response %s = %q, want a generated request id", logging.RequestIDHeader, got)
+	}
+}
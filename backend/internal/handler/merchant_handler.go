@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"qr-service/internal/model"
+	"qr-service/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type MerchantHandler struct {
+	Service *service.MerchantService
+}
+
+// @Summary Save Merchant
+// @Description Endpoint untuk membuat atau mengupdate profil merchant. Category menentukan MDR saat settlement.
+// @Tags Merchant
+// @Accept json
+// @Produce json
+// @Param X-API-KEY header string true "API key admin"
+// @Param merchantId path string true "Merchant ID"
+// @Param request body model.SaveMerchantRequest true "Profil Merchant"
+// @Success 200 {object} model.MerchantResponse
+// @Failure 400 {object} model.ErrorResponse "Validasi input gagal"
+// @Failure 401 {object} model.ErrorResponse "API key admin tidak valid"
+// @Failure 500 {object} model.ErrorResponse "Gagal menyimpan merchant"
+// @Router /merchants/{merchantId} [put]
+func (h *MerchantHandler) SaveMerchant(c *fiber.Ctx) error {
+	var req model.SaveMerchantRequest
+
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	resp, err := h.Service.SaveMerchant(c.Params("merchantId"), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// @Summary Get Merchant
+// @Description Endpoint untuk melihat profil merchant.
+// @Tags Merchant
+// @Produce json
+// @Param merchantId path string true "Merchant ID"
+// @Success 200 {object} model.MerchantResponse
+// @Failure 404 {object} model.ErrorResponse "Merchant tidak ditemukan"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /merchants/{merchantId} [get]
+func (h *MerchantHandler) GetMerchant(c *fiber.Ctx) error {
+	resp, err := h.Service.GetMerchant(c.Params("merchantId"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// @Summary Create Outlet
+// @Description Endpoint untuk mendaftarkan outlet (toko/cabang) baru milik merchant.
+// @Tags Merchant
+// @Accept json
+// @Produce json
+// @Param X-API-KEY header string true "API key admin"
+// @Param merchantId path string true "Merchant ID"
+// @Param request body model.CreateOutletRequest true "Data Outlet"
+// @Success 200 {object} model.OutletResponse
+// @Failure 400 {object} model.ErrorResponse "Validasi input gagal"
+// @Failure 401 {object} model.ErrorResponse "API key admin tidak valid"
+// @Failure 409 {object} model.ErrorResponse "Outlet ID sudah dipakai"
+// @Failure 500 {object} model.ErrorResponse "Gagal menyimpan outlet"
+// @Router /merchants/{merchantId}/outlets [post]
+func (h *MerchantHandler) CreateOutlet(c *fiber.Ctx) error {
+	var req model.CreateOutletRequest
+
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	resp, err := h.Service.CreateOutlet(c.Params("merchantId"), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// @Summary Create Terminal
+// @Description Endpoint untuk mendaftarkan terminal (kasir) baru di sebuah outlet. Terminal ID akan di-encode ke QR.
+// @Tags Merchant
+// @Accept json
+// @Produce json
+// @Param X-API-KEY header string true "API key admin"
+// @Param merchantId path string true "Merchant ID"
+// @Param outletId path string true "Outlet ID"
+// @Param request body model.CreateTerminalRequest true "Data Terminal"
+// @Success 200 {object} model.TerminalResponse
+// @Failure 400 {object} model.ErrorResponse "Validasi input gagal"
+// @Failure 401 {object} model.ErrorResponse "API key admin tidak valid"
+// @Failure 404 {object} model.ErrorResponse "Outlet tidak ditemukan"
+// @Failure 409 {object} model.ErrorResponse "Terminal ID sudah dipakai"
+// @Failure 500 {object} model.ErrorResponse "Gagal menyimpan terminal"
+// @Router /merchants/{merchantId}/outlets/{outletId}/terminals [post]
+func (h *MerchantHandler) CreateTerminal(c *fiber.Ctx) error {
+	var req model.CreateTerminalRequest
+
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	resp, err := h.Service.CreateTerminal(c.Params("merchantId"), c.Params("outletId"), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// @Summary Get Outlets
+// @Description Endpoint untuk mendapatkan semua outlet milik merchant beserta terminalnya.
+// @Tags Merchant
+// @Produce json
+// @Param merchantId path string true "Merchant ID"
+// @Success 200 {object} model.GetOutletsResponse
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /merchants/{merchantId}/outlets [get]
+func (h *MerchantHandler) GetOutlets(c *fiber.Ctx) error {
+	resp, err := h.Service.GetOutlets(c.Params("merchantId"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"qr-service/pkg/metrics"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics middleware mencatat latency setiap request ke histogram per method, route dan status.
+// Error diteruskan ke ErrorHandler di sini agar status response final yang tercatat.
+func Metrics() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		if err := c.Next(); err != nil {
+			if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+				c.Status(fiber.StatusInternalServerError)
+			}
+		}
+
+		// Label disimpan registry, sedangkan string dari Fiber memakai buffer yang dipakai ulang
+		metrics.HTTPRequestDuration.WithLabelValues(strings.Clone(c.Method()), strings.Clone(routeLabel(c)), strconv.Itoa(c.Response().StatusCode())).
+			Observe(time.Since(start).Seconds())
+		return nil
+	}
+}
+
+// routeLabel pola route yang menangani request. Path tanpa route (404) dikelompokkan
+// menjadi "unmatched" agar label metric dan nama span tidak meledak.
+func routeLabel(c *fiber.Ctx) string {
+	route := c.Route().Path
+	if route == "/" && c.Path() != "/" {
+		return "unmatched"
+	}
+	return route
+}
+
+// MetricsHandler GET /metrics dalam format Prometheus (di luar /api/v1, seperti /health)
+var MetricsHandler = adaptor.HTTPHandler(promhttp.Handler())
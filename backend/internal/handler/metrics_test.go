@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"io"
+	"net/http/httptest"
+	"qr-service/pkg/metrics"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetricsRecordsRouteAndSignatureFailures(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Use(Metrics())
+	app.Post("/api/v1/qr/generate", ValidateHMAC(testHMACSecret), func(c *fiber.Ctx) error { return nil })
+	app.Get("/metrics", MetricsHandler)
+
+	before := testutil.ToFloat64(metrics.SignatureFailuresTotal.WithLabelValues("missing"))
+	if status, _ := doRequest(t, app, "POST", "/api/v1/qr/generate", "{}", nil); status != 401 {
+		t.Fatalf("status = %d, want 401", status)
+	}
+	doRequest(t, app, "GET", "/nope", "", nil)
+	if got := testutil.ToFloat64(metrics.SignatureFailuresTotal.WithLabelValues("missing")); got != before+1 {
+		t.Fatalf("signature failures = %v, want %v", got, before+1)
+	}
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	for _, want := range []string{
+		`qr_signature_failures_total{reason="missing"}`,
+		`qr_http_request_duration_seconds_count{method="POST",route="/api/v1/qr/generate",status="401"}`,
+		`qr_http_request_duration_seconds_count{method="GET",route="unmatched",status="404"}`,
+		"go_goroutines ",
+		"process_cpu_seconds_total ",
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics output missing %s", want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"math"
+	"strconv"
+
+	"qr-service/pkg/apperror"
+	"qr-service/pkg/metrics"
+	"qr-service/pkg/ratelimit"
+	"qr-service/pkg/snap"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxRateLimitKeyLength partner/merchant ID yang lebih panjang dari ini memakai IP,
+// agar client tidak bisa membuat bucket dengan key sangat besar
+const maxRateLimitKeyLength = 64
+
+// RateLimit middleware token bucket untuk satu group route. keyBy menentukan siapa yang
+// berbagi budget (ratelimit.KeyPartner, KeyMerchant atau KeyIP). Request yang melebihi
+// budget ditolak dengan 429 SNAP dan header Retry-After. limiter nil berarti rate limit mati.
+func RateLimit(limiter *ratelimit.Limiter, group string, keyBy string) fiber.Handler {
+	if limiter == nil {
+		return func(c *fiber.Ctx) error { return c.Next() }
+	}
+	return func(c *fiber.Ctx) error {
+		result, err := limiter.Allow(c.UserContext(), group, rateLimitKey(c, keyBy))
+		if err != nil {
+			// Store tidak tersedia: request tetap dilayani agar rate limit tidak menjatuhkan API
+			slog.WarnContext(c.UserContext(), "Rate limit store unavailable, request allowed", "group", group, "error", err)
+			return c.Next()
+		}
+
+		if result.Limit > 0 {
+			c.Set("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+			c.Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		}
+		if !result.Allowed {
+			metrics.RateLimitedTotal.WithLabelValues(group).Inc()
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(result.RetryAfter.Seconds()))))
+			return apperror.New(apperror.TooManyRequests, "Too Many Requests")
+		}
+		return c.Next()
+	}
+}
+
+// rateLimitKey key bucket sesuai keyBy, fallback ke IP client jika partner/merchant tidak ada
+func rateLimitKey(c *fiber.Ctx, keyBy string) string {
+	switch keyBy {
+	case ratelimit.KeyPartner:
+		if partnerID := c.Get(snap.HeaderPartnerID); partnerID != "" && len(partnerID) <= maxRateLimitKeyLength {
+			return ratelimit.KeyPartner + ":" + partnerID
+		}
+	case ratelimit.KeyMerchant:
+		if merchantID := requestMerchantID(c); merchantID != "" && len(merchantID) <= maxRateLimitKeyLength {
+			return ratelimit.KeyMerchant + ":" + merchantID
+		}
+	}
+	return ratelimit.KeyIP + ":" + c.IP()
+}
+
+// requestMerchantID merchantId dari path (/merchants/:merchantId), query atau body JSON
+func requestMerchantID(c *fiber.Ctx) string {
+	if merchantID := c.Params("merchantId"); merchantID != "" {
+		return merchantID
+	}
+	if merchantID := c.Query("merchantId"); merchantID != "" {
+		return merchantID
+	}
+	var body struct {
+		MerchantID string `json:"merchantId"`
+	}
+	if len(c.Body()) > 0 && json.Unmarshal(c.Body(), &body) == nil {
+		return body.MerchantID
+	}
+	return ""
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"qr-service/internal/model"
+	"qr-service/pkg/ratelimit"
+	"qr-service/pkg/snap"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRateLimitReturnsSNAP429PerPartner(t *testing.T) {
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), map[string]ratelimit.Rule{"qr": ratelimit.PerMinute(60, 1)})
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Post("/api/v1/qr/generate", ValidateHMAC(testHMACSecret), RateLimit(limiter, "qr", ratelimit.KeyPartner), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	send := func(partnerID string, externalID string) *http.Response {
+		t.Helper()
+		req := httptest.NewRequest("POST", "/api/v1/qr/generate", strings.NewReader("{}"))
+		for key, value := range snapRequestHeaders("{}", externalID) {
+			req.Header.Set(key, value)
+		}
+		req.Header.Set(snap.HeaderPartnerID, partnerID)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	if resp := send("PARTNER01", "2001"); resp.StatusCode != fiber.StatusOK || resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		t.Fatalf("first request = %d remaining %q, want 200 and 0", resp.StatusCode, resp.Header.Get("X-RateLimit-Remaining"))
+	}
+
+	resp := send("PARTNER01", "2002")
+	defer resp.Body.Close()
+	var errResp model.ErrorResponse
+	json.NewDecoder(resp.Body).Decode(&errResp)
+	if resp.StatusCode != fiber.StatusTooManyRequests || errResp.ResponseCode != "4294700" {
+		t.Fatalf("second request = %d %+v, want 429 4294700", resp.StatusCode, errResp)
+	}
+	if got := resp.Header.Get(fiber.HeaderRetryAfter); got != "1" {
+		t.Fatalf("Retry-After = %q, want 1", got)
+	}
+
+	// Partner lain punya bucket sendiri
+	if resp := send("PARTNER02", "2003"); resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("other partner = %d, want 200", resp.StatusCode)
+	}
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"qr-service/internal/model"
+	"qr-service/internal/service"
+	"qr-service/pkg/apperror"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReconciliationHandler struct {
+	Service *service.ReconciliationService
+}
+
+// @Summary Upload Settlement File
+// @Description Endpoint untuk upload file settlement harian dari acquirer dan mencocokkannya dengan transaksi (by reference, amount dan currency). Reference yang muncul lebih dari sekali di file dicatat sebagai DUPLICATE.
+// @Tags Reconciliation
+// @Accept multipart/form-data
+// @Produce json
+// @Param X-API-KEY header string true "API key admin"
+// @Param file formData file true "File settlement"
+// @Param format formData string false "Format file (default: csv)"
+// @Param date formData string false "Tanggal settlement (format: YYYY-MM-DD, WIB). Default diturunkan dari paid_time di file"
+// @Param correct formData bool false "Koreksi status menjadi PAID untuk transaksi yang callback-nya tidak pernah datang (hanya baris dengan status dibayar)"
+// @Success 200 {object} model.ReconciliationResponse
+// @Failure 400 {object} model.ErrorResponse "File atau parameter tidak valid"
+// @Failure 401 {object} model.ErrorResponse "API key admin tidak valid"
+// @Failure 500 {object} model.ErrorResponse "Gagal memproses rekonsiliasi"
+// @Router /reconciliations [post]
+func (h *ReconciliationHandler) Upload(c *fiber.Ctx) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return apperror.Wrap(err, apperror.InvalidMandatoryField, "Settlement file is required")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return apperror.Wrap(err, apperror.BadRequest, "Failed to open settlement file")
+	}
+	defer file.Close()
+
+	correct, _ := strconv.ParseBool(c.FormValue("correct"))
+	req := model.ReconcileRequest{
+		FileName: fileHeader.Filename,
+		Format:   c.FormValue("format", "csv"),
+		Date:     c.FormValue("date"),
+		Correct:  correct,
+	}
+
+	resp, err := h.Service.Reconcile(c.UserContext(), file, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// @Summary Get Reconciliation Report
+// @Description Endpoint untuk melihat laporan rekonsiliasi (matched, missing-on-our-side, missing-on-their-side, amount-mismatch, currency-mismatch, duplicate).
+// @Tags Reconciliation
+// @Produce json
+// @Param X-API-KEY header string true "API key admin"
+// @Param id path int true "Reconciliation ID"
+// @Success 200 {object} model.ReconciliationResponse
+// @Failure 401 {object} model.ErrorResponse "API key admin tidak valid"
+// @Failure 404 {object} model.ErrorResponse "Laporan tidak ditemukan"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /reconciliations/{id} [get]
+func (h *ReconciliationHandler) GetReconciliation(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return apperror.New(apperror.NotFound, "reconciliation not found")
+	}
+
+	resp, err := h.Service.GetReconciliation(uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
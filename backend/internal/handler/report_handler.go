@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"qr-service/internal/model"
+	"qr-service/internal/service"
+	"qr-service/pkg/apperror"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReportHandler struct {
+	Service *service.ReportService
+}
+
+// @Summary Run Daily Reports
+// @Description Endpoint untuk membuat (ulang) laporan harian per merchant: jumlah dan nominal PAID, FAILED, EXPIRED serta jam tersibuk. Scheduler menjalankan ini otomatis setiap hari untuk tanggal kemarin. Merchant yang gagal tidak menghentikan merchant lain dan dicantumkan di failed (responseMessage Partial Success).
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Param X-API-KEY header string true "API key admin"
+// @Param request body model.RunDailyReportsRequest false "Tanggal (default kemarin), merchant dan opsi kirim email"
+// @Success 200 {object} model.DailyReportsResponse
+// @Failure 400 {object} model.ErrorResponse "Tanggal tidak valid"
+// @Failure 401 {object} model.ErrorResponse "API key admin tidak valid"
+// @Failure 500 {object} model.ErrorResponse "Laporan semua merchant gagal dibuat"
+// @Router /reports/daily/run [post]
+func (h *ReportHandler) RunDailyReports(c *fiber.Ctx) error {
+	var req model.RunDailyReportsRequest
+
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return apperror.Wrap(err, apperror.BadRequest, "Invalid request body format")
+		}
+	}
+
+	resp, err := h.Service.GenerateDailyReports(req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// @Summary Get Daily Reports
+// @Description Endpoint untuk melihat daftar laporan harian yang sudah dibuat (tanpa isi file).
+// @Tags Reports
+// @Produce json
+// @Param X-API-KEY header string true "API key admin, atau key merchant untuk laporan merchant sendiri"
+// @Param merchantId query string false "Filter by Merchant ID"
+// @Param startDate query string false "Tanggal laporan awal (format: YYYY-MM-DD)"
+// @Param endDate query string false "Tanggal laporan akhir (format: YYYY-MM-DD)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Limit per page (default: 10, max: 100)"
+// @Success 200 {object} model.DailyReportsResponse
+// @Failure 400 {object} model.ErrorResponse "Invalid filter parameters"
+// @Failure 401 {object} model.ErrorResponse "API key tidak valid"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /reports/daily [get]
+func (h *ReportHandler) GetDailyReports(c *fiber.Ctx) error {
+	var req model.GetDailyReportsRequest
+
+	req.MerchantID = c.Query("merchantId")
+	// Key merchant hanya melihat laporannya sendiri
+	if merchantID := callerMerchant(c); merchantID != "" {
+		req.MerchantID = merchantID
+	}
+	req.StartDate = c.Query("startDate")
+	req.EndDate = c.Query("endDate")
+	req.Page, _ = strconv.Atoi(c.Query("page", "1"))
+	req.Limit, _ = strconv.Atoi(c.Query("limit", "10"))
+
+	resp, err := h.Service.GetDailyReports(req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// @Summary Get Daily Report Detail
+// @Description Endpoint untuk melihat ringkasan satu laporan harian beserta status pengiriman email.
+// @Tags Reports
+// @Produce json
+// @Param X-API-KEY header string true "API key admin, atau key merchant untuk laporan merchant sendiri"
+// @Param id path int true "Report ID"
+// @Success 200 {object} model.DailyReportResponse
+// @Failure 401 {object} model.ErrorResponse "API key tidak valid"
+// @Failure 404 {object} model.ErrorResponse "Laporan tidak ditemukan atau milik merchant lain"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /reports/daily/{id} [get]
+func (h *ReportHandler) GetDailyReport(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return apperror.New(apperror.NotFound, "report not found")
+	}
+
+	resp, err := h.Service.GetDailyReport(uint(id), callerMerchant(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// @Summary Download Daily Report
+// @Description Endpoint untuk mengunduh file laporan harian dalam format HTML atau CSV.
+// @Tags Reports
+// @Produce text/html
+// @Produce text/csv
+// @Param X-API-KEY header string true "API key admin, atau key merchant untuk laporan merchant sendiri"
+// @Param id path int true "Report ID"
+// @Param format query string false "Format file: html (default) atau csv"
+// @Success 200 {file} file "File laporan"
+// @Failure 400 {object} model.ErrorResponse "Format tidak valid"
+// @Failure 401 {object} model.ErrorResponse "API key tidak valid"
+// @Failure 404 {object} model.ErrorResponse "Laporan tidak ditemukan atau milik merchant lain"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /reports/daily/{id}/download [get]
+func (h *ReportHandler) DownloadDailyReport(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return apperror.New(apperror.NotFound, "report not found")
+	}
+
+	content, contentType, filename, err := h.Service.DownloadDailyReport(uint(id), c.Query("format"), callerMerchant(c))
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+filename+`"`)
+	return c.Status(fiber.StatusOK).Send(content)
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"qr-service/internal/model"
+	"qr-service/internal/service"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type RiskHandler struct {
+	Service *service.RiskService
+}
+
+// @Summary Get Merchant Risk Limit
+// @Description Endpoint untuk melihat limit risiko yang berlaku untuk merchant (fallback ke limit default "*").
+// @Tags Risk
+// @Produce json
+// @Param merchantId path string true "Merchant ID (gunakan * untuk limit default)"
+// @Success 200 {object} model.RiskLimitResponse
+// @Failure 404 {object} model.ErrorResponse "Limit tidak ditemukan"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /merchants/{merchantId}/limits [get]
+func (h *RiskHandler) GetLimit(c *fiber.Ctx) error {
+	resp, err := h.Service.GetLimit(c.Params("merchantId"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// @Summary Set Merchant Risk Limit
+// @Description Endpoint untuk membuat atau mengganti limit risiko merchant. Nilai 0 berarti tanpa batas.
+// @Tags Risk
+// @Accept json
+// @Produce json
+// @Param X-API-KEY header string true "API key admin"
+// @Param merchantId path string true "Merchant ID (gunakan * untuk limit default)"
+// @Param request body model.SetRiskLimitRequest true "Konfigurasi limit"
+// @Success 200 {object} model.RiskLimitResponse
+// @Failure 400 {object} model.ErrorResponse "Validasi input gagal"
+// @Failure 401 {object} model.ErrorResponse "API key admin tidak valid"
+// @Failure 500 {object} model.ErrorResponse "Gagal menyimpan limit"
+// @Router /merchants/{merchantId}/limits [put]
+func (h *RiskHandler) SetLimit(c *fiber.Ctx) error {
+	var req model.SetRiskLimitRequest
+
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	resp, err := h.Service.SetLimit(c.Params("merchantId"), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// @Summary Get Risk Rule Hits
+// @Description Endpoint untuk melihat catatan rule risiko yang terpicu, untuk tuning threshold.
+// @Tags Risk
+// @Produce json
+// @Param merchantId query string false "Filter by Merchant ID"
+// @Param rule query string false "Filter by Rule (AMOUNT_LIMIT, DAILY_VOLUME_CAP, MONTHLY_VOLUME_CAP, MERCHANT_VELOCITY, TERMINAL_VELOCITY)"
+// @Param startDate query string false "Start Date (format: YYYY-MM-DD)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Limit per page (default: 10, max: 100)"
+// @Success 200 {object} model.GetRiskHitsResponse
+// @Failure 400 {object} model.ErrorResponse "Invalid filter parameters"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /risk/hits [get]
+func (h *RiskHandler) GetHits(c *fiber.Ctx) error {
+	var req model.GetRiskHitsRequest
+
+	req.MerchantID = c.Query("merchantId")
+	req.Rule = c.Query("rule")
+	req.StartDate = c.Query("startDate")
+	req.Page, _ = strconv.Atoi(c.Query("page", "1"))
+	req.Limit, _ = strconv.Atoi(c.Query("limit", "10"))
+
+	resp, err := h.Service.GetHits(req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
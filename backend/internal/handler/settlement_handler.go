@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"qr-service/internal/model"
+	"qr-service/internal/service"
+	"qr-service/pkg/apperror"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SettlementHandler struct {
+	Service *service.SettlementService
+}
+
+// @Summary Run Settlement
+// @Description Endpoint untuk mengelompokkan transaksi PAID yang belum di-settle ke batch per merchant sampai jam cut-off, lengkap dengan MDR.
+// @Tags Settlement
+// @Accept json
+// @Produce json
+// @Param X-API-KEY header string true "API key admin"
+// @Param request body model.RunSettlementRequest false "Cut-off dan filter merchant"
+// @Success 200 {object} model.SettlementsResponse
+// @Failure 400 {object} model.ErrorResponse "Cut-off tidak valid"
+// @Failure 401 {object} model.ErrorResponse "API key admin tidak valid"
+// @Failure 409 {object} model.ErrorResponse "Transaksi sudah di-settle oleh batch lain"
+// @Failure 500 {object} model.ErrorResponse "Gagal membuat batch atau posting ledger, batch merchant tersebut dibatalkan"
+// @Router /settlements/run [post]
+func (h *SettlementHandler) RunSettlement(c *fiber.Ctx) error {
+	var req model.RunSettlementRequest
+
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return apperror.Wrap(err, apperror.BadRequest, "Invalid request body format")
+		}
+	}
+
+	resp, err := h.Service.RunSettlement(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// @Summary Get Settlements
+// @Description Endpoint untuk mendapatkan batch settlement (gross, fee, net) beserta detail per transaksi.
+// @Tags Settlement
+// @Produce json
+// @Param X-API-KEY header string true "API key admin"
+// @Param merchantId query string false "Filter by Merchant ID"
+// @Param startDate query string false "Cut-off Start Date (format: YYYY-MM-DD)"
+// @Param endDate query string false "Cut-off End Date (format: YYYY-MM-DD)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Limit per page (default: 10, max: 100)"
+// @Success 200 {object} model.SettlementsResponse
+// @Failure 400 {object} model.ErrorResponse "Invalid filter parameters"
+// @Failure 401 {object} model.ErrorResponse "API key admin tidak valid"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /settlements [get]
+func (h *SettlementHandler) GetSettlements(c *fiber.Ctx) error {
+	var req model.GetSettlementsRequest
+
+	req.MerchantID = c.Query("merchantId")
+	req.StartDate = c.Query("startDate")
+	req.EndDate = c.Query("endDate")
+	req.Page, _ = strconv.Atoi(c.Query("page", "1"))
+	req.Limit, _ = strconv.Atoi(c.Query("limit", "10"))
+
+	resp, err := h.Service.GetSettlements(req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// @Summary Get Settlement Detail
+// @Description Endpoint untuk melihat satu batch settlement beserta line item-nya.
+// @Tags Settlement
+// @Produce json
+// @Param X-API-KEY header string true "API key admin"
+// @Param id path int true "Settlement Batch ID"
+// @Success 200 {object} model.SettlementResponse
+// @Failure 401 {object} model.ErrorResponse "API key admin tidak valid"
+// @Failure 404 {object} model.ErrorResponse "Batch tidak ditemukan"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /settlements/{id} [get]
+func (h *SettlementHandler) GetSettlement(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return apperror.New(apperror.NotFound, "settlement not found")
+	}
+
+	resp, err := h.Service.GetSettlement(uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// @Summary Get Fee Rules
+// @Description Endpoint untuk melihat aturan MDR per kategori merchant.
+// @Tags Settlement
+// @Produce json
+// @Success 200 {object} model.FeeRulesResponse
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /fee-rules [get]
+func (h *SettlementHandler) GetFeeRules(c *fiber.Ctx) error {
+	resp, err := h.Service.GetFeeRules()
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// @Summary Save Fee Rule
+// @Description Endpoint untuk membuat atau mengganti aturan MDR sebuah kategori merchant.
+// @Tags Settlement
+// @Accept json
+// @Produce json
+// @Param X-API-KEY header string true "API key admin"
+// @Param category path string true "Kategori merchant (UMI, UKE, UME, UBE, EDU, SPBU, GOV)"
+// @Param request body model.SaveFeeRuleRequest true "Aturan MDR"
+// @Success 200 {object} model.FeeRulesResponse
+// @Failure 400 {object} model.ErrorResponse "Validasi input gagal"
+// @Failure 401 {object} model.ErrorResponse "API key admin tidak valid"
+// @Failure 500 {object} model.ErrorResponse "Gagal menyimpan fee rule"
+// @Router /fee-rules/{category} [put]
+func (h *SettlementHandler) SaveFeeRule(c *fiber.Ctx) error {
+	var req model.SaveFeeRuleRequest
+
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	resp, err := h.Service.SaveFeeRule(c.Params("category"), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"errors"
+	"qr-service/pkg/apperror"
+	"qr-service/pkg/snap"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SNAPHeaders middleware validasi header wajib SNAP: X-TIMESTAMP (ISO 8601, dalam jendela
+// snap.TimestampSkew), X-PARTNER-ID, X-EXTERNAL-ID (numerik, unik per partner per hari) dan CHANNEL-ID.
+// Dipasang setelah ValidateHMAC agar request tanpa signature valid tidak memakai X-EXTERNAL-ID.
+// X-EXTERNAL-ID dilepas lagi jika request gagal di sisi server (5xx) agar partner bisa retry.
+func SNAPHeaders(externalIDs *snap.ExternalIDStore) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		for _, header := range []string{snap.HeaderTimestamp, snap.HeaderPartnerID, snap.HeaderExternalID, snap.HeaderChannelID} {
+			if c.Get(header) == "" {
+				return apperror.Field(apperror.InvalidMandatoryField, header)
+			}
+		}
+
+		timestamp, err := time.Parse(time.RFC3339, c.Get(snap.HeaderTimestamp))
+		if err != nil {
+			return apperror.Field(apperror.InvalidFieldFormat, snap.HeaderTimestamp)
+		}
+		now := time.Now()
+		if skew := now.Sub(timestamp); skew > snap.TimestampSkew || skew < -snap.TimestampSkew {
+			return apperror.New(apperror.Unauthorized, "Unauthorized: X-TIMESTAMP outside allowed window")
+		}
+		partnerID := c.Get(snap.HeaderPartnerID)
+		if len(partnerID) > 36 {
+			return apperror.Field(apperror.InvalidFieldFormat, snap.HeaderPartnerID)
+		}
+		externalID := c.Get(snap.HeaderExternalID)
+		if len(externalID) > 36 || !isDigits(externalID) {
+			return apperror.Field(apperror.InvalidFieldFormat, snap.HeaderExternalID)
+		}
+		if channelID := c.Get(snap.HeaderChannelID); len(channelID) > 5 || !isDigits(channelID) {
+			return apperror.Field(apperror.InvalidFieldFormat, snap.HeaderChannelID)
+		}
+
+		if !externalIDs.Reserve(partnerID, externalID, now) {
+			return apperror.New(apperror.Conflict, "Conflict: X-EXTERNAL-ID already used today")
+		}
+		err = c.Next()
+		if isServerError(c, err) {
+			externalIDs.Release(partnerID, externalID, now)
+		}
+		return err
+	}
+}
+
+// isServerError true jika hasil handler berupa HTTP 5xx, baik dari error yang dikembalikan
+// (diubah ErrorHandler) maupun status response yang ditulis langsung
+func isServerError(c *fiber.Ctx, err error) bool {
+	if err == nil {
+		return c.Response().StatusCode() >= fiber.StatusInternalServerError
+	}
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code >= fiber.StatusInternalServerError
+	}
+	return apperror.From(err).Code.HTTPStatus() >= fiber.StatusInternalServerError
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
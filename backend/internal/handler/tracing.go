@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"qr-service/pkg/tracing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// Tracing middleware membuat server span untuk setiap request. Trace context W3C dari
+// header traceparent diteruskan, span disimpan di c.UserContext() untuk handler dan service,
+// dan traceparent dikembalikan di response agar client bisa mencari trace-nya.
+func Tracing() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := tracing.Extract(c.UserContext(), requestCarrier{c})
+		ctx, span := tracing.Start(ctx, c.Method(), attribute.String("http.request.method", c.Method()))
+		defer span.End()
+		span.SetAttributes(attribute.String("url.path", c.Path()))
+
+		c.SetUserContext(ctx)
+		if traceparent := tracing.Traceparent(ctx); traceparent != "" {
+			c.Set(tracing.TraceparentHeader, traceparent)
+		}
+
+		if err := c.Next(); err != nil {
+			span.RecordError(err)
+			if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+				c.Status(fiber.StatusInternalServerError)
+			}
+		}
+
+		// Nama span memakai route (bukan path) setelah routing selesai
+		route := routeLabel(c)
+		status := c.Response().StatusCode()
+		span.SetName(c.Method() + " " + route)
+		span.SetAttributes(attribute.String("http.route", route), attribute.Int("http.response.status_code", status))
+		if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, "")
+		}
+		return nil
+	}
+}
+
+// requestCarrier membaca header trace context langsung dari request Fiber
+type requestCarrier struct {
+	c *fiber.Ctx
+}
+
+func (r requestCarrier) Get(key string) string {
+	return r.c.Get(key)
+}
+
+func (r requestCarrier) Set(key string, value string) {
+	r.c.Request().Header.Set(key, value)
+}
+
+func (r requestCarrier) Keys() []string {
+	return []string{tracing.TraceparentHeader, "tracestate", "baggage"}
+}
@@ -1,13 +1,18 @@
 package handler
 
 import (
+	"bufio"
+	"log/slog"
 	"qr-service/internal/model"
 	"qr-service/internal/service"
+	"qr-service/pkg/apperror"
+	"qr-service/pkg/metrics"
+	"qr-service/pkg/provider"
+	"qr-service/pkg/snap"
+	"qr-service/pkg/tracing"
 	"qr-service/pkg/util"
 	"strconv"
-	"strings"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -15,26 +20,41 @@ type TransactionHandler struct {
 	Service *service.TransactionService
 }
 
-// Middleware: 3. Validasi Signature Hash (HMAC-SHA256)
-func ValidateHMAC(c *fiber.Ctx) error {
+// callbackProviderKey key Locals untuk provider yang sudah mengautentikasi callback
+const callbackProviderKey = "callbackProvider"
+
+// Middleware: 3. Validasi Signature Hash (HMAC-SHA256) dengan secret dari konfigurasi
+func ValidateHMAC(secret string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if err := verifySignature(c, secret); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+// verifySignature memeriksa X-Signature terhadap X-TIMESTAMP, X-EXTERNAL-ID dan body
+// (snap.StringToSign) dalam span ValidateHMAC
+func verifySignature(c *fiber.Ctx, secret string) (err error) {
+	_, span := tracing.Start(c.UserContext(), "ValidateHMAC")
+	defer func() { tracing.End(span, err) }()
+
 	signature := c.Get("X-Signature")
 
-	body := string(c.Body())
+	data := snap.StringToSign(c.Get(snap.HeaderTimestamp), c.Get(snap.HeaderExternalID), c.Body())
 
 	if signature == "" {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"responseCode":    fiber.StatusUnauthorized,
-			"responseMessage": "Signature header missing"})
+		metrics.SignatureFailuresTotal.WithLabelValues("missing").Inc()
+		return apperror.New(apperror.Unauthorized, "Signature header missing")
 	}
 
-	if !util.ValidateHMACSHA256(signature, body) {
+	if !util.ValidateHMACSHA256(secret, signature, data) {
 		// Tanggapi request dengan status 401 Unauthorized
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"responseCode":    fiber.StatusUnauthorized,
-			"responseMessage": "Invalid Signature Hash"})
+		metrics.SignatureFailuresTotal.WithLabelValues("invalid").Inc()
+		return apperror.New(apperror.Unauthorized, "Invalid Signature Hash")
 	}
 
-	return c.Next()
+	return nil
 }
 
 // @Summary Generate QR Code
@@ -42,55 +62,31 @@ func ValidateHMAC(c *fiber.Ctx) error {
 // @Tags QR
 // @Accept json
 // @Produce json
-// @Param X-Signature header string true "HMAC-SHA256 Signature (Body Hash)"
+// @Param X-Signature header string true "HMAC-SHA256 dari X-TIMESTAMP:X-EXTERNAL-ID:body"
+// @Param X-TIMESTAMP header string true "Waktu request ISO 8601, maksimal 5 menit dari jam server (contoh: 2025-09-21T10:00:00+07:00)"
+// @Param X-PARTNER-ID header string true "Partner ID (maks. 36 karakter)"
+// @Param X-EXTERNAL-ID header string true "ID numerik unik per partner per hari (maks. 36 digit)"
+// @Param CHANNEL-ID header string true "Channel ID numerik (maks. 5 digit)"
 // @Param request body model.GenerateQRRequest true "Data Transaksi yang dibutuhkan"
 // @Success 200 {object} model.GenerateQRResponse
-// @Failure 400 {object} fiber.Map "Validasi input gagal (misalnya Amount <= 0 atau field kosong)"
-// @Failure 401 {object} fiber.Map "Signature Hash tidak valid (Unauthorized)"
-// @Failure 500 {object} fiber.Map "Gagal menyimpan data transaksi ke database"
+// @Failure 400 {object} model.ErrorResponse "Validasi input gagal (misalnya Amount <= 0 atau field kosong)"
+// @Failure 401 {object} model.ErrorResponse "Signature Hash tidak valid (Unauthorized)"
+// @Failure 403 {object} model.ErrorResponse "Ditolak rule risiko (limit amount, volume, atau velocity)"
+// @Failure 409 {object} model.ErrorResponse "Partner reference atau X-EXTERNAL-ID sudah dipakai"
+// @Failure 429 {object} model.ErrorResponse "Rate limit per partner terlampaui, lihat header Retry-After"
+// @Failure 500 {object} model.ErrorResponse "Gagal menyimpan data transaksi ke database"
 // @Router /qr/generate [post]
 func (h *TransactionHandler) GenerateQR(c *fiber.Ctx) error {
 	var req model.GenerateQRRequest
 
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"responseCode":    fiber.StatusBadRequest,
-			"responseMessage": "Invalid request body format",
-		})
-	}
-
-	validate := validator.New()
-	if err := validate.Struct(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"responseCode":    fiber.StatusBadRequest,
-			"responseMessage": "Validation failed: " + err.Error(),
-		})
+	if err := parseBody(c, &req); err != nil {
+		return err
 	}
+	req.ChannelID = c.Get(snap.HeaderChannelID)
 
-	resp, err := h.Service.GenerateQR(req)
-
+	resp, err := h.Service.GenerateQR(c.UserContext(), req)
 	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key") ||
-			strings.Contains(err.Error(), "unique constraint") ||
-			strings.Contains(err.Error(), "23505") ||
-			strings.Contains(err.Error(), "already exists") {
-			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
-				"responseCode":    fiber.StatusConflict,
-				"responseMessage": "Transaction with the same reference already exists",
-			})
-		}
-		if strings.Contains(err.Error(), "amount must be greater than 0") ||
-			strings.Contains(err.Error(), "invalid amount format") ||
-			strings.Contains(err.Error(), "only IDR currency is supported") {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"responseCode":    fiber.StatusBadRequest,
-				"responseMessage": err.Error(),
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"responseCode":    fiber.StatusInternalServerError,
-			"responseMessage": "Failed to process QR generation",
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(resp)
@@ -101,85 +97,152 @@ func (h *TransactionHandler) GenerateQR(c *fiber.Ctx) error {
 // @Tags QR
 // @Accept json
 // @Produce json
-// @Param X-Signature header string true "HMAC-SHA256 Signature (Body Hash)"
+// @Param X-Signature header string true "HMAC-SHA256 dari X-TIMESTAMP:X-EXTERNAL-ID:body"
+// @Param X-TIMESTAMP header string true "Waktu request ISO 8601, maksimal 5 menit dari jam server (contoh: 2025-09-21T10:00:00+07:00)"
+// @Param X-PARTNER-ID header string true "Partner ID (maks. 36 karakter)"
+// @Param X-EXTERNAL-ID header string true "ID numerik unik per partner per hari (maks. 36 digit)"
+// @Param CHANNEL-ID header string true "Channel ID numerik (maks. 5 digit)"
 // @Param request body model.PaymentCallbackRequest true "Data Callback Payment"
 // @Success 200 {object} model.PaymentCallbackResponse
-// @Failure 400 {object} fiber.Map "Input validasi gagal atau data mismatch"
-// @Failure 401 {object} fiber.Map "Signature Hash tidak valid"
-// @Failure 404 {object} fiber.Map "Reference Number tidak ditemukan"
-// @Failure 500 {object} fiber.Map "Gagal mengupdate status transaksi"
+// @Failure 400 {object} model.ErrorResponse "Input validasi gagal atau data mismatch"
+// @Failure 401 {object} model.ErrorResponse "Signature Hash tidak valid"
+// @Failure 404 {object} model.ErrorResponse "Reference Number tidak ditemukan, amount tidak sesuai atau status tidak dikenal"
+// @Failure 409 {object} model.ErrorResponse "X-EXTERNAL-ID sudah dipakai hari ini"
+// @Failure 429 {object} model.ErrorResponse "Rate limit per partner terlampaui, lihat header Retry-After"
+// @Failure 500 {object} model.ErrorResponse "Gagal mengupdate status transaksi"
 // @Router /qr/payment [post]
 func (h *TransactionHandler) ProcessPaymentCallback(c *fiber.Ctx) error {
 	var req model.PaymentCallbackRequest
 
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"responseCode":    fiber.StatusBadRequest,
-			"responseMessage": "Invalid request body format",
-		})
+	if err := parseBody(c, &req); err != nil {
+		return err
+	}
+
+	resp, err := h.Service.ProcessPaymentCallback(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// AuthenticateProviderCallback middleware route callback: mencari provider dari path dan
+// memverifikasi request lewat adapter-nya, sebelum header SNAP dan X-EXTERNAL-ID diperiksa
+func (h *TransactionHandler) AuthenticateProviderCallback(c *fiber.Ctx) error {
+	p, ok := h.Service.Providers.Get(c.Params("provider"))
+	if !ok {
+		return apperror.Newf(apperror.InvalidRouting, "unknown payment provider: %s", c.Params("provider"))
+	}
+
+	header := func(key string) string { return c.Get(key) }
+	if err := p.AuthenticateCallback(header, c.Body()); err != nil {
+		return err
 	}
+	c.Locals(callbackProviderKey, p)
+	return c.Next()
+}
 
-	validate := validator.New()
-	if err := validate.Struct(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"responseCode":    fiber.StatusBadRequest,
-			"responseMessage": "Validation failed: " + err.Error(),
-		})
+// @Summary Process Provider Payment Callback
+// @Description Endpoint callback untuk acquirer/payment gateway yang dikonfigurasi di providers. Autentikasi (contoh X-Signature HMAC) dan format body ditentukan adapter provider; adapter snap memakai format yang sama dengan /qr/payment.
+// @Description Setelah autentikasi, header SNAP diperiksa dan X-EXTERNAL-ID yang sudah dipakai hari ini ditolak agar callback tidak bisa di-replay.
+// @Tags QR
+// @Accept json
+// @Produce json
+// @Param provider path string true "Nama provider (contoh manjo)"
+// @Param X-Signature header string false "Signature sesuai provider (adapter snap: HMAC-SHA256 dari X-TIMESTAMP:X-EXTERNAL-ID:body dengan secret provider)"
+// @Param X-TIMESTAMP header string true "Waktu request ISO 8601, maksimal 5 menit dari jam server (contoh: 2025-09-21T10:00:00+07:00)"
+// @Param X-PARTNER-ID header string true "Partner ID (maks. 36 karakter)"
+// @Param X-EXTERNAL-ID header string true "ID numerik unik per partner per hari (maks. 36 digit)"
+// @Param CHANNEL-ID header string true "Channel ID numerik (maks. 5 digit)"
+// @Param request body model.PaymentCallbackRequest true "Data callback (adapter snap)"
+// @Success 200 {object} model.PaymentCallbackResponse
+// @Failure 400 {object} model.ErrorResponse "Body callback atau header SNAP tidak valid"
+// @Failure 401 {object} model.ErrorResponse "Autentikasi callback gagal"
+// @Failure 404 {object} model.ErrorResponse "Provider tidak dikenal, transaksi tidak ditemukan, data mismatch atau status tidak dikenal"
+// @Failure 409 {object} model.ErrorResponse "X-EXTERNAL-ID sudah dipakai hari ini"
+// @Failure 429 {object} model.ErrorResponse "Rate limit per IP terlampaui, lihat header Retry-After"
+// @Failure 500 {object} model.ErrorResponse "Gagal mengupdate status transaksi"
+// @Router /callbacks/{provider} [post]
+func (h *TransactionHandler) ProviderCallback(c *fiber.Ctx) error {
+	p, ok := c.Locals(callbackProviderKey).(provider.Provider)
+	if !ok {
+		return apperror.New(apperror.Internal, "callback provider not authenticated")
 	}
 
-	resp, err := h.Service.ProcessPaymentCallback(req)
+	event, err := p.ParseCallback(c.Body())
+	if err != nil {
+		return err
+	}
+	event.Provider = p.Name()
 
+	resp, err := h.Service.ProcessPaymentEvent(c.UserContext(), event)
 	if err != nil {
-		if strings.Contains(err.Error(), "transaction not found") {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"responseCode":    fiber.StatusNotFound,
-				"responseMessage": err.Error(),
-			})
-		}
-		if strings.Contains(err.Error(), "mismatch") ||
-			strings.Contains(err.Error(), "invalid") ||
-			strings.Contains(err.Error(), "only IDR") ||
-			strings.Contains(err.Error(), "invalid transaction status") {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"responseCode":    fiber.StatusBadRequest,
-				"responseMessage": err.Error(),
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"responseCode":    fiber.StatusInternalServerError,
-			"responseMessage": "Failed to process payment callback: " + err.Error(),
-		})
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// @Summary Inquire Transaction Status
+// @Description Endpoint untuk menanyakan status transaksi ke provider yang membuat QR-nya, lalu menerapkan hasilnya seperti callback. Berguna jika callback tidak pernah diterima.
+// @Tags Transactions
+// @Produce json
+// @Param X-API-KEY header string true "API key admin, atau key merchant untuk transaksi merchant sendiri"
+// @Param referenceNo path string true "Reference Number internal"
+// @Success 200 {object} model.StatusInquiryResponse
+// @Failure 401 {object} model.ErrorResponse "API key tidak valid"
+// @Failure 404 {object} model.ErrorResponse "Transaksi tidak ditemukan, milik merchant lain, atau status dari provider tidak dikenal"
+// @Failure 405 {object} model.ErrorResponse "Provider tidak mendukung inquiry status"
+// @Failure 500 {object} model.ErrorResponse "Inquiry ke provider gagal"
+// @Router /transactions/{referenceNo}/inquiry [post]
+func (h *TransactionHandler) InquireStatus(c *fiber.Ctx) error {
+	resp, err := h.Service.InquireStatus(c.UserContext(), c.Params("referenceNo"), callerMerchant(c))
+	if err != nil {
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(resp)
 }
 
 // @Summary Get All Transactions
-// @Description Endpoint untuk mendapatkan semua transaksi dengan filter dan pagination
+// @Description Endpoint untuk mendapatkan semua transaksi dengan filter, sorting dan pagination.
+// @Description Pagination bisa memakai page/limit atau cursor after/before (keyset created_at,id) yang stabil saat transaksi baru masuk.
+// @Description Tanggal YYYY-MM-DD dibaca di zona waktu `timezone` (default Asia/Jakarta), batas akhir inklusif.
 // @Tags QR
 // @Accept json
 // @Produce json
 // @Param referenceNumber query string false "Filter by Reference Number"
-// @Param customerId query string false "Filter by Customer ID"
-// @Param status query string false "Filter by Status (Success, Failed, Pending, Expired, Paid, SUCCESS, FAILED, PENDING, EXPIRED)"
-// @Param startDate query string false "Start Date (format: YYYY-MM-DD)"
-// @Param endDate query string false "End Date (format: YYYY-MM-DD)"
+// @Param referenceMatch query string false "Cara mencocokkan referenceNumber: exact (default) atau prefix"
+// @Param customerId query string false "Filter by PAN pembayar (masked atau lengkap, dicocokkan dalam bentuk masked)"
+// @Param rrn query string false "Filter by RRN dari issuer"
+// @Param issuerId query string false "Filter by Issuer ID"
+// @Param merchantId query string false "Filter by Merchant ID"
+// @Param outletId query string false "Filter by Outlet ID"
+// @Param terminalId query string false "Filter by Terminal ID"
+// @Param status query string false "Filter by Status, bisa lebih dari satu dipisah koma (contoh: PAID,PENDING)"
+// @Param minAmount query number false "Amount minimum"
+// @Param maxAmount query number false "Amount maksimum"
+// @Param currency query string false "Filter by Currency (contoh: IDR)"
+// @Param startDate query string false "Created date awal (YYYY-MM-DD atau RFC3339)"
+// @Param endDate query string false "Created date akhir (YYYY-MM-DD atau RFC3339)"
+// @Param paidStartDate query string false "Paid date awal (YYYY-MM-DD atau RFC3339)"
+// @Param paidEndDate query string false "Paid date akhir (YYYY-MM-DD atau RFC3339)"
+// @Param timezone query string false "Zona waktu IANA untuk tanggal (default: Asia/Jakarta)"
+// @Param search query string false "Cari di reference, partner reference, merchant ID, trx ID, RRN, PAN, nama pembayar atau issuer"
+// @Param sort query string false "Kolom sort: created_at (default), amount, paid_date, status"
+// @Param order query string false "Arah sort: desc (default) atau asc"
 // @Param page query int false "Page number (default: 1)"
 // @Param limit query int false "Limit per page (default: 10, max: 100)"
+// @Param after query string false "Cursor dari cursor.next, ambil halaman berikutnya (hanya untuk sort created_at)"
+// @Param before query string false "Cursor dari cursor.prev, ambil halaman sebelumnya (hanya untuk sort created_at)"
+// @Param includeTotal query bool false "Hitung total (default: true untuk page, false untuk cursor)"
 // @Success 200 {object} model.GetTransactionsResponse
-// @Failure 400 {object} fiber.Map "Invalid filter parameters"
-// @Failure 500 {object} fiber.Map "Internal server error"
-// @Router /qr/transactions [get]
+// @Failure 400 {object} model.ErrorResponse "Invalid filter parameters"
+// @Failure 429 {object} model.ErrorResponse "Rate limit per IP terlampaui, lihat header Retry-After"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /transactions [get]
 func (h *TransactionHandler) GetTransactions(c *fiber.Ctx) error {
-	var req model.GetTransactionsRequest
-
-	// Parse query parameters
-	req.ReferenceNumber = c.Query("referenceNumber")
-	req.CustomerID = c.Query("customerId")
-	req.Status = c.Query("status")
-	req.StartDate = c.Query("startDate")
-	req.EndDate = c.Query("endDate")
-	req.Search = c.Query("search")
+	req := transactionsQuery(c)
 
 	// Parse pagination parameters dengan default values
 	page, err := strconv.Atoi(c.Query("page", "1"))
@@ -197,24 +260,136 @@ func (h *TransactionHandler) GetTransactions(c *fiber.Ctx) error {
 	// Panggil service
 	resp, err := h.Service.GetTransactions(req)
 	if err != nil {
-		if err.Error() == "invalid status" {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"responseCode":    fiber.StatusBadRequest,
-				"responseMessage": "Invalid status parameter",
-			})
-		}
-		if err.Error() == "invalid start date format, use YYYY-MM-DD" ||
-			err.Error() == "invalid end date format, use YYYY-MM-DD" {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"responseCode":    fiber.StatusBadRequest,
-				"responseMessage": err.Error(),
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"responseCode":    fiber.StatusInternalServerError,
-			"responseMessage": "Failed to retrieve transactions",
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(resp)
 }
+
+// transactionsQuery membaca query parameter filter transaksi, dipakai list dan export
+func transactionsQuery(c *fiber.Ctx) model.GetTransactionsRequest {
+	var req model.GetTransactionsRequest
+
+	req.ReferenceNumber = c.Query("referenceNumber")
+	req.ReferenceMatch = c.Query("referenceMatch")
+	req.CustomerID = c.Query("customerId")
+	req.RRN = c.Query("rrn")
+	req.IssuerID = c.Query("issuerId")
+	req.MerchantID = c.Query("merchantId")
+	req.OutletID = c.Query("outletId")
+	req.TerminalID = c.Query("terminalId")
+	req.Status = c.Query("status")
+	req.MinAmount = c.Query("minAmount")
+	req.MaxAmount = c.Query("maxAmount")
+	req.Currency = c.Query("currency")
+	req.StartDate = c.Query("startDate")
+	req.EndDate = c.Query("endDate")
+	req.PaidStartDate = c.Query("paidStartDate")
+	req.PaidEndDate = c.Query("paidEndDate")
+	req.Timezone = c.Query("timezone")
+	req.Search = c.Query("search")
+	req.Sort = c.Query("sort")
+	req.Order = c.Query("order")
+	req.After = c.Query("after")
+	req.Before = c.Query("before")
+	if c.Query("includeTotal") != "" {
+		includeTotal := c.QueryBool("includeTotal")
+		req.IncludeTotal = &includeTotal
+	}
+	return req
+}
+
+// @Summary Get Transaction Statistics
+// @Description Endpoint untuk statistik dashboard yang dihitung di database: jumlah dan nominal per status, success rate, rata-rata ticket dan time series per jam/hari/minggu.
+// @Tags Transactions
+// @Produce json
+// @Param merchantId query string false "Filter by Merchant ID"
+// @Param outletId query string false "Filter by Outlet ID"
+// @Param terminalId query string false "Filter by Terminal ID"
+// @Param startDate query string false "Created date awal (YYYY-MM-DD atau RFC3339, default: hari ini)"
+// @Param endDate query string false "Created date akhir (YYYY-MM-DD atau RFC3339)"
+// @Param timezone query string false "Zona waktu IANA untuk tanggal dan bucket (default: Asia/Jakarta)"
+// @Param interval query string false "Bucket time series: hour, day atau week (default: hour untuk <= 2 hari, selain itu day)"
+// @Success 200 {object} model.TransactionStatsResponse
+// @Failure 400 {object} model.ErrorResponse "Invalid parameters"
+// @Failure 429 {object} model.ErrorResponse "Rate limit per IP terlampaui, lihat header Retry-After"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /transactions/stats [get]
+func (h *TransactionHandler) GetStats(c *fiber.Ctx) error {
+	var req model.GetTransactionStatsRequest
+
+	req.MerchantID = c.Query("merchantId")
+	req.OutletID = c.Query("outletId")
+	req.TerminalID = c.Query("terminalId")
+	req.StartDate = c.Query("startDate")
+	req.EndDate = c.Query("endDate")
+	req.Timezone = c.Query("timezone")
+	req.Interval = c.Query("interval")
+
+	resp, err := h.Service.GetStats(req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// @Summary Export Transactions
+// @Description Endpoint untuk export transaksi ke CSV atau XLSX dengan filter yang sama seperti GET /transactions. Hasil di-stream dari database, tanggal dalam WIB (atau timezone), dan diakhiri footer total.
+// @Tags Transactions
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Format file: csv (default) atau xlsx"
+// @Param referenceNumber query string false "Filter by Reference Number"
+// @Param referenceMatch query string false "Cara mencocokkan referenceNumber: exact (default) atau prefix"
+// @Param customerId query string false "Filter by PAN pembayar (masked atau lengkap, dicocokkan dalam bentuk masked)"
+// @Param rrn query string false "Filter by RRN dari issuer"
+// @Param issuerId query string false "Filter by Issuer ID"
+// @Param merchantId query string false "Filter by Merchant ID"
+// @Param outletId query string false "Filter by Outlet ID"
+// @Param terminalId query string false "Filter by Terminal ID"
+// @Param status query string false "Filter by Status, bisa lebih dari satu dipisah koma (contoh: PAID,PENDING)"
+// @Param minAmount query number false "Amount minimum"
+// @Param maxAmount query number false "Amount maksimum"
+// @Param currency query string false "Filter by Currency (contoh: IDR)"
+// @Param startDate query string false "Created date awal (YYYY-MM-DD atau RFC3339)"
+// @Param endDate query string false "Created date akhir (YYYY-MM-DD atau RFC3339)"
+// @Param paidStartDate query string false "Paid date awal (YYYY-MM-DD atau RFC3339)"
+// @Param paidEndDate query string false "Paid date akhir (YYYY-MM-DD atau RFC3339)"
+// @Param timezone query string false "Zona waktu IANA untuk filter tanggal dan isi file (default: Asia/Jakarta)"
+// @Param search query string false "Cari di reference, partner reference, merchant ID, trx ID, RRN, PAN, nama pembayar atau issuer"
+// @Param sort query string false "Kolom sort: created_at (default), amount, paid_date, status"
+// @Param order query string false "Arah sort: desc (default) atau asc"
+// @Success 200 {file} file "File CSV atau XLSX"
+// @Failure 400 {object} model.ErrorResponse "Invalid filter parameters"
+// @Failure 429 {object} model.ErrorResponse "Rate limit per IP terlampaui, lihat header Retry-After"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Router /transactions/export [get]
+func (h *TransactionHandler) ExportTransactions(c *fiber.Ctx) error {
+	exp, err := h.Service.ExportTransactions(transactionsQuery(c), c.Query("format"))
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, exp.ContentType)
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+exp.Filename+`"`)
+
+	// Stream ke client; status 200 sudah terkirim, jadi error di tengah jalan hanya bisa dicatat
+	ctx := c.UserContext()
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := exp.Write(w); err != nil {
+			slog.ErrorContext(ctx, "Failed to export transactions", "error", err)
+		}
+	})
+	return nil
+}
+
+// @Summary Get Status Mappings
+// @Description Endpoint admin untuk melihat tabel pemetaan status gateway ke status internal yang sedang berlaku. Tabel "default" dipakai gateway tanpa tabel sendiri; status di luar tabel ditolak.
+// @Tags QR
+// @Produce json
+// @Success 200 {object} model.StatusMappingsResponse
+// @Router /status-mappings [get]
+func (h *TransactionHandler) GetStatusMappings(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(h.Service.GetStatusMappings())
+}
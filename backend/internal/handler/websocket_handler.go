@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"sync"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -11,8 +13,18 @@ import (
 	ws "qr-service/pkg/websocket"
 )
 
+// subscribeMessage adalah message dari client untuk mengganti filter subscription
+type subscribeMessage struct {
+	Type string `json:"type"`
+	ws.Subscription
+}
+
+// shutdownReconnectAfter saran jeda reconnect untuk client saat server shutdown
+const shutdownReconnectAfter = 5 * time.Second
+
 type WebSocketHandler struct {
-	hub *ws.Hub
+	hub   *ws.Hub
+	conns sync.WaitGroup // writePump yang masih berjalan
 }
 
 func NewWebSocketHandler(hub *ws.Hub) *WebSocketHandler {
@@ -36,10 +48,18 @@ func (h *WebSocketHandler) WebSocketConnection(c *fiberws.Conn) {
 		Send: make(chan []byte, 256),
 	}
 
+	// Filter awal dari query string, contoh: /ws?merchantId=M001&terminalId=T01
+	client.Subscribe(ws.Subscription{
+		MerchantID: c.Query("merchantId"),
+		OutletID:   c.Query("outletId"),
+		TerminalID: c.Query("terminalId"),
+	})
+
 	// Register client
 	h.hub.Register(client)
 
 	// Goroutine untuk mengirim messages ke client
+	h.conns.Add(1)
 	go h.writePump(c, client)
 
 	// Goroutine untuk membaca messages dari client (keep connection alive)
@@ -51,14 +71,56 @@ func (h *WebSocketHandler) writePump(c *fiberws.Conn, client *ws.Client) {
 	defer func() {
 		h.hub.Unregister(client)
 		c.Close()
+		h.conns.Done()
 	}()
 
 	// Gunakan for range pada channel
 	for message := range client.Send {
 		if err := c.WriteMessage(fiberws.TextMessage, message); err != nil {
-			break
+			return
 		}
 	}
+
+	// Send ditutup karena hub shutdown: beri tahu client kapan reconnect lalu kirim close frame
+	select {
+	case <-h.hub.Stopping():
+		h.sendShutdown(c)
+	default:
+	}
+}
+
+// sendShutdown mengirim {"type":"SERVER_SHUTDOWN","reconnect_after_ms":5000} lalu close frame
+// 1012 (Service Restart) agar client tahu harus reconnect, bukan menganggap koneksi rusak
+func (h *WebSocketHandler) sendShutdown(c *fiberws.Conn) {
+	deadline := time.Now().Add(time.Second)
+	c.SetWriteDeadline(deadline)
+
+	notice, _ := json.Marshal(map[string]interface{}{
+		"type":               "SERVER_SHUTDOWN",
+		"reconnect_after_ms": shutdownReconnectAfter.Milliseconds(),
+		"timestamp":          time.Now().Unix(),
+	})
+	if err := c.WriteMessage(fiberws.TextMessage, notice); err != nil {
+		return
+	}
+	closeFrame := fiberws.FormatCloseMessage(fiberws.CloseServiceRestart, "server restarting, reconnect in "+shutdownReconnectAfter.String())
+	c.WriteControl(fiberws.CloseMessage, closeFrame, deadline)
+}
+
+// Wait menunggu semua koneksi WebSocket selesai mengirim close frame setelah hub dihentikan
+func (h *WebSocketHandler) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		h.conns.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 // readPump handles receiving messages from the client
@@ -76,7 +138,7 @@ func (h *WebSocketHandler) readPump(c *fiberws.Conn, client *ws.Client) {
 	})
 
 	for {
-		messageType, _, err := c.ReadMessage()
+		messageType, data, err := c.ReadMessage()
 		if err != nil {
 			break
 		}
@@ -84,6 +146,15 @@ func (h *WebSocketHandler) readPump(c *fiberws.Conn, client *ws.Client) {
 			break
 		}
 
+		// Client bisa mengganti filter dengan mengirim
+		// {"type":"SUBSCRIBE","merchant_id":"...","outlet_id":"...","terminal_id":"..."}
+		if messageType == fiberws.TextMessage {
+			var req subscribeMessage
+			if err := json.Unmarshal(data, &req); err == nil && req.Type == "SUBSCRIBE" {
+				client.Subscribe(req.Subscription)
+			}
+		}
+
 		// Reset read deadline untuk keep connection alive
 		c.SetReadDeadline(time.Now().Add(60 * time.Second))
 	}
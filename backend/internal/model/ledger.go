@@ -0,0 +1,119 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Tipe akun ledger
+const (
+	AccountAsset     = "ASSET"
+	AccountLiability = "LIABILITY"
+	AccountRevenue   = "REVENUE"
+)
+
+// Tipe journal entry
+const (
+	EntryPayment    = "PAYMENT"
+	EntryRefund     = "REFUND"
+	EntryFee        = "FEE"
+	EntrySettlement = "SETTLEMENT"
+)
+
+// Arah posting
+const (
+	Debit  = "DEBIT"
+	Credit = "CREDIT"
+)
+
+// Kode akun tetap milik platform
+const (
+	AcquirerClearingAccount = "ACQUIRER_CLEARING" // dana yang masih di acquirer
+	FeeRevenueAccount       = "FEE_REVENUE"       // pendapatan MDR
+)
+
+// MerchantPayableAccount kode akun hutang ke merchant
+func MerchantPayableAccount(merchantID string) string {
+	return "MERCHANT_PAYABLE:" + merchantID
+}
+
+// LedgerAccount akun di double-entry ledger
+type LedgerAccount struct {
+	gorm.Model
+	Code       string `json:"code" gorm:"unique;not null"`
+	Type       string `json:"type" gorm:"not null"`
+	MerchantID string `json:"merchant_id" gorm:"index"`
+}
+
+// JournalEntry satu kejadian keuangan; jumlah debit dan kredit postingnya selalu sama.
+// Append-only: entry dan posting tidak pernah diupdate atau dihapus.
+type JournalEntry struct {
+	ID          uint      `json:"id" gorm:"primarykey"`
+	CreatedAt   time.Time `json:"created_at"`
+	EntryType   string    `json:"entry_type" gorm:"not null;index"`
+	Reference   string    `json:"reference" gorm:"unique;not null"` // idempotency key, contoh: PAYMENT:A0000581937281024001
+	MerchantID  string    `json:"merchant_id" gorm:"index"`
+	Description string    `json:"description"`
+	Postings    []Posting `json:"postings,omitempty" gorm:"foreignKey:EntryID"`
+}
+
+// Posting satu baris debit/kredit ke sebuah akun
+type Posting struct {
+	ID          uint      `json:"id" gorm:"primarykey"`
+	CreatedAt   time.Time `json:"created_at"`
+	EntryID     uint      `json:"entry_id" gorm:"not null;index"`
+	AccountCode string    `json:"account_code" gorm:"not null;index"`
+	Direction   string    `json:"direction" gorm:"not null"`
+	Amount      float64   `json:"amount" gorm:"type:numeric(18,2);not null"`
+}
+
+// MerchantBalance saldo merchant yang dihitung dari ledger
+type MerchantBalance struct {
+	MerchantID    string  `json:"merchant_id"`
+	Balance       float64 `json:"balance"` // hutang ke merchant yang belum dibayarkan
+	TotalPayments float64 `json:"total_payments"`
+	TotalRefunds  float64 `json:"total_refunds"`
+	TotalFees     float64 `json:"total_fees"`
+	TotalSettled  float64 `json:"total_settled"`
+	Currency      string  `json:"currency"`
+}
+
+// MerchantBalanceResponse response untuk GET /api/v1/merchants/:merchantId/balance
+type MerchantBalanceResponse struct {
+	ResponseCode    string          `json:"responseCode"`
+	ResponseMessage string          `json:"responseMessage"`
+	Data            MerchantBalance `json:"data"`
+}
+
+// LedgerCheck hasil pengecekan konsistensi ledger
+type LedgerCheck struct {
+	Balanced          bool    `json:"balanced"`
+	EntryCount        int64   `json:"entry_count"`
+	TotalDebit        float64 `json:"total_debit"`
+	TotalCredit       float64 `json:"total_credit"`
+	UnbalancedEntries []uint  `json:"unbalanced_entries"`
+}
+
+// LedgerCheckResponse response untuk GET /api/v1/ledger/check
+type LedgerCheckResponse struct {
+	ResponseCode    string      `json:"responseCode"`
+	ResponseMessage string      `json:"responseMessage"`
+	Data            LedgerCheck `json:"data"`
+}
+
+// GetJournalEntriesRequest query parameter GET /api/v1/ledger/entries
+type GetJournalEntriesRequest struct {
+	MerchantID string `json:"merchantId,omitempty" query:"merchantId"`
+	EntryType  string `json:"entryType,omitempty" query:"entryType"`
+	Page       int    `json:"page,omitempty" query:"page"`
+	Limit      int    `json:"limit,omitempty" query:"limit"`
+}
+
+// JournalEntriesResponse response untuk list journal entry
+type JournalEntriesResponse struct {
+	ResponseCode    string          `json:"responseCode"`
+	ResponseMessage string          `json:"responseMessage"`
+	Data            []JournalEntry  `json:"data"`
+	Pagination      *PaginationInfo `json:"pagination,omitempty"`
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"gorm.io/gorm"
+)
+
+// Merchant profil merchant. Category menentukan MDR yang dipakai saat settlement
+// (UMI, UKE, UME, UBE, EDU, SPBU, GOV); default UMI sesuai kriteria di template QR.
+type Merchant struct {
+	gorm.Model
+	MerchantID  string `json:"merchant_id" gorm:"unique;not null"`
+	Name        string `json:"name"`
+	Category    string `json:"category" gorm:"not null;default:'UMI'"`
+	ReportEmail string `json:"report_email"` // tujuan email laporan harian, kosong = tidak dikirim
+}
+
+// Outlet adalah toko/cabang milik sebuah merchant
+type Outlet struct {
+	gorm.Model
+	OutletID   string     `json:"outlet_id" gorm:"unique;not null"`
+	MerchantID string     `json:"merchant_id" gorm:"not null;index"`
+	Name       string     `json:"name" gorm:"not null"`
+	Address    string     `json:"address"`
+	Terminals  []Terminal `json:"terminals,omitempty" gorm:"foreignKey:OutletID;references:OutletID"`
+}
+
+// Terminal adalah kasir/till di dalam sebuah outlet.
+// TerminalID ikut di-encode ke QR (tag 62 sub-tag 07).
+type Terminal struct {
+	gorm.Model
+	TerminalID string `json:"terminal_id" gorm:"unique;not null"`
+	OutletID   string `json:"outlet_id" gorm:"not null;index"`
+	MerchantID string `json:"merchant_id" gorm:"not null;index"`
+	Name       string `json:"name"`
+}
+
+// Request Body untuk PUT /api/v1/merchants/:merchantId
+type SaveMerchantRequest struct {
+	Name        string `json:"name"`
+	Category    string `json:"category" validate:"required,oneof=UMI UKE UME UBE EDU SPBU GOV"`
+	ReportEmail string `json:"reportEmail" validate:"omitempty,email"`
+}
+
+// Request Body untuk membuat outlet baru
+type CreateOutletRequest struct {
+	OutletID string `json:"outletId" validate:"required,max=25"`
+	Name     string `json:"name" validate:"required"`
+	Address  string `json:"address"`
+}
+
+// Request Body untuk membuat terminal baru
+type CreateTerminalRequest struct {
+	TerminalID string `json:"terminalId" validate:"required,max=25"`
+	Name       string `json:"name"`
+}
+
+// MerchantResponse response untuk endpoint profil merchant
+type MerchantResponse struct {
+	ResponseCode    string   `json:"responseCode"`
+	ResponseMessage string   `json:"responseMessage"`
+	Data            Merchant `json:"data"`
+}
+
+// OutletResponse response untuk endpoint outlet
+type OutletResponse struct {
+	ResponseCode    string `json:"responseCode"`
+	ResponseMessage string `json:"responseMessage"`
+	Data            Outlet `json:"data"`
+}
+
+// TerminalResponse response untuk endpoint terminal
+type TerminalResponse struct {
+	ResponseCode    string   `json:"responseCode"`
+	ResponseMessage string   `json:"responseMessage"`
+	Data            Terminal `json:"data"`
+}
+
+// GetOutletsResponse response untuk list outlet milik merchant
+type GetOutletsResponse struct {
+	ResponseCode    string   `json:"responseCode"`
+	ResponseMessage string   `json:"responseMessage"`
+	Data            []Outlet `json:"data"`
+}
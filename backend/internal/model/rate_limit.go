@@ -0,0 +1,11 @@
+package model
+
+// RateLimitBucket state token bucket rate limit yang dibagi semua instance.
+// RefilledAt dalam unix mikrodetik agar perhitungan refill bisa dilakukan di SQL yang sama
+// untuk Postgres dan SQLite.
+type RateLimitBucket struct {
+	BucketKey  string  `gorm:"primaryKey"`
+	Tokens     float64 `gorm:"not null"`
+	Allowed    bool    `gorm:"not null"` // hasil Take terakhir
+	RefilledAt int64   `gorm:"not null;index"`
+}
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Kategori hasil pencocokan file settlement dengan tabel transaksi
+const (
+	ReconMatched        = "MATCHED"
+	ReconMissingOurs    = "MISSING_ON_OUR_SIDE"   // ada di file settlement, tidak ada di database
+	ReconMissingTheirs  = "MISSING_ON_THEIR_SIDE" // PAID di database, tidak ada di file settlement
+	ReconAmountMismatch = "AMOUNT_MISMATCH"
+	// ReconCurrencyMismatch currency di file berbeda dengan transaksi (baris tanpa currency tidak dibandingkan)
+	ReconCurrencyMismatch = "CURRENCY_MISMATCH"
+	// ReconDuplicate reference sudah muncul di baris sebelumnya pada file yang sama
+	ReconDuplicate = "DUPLICATE"
+)
+
+// ReconciliationRun satu kali proses rekonsiliasi terhadap sebuah file settlement
+type ReconciliationRun struct {
+	gorm.Model
+	FileName         string               `json:"file_name"`
+	Format           string               `json:"format" gorm:"not null"`
+	PeriodStart      time.Time            `json:"period_start"`
+	PeriodEnd        time.Time            `json:"period_end"`
+	TotalRecords     int                  `json:"total_records"`
+	Matched          int                  `json:"matched"`
+	MissingOurs      int                  `json:"missing_on_our_side"`
+	MissingTheirs    int                  `json:"missing_on_their_side"`
+	AmountMismatch   int                  `json:"amount_mismatch"`
+	CurrencyMismatch int                  `json:"currency_mismatch"`
+	Duplicate        int                  `json:"duplicate"`
+	Corrected        int                  `json:"corrected"`
+	Items            []ReconciliationItem `json:"items,omitempty" gorm:"foreignKey:RunID"`
+}
+
+// ReconciliationItem detail per baris hasil rekonsiliasi
+type ReconciliationItem struct {
+	gorm.Model
+	RunID              uint       `json:"run_id" gorm:"not null;index"`
+	Category           string     `json:"category" gorm:"not null;index"`
+	Line               int        `json:"line,omitempty"` // nomor baris di file settlement (0 jika tidak ada di file)
+	ReferenceNo        string     `json:"reference_no" gorm:"index"`
+	PartnerReferenceNo string     `json:"partner_reference_no"`
+	OurAmount          *float64   `json:"our_amount"`
+	TheirAmount        *float64   `json:"their_amount"`
+	OurCurrency        string     `json:"our_currency"`
+	TheirCurrency      string     `json:"their_currency"`
+	OurStatus          string     `json:"our_status"`
+	TheirStatus        string     `json:"their_status"`
+	PaidTime           *time.Time `json:"paid_time"`
+	Corrected          bool       `json:"corrected"` // status transaksi dikoreksi menjadi PAID
+}
+
+// ReconcileRequest parameter proses rekonsiliasi
+type ReconcileRequest struct {
+	FileName string `json:"fileName"`
+	Format   string `json:"format" query:"format"`
+	Date     string `json:"date,omitempty" query:"date"`       // YYYY-MM-DD (WIB), default diturunkan dari paid_time di file
+	Correct  bool   `json:"correct,omitempty" query:"correct"` // koreksi status transaksi yang callback-nya tidak pernah datang
+}
+
+// ReconciliationResponse response laporan rekonsiliasi
+type ReconciliationResponse struct {
+	ResponseCode    string            `json:"responseCode"`
+	ResponseMessage string            `json:"responseMessage"`
+	Data            ReconciliationRun `json:"data"`
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Format file laporan harian yang bisa diunduh
+const (
+	ReportFormatHTML = "html"
+	ReportFormatCSV  = "csv"
+)
+
+// DailyReport rekap harian transaksi satu merchant (tanggal dalam WIB).
+// Isi HTML dan CSV disimpan agar laporan yang sudah dikirim bisa diunduh ulang apa adanya.
+type DailyReport struct {
+	gorm.Model
+	MerchantID    string     `json:"merchant_id" gorm:"not null;uniqueIndex:idx_daily_reports_merchant_date"`
+	ReportDate    string     `json:"report_date" gorm:"not null;uniqueIndex:idx_daily_reports_merchant_date"` // YYYY-MM-DD
+	TotalCount    int64      `json:"total_count"`
+	TotalAmount   float64    `json:"total_amount"`
+	PaidCount     int64      `json:"paid_count"`
+	PaidAmount    float64    `json:"paid_amount"`
+	FailedCount   int64      `json:"failed_count"`
+	FailedAmount  float64    `json:"failed_amount"`
+	ExpiredCount  int64      `json:"expired_count"`
+	ExpiredAmount float64    `json:"expired_amount"`
+	PendingCount  int64      `json:"pending_count"`
+	PendingAmount float64    `json:"pending_amount"`
+	SuccessRate   float64    `json:"success_rate"` // PAID / semua transaksi, 0..1
+	TopHours      []HourStat `json:"top_hours" gorm:"serializer:json"`
+	HTML          string     `json:"-"`
+	CSV           string     `json:"-"`
+	EmailedTo     string     `json:"emailed_to,omitempty"`
+	EmailedAt     *time.Time `json:"emailed_at,omitempty"`
+	EmailError    string     `json:"email_error,omitempty"`
+}
+
+// HourStat transaksi dalam satu jam (WIB)
+type HourStat struct {
+	Hour       int     `json:"hour"` // 0-23
+	Count      int64   `json:"count"`
+	Amount     float64 `json:"amount"`
+	PaidCount  int64   `json:"paid_count"`
+	PaidAmount float64 `json:"paid_amount"`
+}
+
+// Request Body untuk POST /api/v1/reports/daily/run
+type RunDailyReportsRequest struct {
+	Date       string `json:"date"`                 // YYYY-MM-DD (WIB), default kemarin
+	MerchantID string `json:"merchantId,omitempty"` // kosong = semua merchant yang bertransaksi
+	SendEmail  bool   `json:"sendEmail"`
+}
+
+// GetDailyReportsRequest query parameter GET /api/v1/reports/daily
+type GetDailyReportsRequest struct {
+	MerchantID string `json:"merchantId,omitempty" query:"merchantId"`
+	StartDate  string `json:"startDate,omitempty" query:"startDate"` // YYYY-MM-DD
+	EndDate    string `json:"endDate,omitempty" query:"endDate"`     // YYYY-MM-DD
+	Page       int    `json:"page,omitempty" query:"page"`
+	Limit      int    `json:"limit,omitempty" query:"limit"`
+}
+
+// DailyReportsResponse response untuk list laporan harian
+type DailyReportsResponse struct {
+	ResponseCode    string               `json:"responseCode"`
+	ResponseMessage string               `json:"responseMessage"`
+	Data            []DailyReport        `json:"data"`
+	Failed          []DailyReportFailure `json:"failed,omitempty"` // merchant yang laporannya gagal dibuat
+	Pagination      *PaginationInfo      `json:"pagination,omitempty"`
+}
+
+// DailyReportFailure merchant yang laporannya gagal dibuat atau disimpan dalam satu run
+type DailyReportFailure struct {
+	MerchantID string `json:"merchant_id"`
+	Error      string `json:"error"`
+}
+
+// DailyReportResponse response untuk satu laporan harian
+type DailyReportResponse struct {
+	ResponseCode    string      `json:"responseCode"`
+	ResponseMessage string      `json:"responseMessage"`
+	Data            DailyReport `json:"data"`
+}
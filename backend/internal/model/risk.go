@@ -0,0 +1,79 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DefaultRiskMerchantID adalah merchant_id untuk limit default yang berlaku
+// bagi merchant yang tidak punya konfigurasi sendiri
+const DefaultRiskMerchantID = "*"
+
+// RiskLimit konfigurasi rule risiko per merchant. Nilai 0 berarti tanpa batas.
+type RiskLimit struct {
+	gorm.Model
+	MerchantID                    string  `json:"merchant_id" gorm:"unique;not null"`
+	MinAmount                     float64 `json:"min_amount"`
+	MaxAmount                     float64 `json:"max_amount"`
+	DailyVolumeCap                float64 `json:"daily_volume_cap"`                  // total amount PENDING + PAID per hari (WIB)
+	MonthlyVolumeCap              float64 `json:"monthly_volume_cap"`                // total amount PENDING + PAID per bulan (WIB)
+	MaxGeneratesPerMinute         int     `json:"max_generates_per_minute"`          // per merchant
+	MaxTerminalGeneratesPerMinute int     `json:"max_terminal_generates_per_minute"` // per terminal
+	MonitorOnly                   bool    `json:"monitor_only"`                      // true = hanya dicatat, tidak ditolak
+}
+
+// RiskRuleHit catatan setiap kali sebuah rule terpicu, untuk tuning threshold
+type RiskRuleHit struct {
+	gorm.Model
+	MerchantID         string  `json:"merchant_id" gorm:"not null;index"`
+	OutletID           string  `json:"outlet_id"`
+	TerminalID         string  `json:"terminal_id"`
+	PartnerReferenceNo string  `json:"partner_reference_no"`
+	Amount             float64 `json:"amount"`
+	Rule               string  `json:"rule" gorm:"not null;index"`
+	ResponseCode       string  `json:"response_code"`
+	Detail             string  `json:"detail"`
+	Enforced           bool    `json:"enforced"`
+}
+
+// RiskMerchantLock baris yang dikunci selama rule risiko dievaluasi dan transaksi disimpan,
+// sehingga generate QR paralel untuk merchant yang sama berjalan bergantian
+type RiskMerchantLock struct {
+	MerchantID string    `gorm:"primaryKey"`
+	LockedAt   time.Time `gorm:"not null"`
+}
+
+// Request Body untuk PUT /api/v1/merchants/:merchantId/limits
+type SetRiskLimitRequest struct {
+	MinAmount                     float64 `json:"minAmount" validate:"gte=0"`
+	MaxAmount                     float64 `json:"maxAmount" validate:"gte=0"`
+	DailyVolumeCap                float64 `json:"dailyVolumeCap" validate:"gte=0"`
+	MonthlyVolumeCap              float64 `json:"monthlyVolumeCap" validate:"gte=0"`
+	MaxGeneratesPerMinute         int     `json:"maxGeneratesPerMinute" validate:"gte=0"`
+	MaxTerminalGeneratesPerMinute int     `json:"maxTerminalGeneratesPerMinute" validate:"gte=0"`
+	MonitorOnly                   bool    `json:"monitorOnly"`
+}
+
+// RiskLimitResponse response untuk endpoint limit merchant
+type RiskLimitResponse struct {
+	ResponseCode    string    `json:"responseCode"`
+	ResponseMessage string    `json:"responseMessage"`
+	Data            RiskLimit `json:"data"`
+}
+
+type GetRiskHitsRequest struct {
+	MerchantID string `json:"merchantId,omitempty" query:"merchantId"`
+	Rule       string `json:"rule,omitempty" query:"rule"`
+	StartDate  string `json:"startDate,omitempty" query:"startDate"`
+	Page       int    `json:"page,omitempty" query:"page"`
+	Limit      int    `json:"limit,omitempty" query:"limit"`
+}
+
+// GetRiskHitsResponse response untuk list rule hit
+type GetRiskHitsResponse struct {
+	ResponseCode    string          `json:"responseCode"`
+	ResponseMessage string          `json:"responseMessage"`
+	Data            []RiskRuleHit   `json:"data"`
+	Pagination      *PaginationInfo `json:"pagination,omitempty"`
+}
@@ -0,0 +1,89 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FeeRule aturan MDR per kategori merchant
+type FeeRule struct {
+	gorm.Model
+	Category   string  `json:"category" gorm:"unique;not null"`
+	MDRPercent float64 `json:"mdr_percent"`  // contoh: 0.3 untuk 0.3%
+	FixedFee   float64 `json:"fixed_fee"`    // biaya tetap per transaksi
+	ExemptUpTo float64 `json:"exempt_up_to"` // transaksi <= nilai ini bebas MDR (0 = tidak ada pengecualian)
+}
+
+// SettlementBatch kumpulan transaksi PAID satu merchant yang dibayarkan bersama
+type SettlementBatch struct {
+	gorm.Model
+	BatchNo          string           `json:"batch_no" gorm:"unique;not null"`
+	MerchantID       string           `json:"merchant_id" gorm:"not null;index"`
+	Category         string           `json:"category"`
+	PeriodStart      time.Time        `json:"period_start"`
+	Cutoff           time.Time        `json:"cutoff" gorm:"index"`
+	TransactionCount int              `json:"transaction_count"`
+	GrossAmount      float64          `json:"gross_amount"`
+	FeeAmount        float64          `json:"fee_amount"`
+	NetAmount        float64          `json:"net_amount"`
+	Currency         string           `json:"currency" gorm:"not null;default:'IDR'"`
+	Lines            []SettlementLine `json:"lines,omitempty" gorm:"foreignKey:BatchID"`
+}
+
+// SettlementLine detail fee per transaksi di dalam batch
+type SettlementLine struct {
+	gorm.Model
+	BatchID       uint       `json:"batch_id" gorm:"not null;index"`
+	TransactionID uint       `json:"transaction_id" gorm:"not null;uniqueIndex"`
+	ReferenceNo   string     `json:"reference_no"`
+	TerminalID    string     `json:"terminal_id"`
+	PaidDate      *time.Time `json:"paid_date"`
+	GrossAmount   float64    `json:"gross_amount"`
+	MDRPercent    float64    `json:"mdr_percent"`
+	FeeAmount     float64    `json:"fee_amount"`
+	NetAmount     float64    `json:"net_amount"`
+}
+
+// Request Body untuk PUT /api/v1/fee-rules/:category
+type SaveFeeRuleRequest struct {
+	MDRPercent float64 `json:"mdrPercent" validate:"gte=0,lte=100"`
+	FixedFee   float64 `json:"fixedFee" validate:"gte=0"`
+	ExemptUpTo float64 `json:"exemptUpTo" validate:"gte=0"`
+}
+
+// Request Body untuk POST /api/v1/settlements/run
+type RunSettlementRequest struct {
+	Cutoff     string `json:"cutoff,omitempty"`     // RFC3339, default jam cut-off hari ini (WIB)
+	MerchantID string `json:"merchantId,omitempty"` // kosong = semua merchant
+}
+
+type GetSettlementsRequest struct {
+	MerchantID string `json:"merchantId,omitempty" query:"merchantId"`
+	StartDate  string `json:"startDate,omitempty" query:"startDate"`
+	EndDate    string `json:"endDate,omitempty" query:"endDate"`
+	Page       int    `json:"page,omitempty" query:"page"`
+	Limit      int    `json:"limit,omitempty" query:"limit"`
+}
+
+// FeeRulesResponse response untuk list aturan MDR
+type FeeRulesResponse struct {
+	ResponseCode    string    `json:"responseCode"`
+	ResponseMessage string    `json:"responseMessage"`
+	Data            []FeeRule `json:"data"`
+}
+
+// SettlementsResponse response untuk list / hasil run settlement
+type SettlementsResponse struct {
+	ResponseCode    string            `json:"responseCode"`
+	ResponseMessage string            `json:"responseMessage"`
+	Data            []SettlementBatch `json:"data"`
+	Pagination      *PaginationInfo   `json:"pagination,omitempty"`
+}
+
+// SettlementResponse response untuk detail satu batch
+type SettlementResponse struct {
+	ResponseCode    string          `json:"responseCode"`
+	ResponseMessage string          `json:"responseMessage"`
+	Data            SettlementBatch `json:"data"`
+}
@@ -0,0 +1,76 @@
+package model
+
+import "time"
+
+// Interval bucket time series statistik
+const (
+	IntervalHour = "hour"
+	IntervalDay  = "day"
+	IntervalWeek = "week"
+)
+
+// GetTransactionStatsRequest query parameter GET /api/v1/transactions/stats
+type GetTransactionStatsRequest struct {
+	MerchantID string `json:"merchantId,omitempty" query:"merchantId"`
+	OutletID   string `json:"outletId,omitempty" query:"outletId"`
+	TerminalID string `json:"terminalId,omitempty" query:"terminalId"`
+	StartDate  string `json:"startDate,omitempty" query:"startDate"` // YYYY-MM-DD atau RFC3339, default hari ini
+	EndDate    string `json:"endDate,omitempty" query:"endDate"`     // YYYY-MM-DD (inklusif) atau RFC3339
+	Timezone   string `json:"timezone,omitempty" query:"timezone"`   // IANA, default Asia/Jakarta
+	Interval   string `json:"interval,omitempty" query:"interval"`   // hour, day, week
+}
+
+// TransactionStatsQuery parameter agregasi untuk TransactionStore.GetStats.
+// Bucket ke-n dimulai pada Origin + n*Interval; Interval 0 berarti tanpa time series.
+type TransactionStatsQuery struct {
+	Filter   TransactionFilter
+	Origin   time.Time
+	Interval time.Duration
+}
+
+// StatusStats jumlah dan nominal transaksi per status
+type StatusStats struct {
+	Status string  `json:"status"`
+	Count  int64   `json:"count"`
+	Amount float64 `json:"amount"`
+}
+
+// StatsBucket satu titik time series
+type StatsBucket struct {
+	Start      time.Time `json:"start"`
+	Count      int64     `json:"count"`
+	Amount     float64   `json:"amount"`
+	PaidCount  int64     `json:"paid_count"`
+	PaidAmount float64   `json:"paid_amount"`
+}
+
+// TransactionAggregates hasil mentah agregasi dari store (bucket kosong tidak ikut)
+type TransactionAggregates struct {
+	ByStatus []StatusStats
+	Series   []StatsBucket
+}
+
+// TransactionStats statistik transaksi untuk dashboard
+type TransactionStats struct {
+	MerchantID    string        `json:"merchant_id,omitempty"`
+	OutletID      string        `json:"outlet_id,omitempty"`
+	TerminalID    string        `json:"terminal_id,omitempty"`
+	From          time.Time     `json:"from"`
+	To            time.Time     `json:"to"`
+	Interval      string        `json:"interval"`
+	TotalCount    int64         `json:"total_count"`
+	TotalAmount   float64       `json:"total_amount"`
+	PaidCount     int64         `json:"paid_count"`
+	PaidAmount    float64       `json:"paid_amount"`
+	SuccessRate   float64       `json:"success_rate"`   // PAID / semua transaksi, 0..1
+	AverageTicket float64       `json:"average_ticket"` // rata-rata nominal transaksi PAID
+	ByStatus      []StatusStats `json:"by_status"`
+	Series        []StatsBucket `json:"series"`
+}
+
+// TransactionStatsResponse response untuk GET /api/v1/transactions/stats
+type TransactionStatsResponse struct {
+	ResponseCode    string           `json:"responseCode"`
+	ResponseMessage string           `json:"responseMessage"`
+	Data            TransactionStats `json:"data"`
+}
@@ -10,6 +10,8 @@ import (
 type Transaction struct {
 	gorm.Model
 	MerchantID         string     `json:"merchant_id" gorm:"not null"`
+	OutletID           string     `json:"outlet_id" gorm:"index"`
+	TerminalID         string     `json:"terminal_id" gorm:"index"`
 	Amount             float64    `json:"amount" gorm:"not null"`
 	TrxID              string     `json:"trx_id" gorm:"unique;not null"`
 	PartnerReferenceNo string     `json:"partner_reference_no" gorm:"unique;not null"`
@@ -18,6 +20,25 @@ type Transaction struct {
 	TransactionDate    time.Time  `json:"transaction_date"`
 	PaidDate           *time.Time `json:"paid_date"`
 	Currency           string     `json:"currency" gorm:"not null;default:'IDR'"`
+	SettlementBatchID  *uint      `json:"settlement_batch_id" gorm:"index"`
+	SettledAt          *time.Time `json:"settled_at"`
+	Provider           string     `json:"provider" gorm:"not null;default:''"` // Acquirer yang membuat QR, kosong = provider default
+	PayerInfo          `gorm:"embedded"`
+}
+
+// PayerInfo data pembayar dari callback issuer. CustomerPAN selalu disimpan dalam bentuk masked.
+type PayerInfo struct {
+	IssuerID     string `json:"issuer_id" gorm:"column:issuer_id"`
+	IssuerName   string `json:"issuer_name" gorm:"column:issuer_name"`
+	CustomerPAN  string `json:"customer_pan" gorm:"column:customer_pan;index"` // contoh: 936008**********1234
+	CustomerName string `json:"customer_name" gorm:"column:customer_name"`
+	RRN          string `json:"rrn" gorm:"column:rrn;index"` // Retrieval Reference Number
+	ApprovalCode string `json:"approval_code" gorm:"column:approval_code"`
+}
+
+// IsZero true jika callback tidak membawa data pembayar sama sekali
+func (p PayerInfo) IsZero() bool {
+	return p == PayerInfo{}
 }
 
 // Struct untuk Amount dengan value dan currency
@@ -31,6 +52,9 @@ type GenerateQRRequest struct {
 	PartnerReferenceNo string `json:"partnerReferenceNo" validate:"required"`
 	Amount             Amount `json:"amount" validate:"required"`
 	MerchantID         string `json:"merchantId" validate:"required"`
+	OutletID           string `json:"outletId,omitempty"`                               // Opsional, diturunkan dari terminal jika kosong
+	TerminalID         string `json:"terminalId,omitempty" validate:"omitempty,max=25"` // Opsional, di-encode ke QR tag 62 sub-tag 07 (maks. 25)
+	ChannelID          string `json:"-"`                                                // Dari header CHANNEL-ID, menentukan prefix ReferenceNo
 }
 
 // Response Body
@@ -44,34 +68,133 @@ type GenerateQRResponse struct {
 
 // Request Body untuk endpoint callback payment
 type PaymentCallbackRequest struct {
-	OriginalReferenceNo        string `json:"originalReferenceNo" validate:"required"`        // ReferenceNo internal (A0000000577)
-	OriginalPartnerReferenceNo string `json:"originalPartnerReferenceNo" validate:"required"` // PartnerReferenceNo (DIRECT-API-NMS-whhq7gvx58)
-	TransactionStatusDesc      string `json:"transactionStatusDesc" validate:"required"`      // Success, Failed, dll
-	PaidTime                   string `json:"paidTime" validate:"required"`                   // 2025-09-21T09:25:00+07:00
-	Amount                     Amount `json:"amount" validate:"required"`                     // {value: "10000.00", currency: "IDR"}
+	OriginalReferenceNo        string                 `json:"originalReferenceNo" validate:"required"`        // ReferenceNo internal (A0000581937281024001)
+	OriginalPartnerReferenceNo string                 `json:"originalPartnerReferenceNo" validate:"required"` // PartnerReferenceNo (DIRECT-API-NMS-whhq7gvx58)
+	TransactionStatusDesc      string                 `json:"transactionStatusDesc" validate:"required"`      // Success, Failed, dll
+	PaidTime                   string                 `json:"paidTime" validate:"required"`                   // 2025-09-21T09:25:00+07:00
+	Amount                     Amount                 `json:"amount" validate:"required"`                     // {value: "10000.00", currency: "IDR"}
+	LatestTransactionStatus    string                 `json:"latestTransactionStatus,omitempty"`              // Opsional, kode SNAP 00-07; didahulukan dari transactionStatusDesc
+	AdditionalInfo             *PaymentAdditionalInfo `json:"additionalInfo,omitempty"`                       // Opsional, data pembayar dari issuer
+}
+
+// StatusInquiryResponse response endpoint POST /api/v1/transactions/{referenceNo}/inquiry
+type StatusInquiryResponse struct {
+	ResponseCode          string `json:"responseCode"`
+	ResponseMessage       string `json:"responseMessage"`
+	ReferenceNo           string `json:"referenceNo"`
+	Provider              string `json:"provider"`
+	TransactionStatusDesc string `json:"transactionStatusDesc"` // status dari provider
+	Status                string `json:"status"`                // status internal setelah inquiry
+}
+
+// GatewayStatusMapping tabel status eksternal -> internal yang berlaku untuk satu gateway
+type GatewayStatusMapping struct {
+	Gateway string            `json:"gateway"`
+	Mapping map[string]string `json:"mapping"`
+}
+
+// StatusMappingsResponse response endpoint GET /api/v1/status-mappings
+type StatusMappingsResponse struct {
+	ResponseCode    string                 `json:"responseCode"`
+	ResponseMessage string                 `json:"responseMessage"`
+	Data            []GatewayStatusMapping `json:"data"`
+}
+
+// PaymentAdditionalInfo data pembayar yang dikirim issuer di callback QRIS
+type PaymentAdditionalInfo struct {
+	IssuerID     string `json:"issuerId,omitempty" validate:"omitempty,max=32"`    // contoh: 93600014 (NNS issuer)
+	IssuerName   string `json:"issuerName,omitempty" validate:"omitempty,max=100"` // contoh: BCA, GoPay
+	CustomerPAN  string `json:"customerPan,omitempty" validate:"omitempty,max=32"` // PAN pembayar, akan di-mask jika belum
+	CustomerName string `json:"customerName,omitempty" validate:"omitempty,max=100"`
+	RRN          string `json:"rrn,omitempty" validate:"omitempty,max=32"`
+	ApprovalCode string `json:"approvalCode,omitempty" validate:"omitempty,max=16"`
 }
 
 // Response Body untuk callback payment
 type PaymentCallbackResponse struct {
-	ResponseCode          string `json:"responseCode"`          // 2005100
+	ResponseCode          string `json:"responseCode"`          // 2005200
 	ResponseMessage       string `json:"responseMessage"`       // Successful
 	TransactionStatusDesc string `json:"transactionStatusDesc"` // Success
 }
 
 type GetTransactionsRequest struct {
 	ReferenceNumber string `json:"referenceNumber,omitempty" query:"referenceNumber"`
-	CustomerID      string `json:"customerId,omitempty" query:"customerId"`
-	Status          string `json:"status,omitempty" query:"status"`
-	StartDate       string `json:"startDate,omitempty" query:"startDate"`
-	EndDate         string `json:"endDate,omitempty" query:"endDate"`
+	ReferenceMatch  string `json:"referenceMatch,omitempty" query:"referenceMatch"` // exact (default) | prefix
+	CustomerID      string `json:"customerId,omitempty" query:"customerId"`         // PAN pembayar (masked), cocok persis
+	RRN             string `json:"rrn,omitempty" query:"rrn"`
+	IssuerID        string `json:"issuerId,omitempty" query:"issuerId"`
+	MerchantID      string `json:"merchantId,omitempty" query:"merchantId"`
+	OutletID        string `json:"outletId,omitempty" query:"outletId"`
+	TerminalID      string `json:"terminalId,omitempty" query:"terminalId"`
+	Status          string `json:"status,omitempty" query:"status"` // bisa lebih dari satu, dipisah koma: PAID,PENDING
+	MinAmount       string `json:"minAmount,omitempty" query:"minAmount"`
+	MaxAmount       string `json:"maxAmount,omitempty" query:"maxAmount"`
+	Currency        string `json:"currency,omitempty" query:"currency"`
+	StartDate       string `json:"startDate,omitempty" query:"startDate"`         // created_at, YYYY-MM-DD atau RFC3339
+	EndDate         string `json:"endDate,omitempty" query:"endDate"`             // created_at, YYYY-MM-DD (inklusif) atau RFC3339
+	PaidStartDate   string `json:"paidStartDate,omitempty" query:"paidStartDate"` // paid_date, YYYY-MM-DD atau RFC3339
+	PaidEndDate     string `json:"paidEndDate,omitempty" query:"paidEndDate"`     // paid_date, YYYY-MM-DD (inklusif) atau RFC3339
+	Timezone        string `json:"timezone,omitempty" query:"timezone"`           // zona waktu tanggal YYYY-MM-DD, default Asia/Jakarta
 	Search          string `json:"search,omitempty" query:"search"`
+	Sort            string `json:"sort,omitempty" query:"sort"`   // created_at (default) | amount | paid_date | status
+	Order           string `json:"order,omitempty" query:"order"` // asc | desc (default)
 	Page            int    `json:"page,omitempty" query:"page"`
 	Limit           int    `json:"limit,omitempty" query:"limit"`
+	After           string `json:"after,omitempty" query:"after"`               // cursor: halaman setelah transaksi ini
+	Before          string `json:"before,omitempty" query:"before"`             // cursor: halaman sebelum transaksi ini
+	IncludeTotal    *bool  `json:"includeTotal,omitempty" query:"includeTotal"` // default true untuk page/limit, false untuk cursor
+}
+
+// Kolom yang boleh dipakai untuk sort transaksi
+const (
+	SortCreatedAt = "created_at"
+	SortAmount    = "amount"
+	SortPaidDate  = "paid_date"
+	SortStatus    = "status"
+)
+
+// TransactionCursor posisi keyset (created_at, id) untuk cursor pagination
+type TransactionCursor struct {
+	CreatedAt time.Time
+	ID        uint
+}
+
+// TransactionFilter filter yang sudah divalidasi untuk query transaksi di repository.
+// Rentang waktu berbentuk [From, To); nilai kosong berarti tidak difilter.
+type TransactionFilter struct {
+	ReferenceNo     string
+	ReferencePrefix bool // true = reference_no diawali ReferenceNo, false = sama persis
+	CustomerPAN     string
+	RRN             string
+	IssuerID        string
+	MerchantID      string
+	OutletID        string
+	TerminalID      string
+	Statuses        []string
+	MinAmount       *float64
+	MaxAmount       *float64
+	Currency        string
+	CreatedFrom     time.Time
+	CreatedTo       time.Time
+	PaidFrom        time.Time
+	PaidTo          time.Time
+	Search          string
+	SortBy          string // salah satu konstanta Sort*, default SortCreatedAt
+	SortAsc         bool
+	Offset          int
+	Limit           int
+	// After/Before mengaktifkan keyset pagination (hanya untuk SortCreatedAt).
+	// Hasil Before tetap dikembalikan dalam urutan sort yang sama.
+	After     *TransactionCursor
+	Before    *TransactionCursor
+	SkipTotal bool // tidak menjalankan COUNT(*), total dikembalikan 0
 }
 
 // TransactionResponse representasi response transaksi
 type TransactionResponse struct {
 	MerchantID         string     `json:"merchant_id" gorm:"not null"`
+	OutletID           string     `json:"outlet_id"`
+	TerminalID         string     `json:"terminal_id"`
 	Amount             float64    `json:"amount" gorm:"not null"`
 	TrxID              string     `json:"trx_id" gorm:"unique;not null"`
 	PartnerReferenceNo string     `json:"partner_reference_no" gorm:"unique;not null"`
@@ -82,6 +205,7 @@ type TransactionResponse struct {
 	Currency           string     `json:"currency" gorm:"not null;default:'IDR'"`
 	CreatedAt          time.Time  `json:"created_at"`
 	UpdatedAt          time.Time  `json:"updated_at"`
+	PayerInfo
 }
 
 // GetTransactionsResponse response untuk get all transactions
@@ -90,6 +214,15 @@ type GetTransactionsResponse struct {
 	ResponseMessage string                `json:"responseMessage"`
 	Data            []TransactionResponse `json:"data,omitempty"`
 	Pagination      *PaginationInfo       `json:"pagination,omitempty"`
+	Cursor          *CursorInfo           `json:"cursor,omitempty"`
+}
+
+// CursorInfo token opaque untuk mengambil halaman berikutnya/sebelumnya
+type CursorInfo struct {
+	Next    string `json:"next,omitempty"` // kirim sebagai ?after=
+	Prev    string `json:"prev,omitempty"` // kirim sebagai ?before=
+	HasNext bool   `json:"hasNext"`
+	HasPrev bool   `json:"hasPrev"`
 }
 
 // PaginationInfo informasi pagination
@@ -99,3 +232,9 @@ type PaginationInfo struct {
 	Total     int `json:"total"`
 	TotalPage int `json:"totalPage"`
 }
+
+// ErrorResponse bentuk JSON semua response error, dibuat oleh handler.ErrorHandler
+type ErrorResponse struct {
+	ResponseCode    string `json:"responseCode"`
+	ResponseMessage string `json:"responseMessage"`
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"qr-service/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type LedgerRepository struct {
+	DB *gorm.DB
+}
+
+func NewLedgerRepository(db *gorm.DB) *LedgerRepository {
+	return &LedgerRepository{DB: db}
+}
+
+// Transaction menjalankan fn dalam satu database transaction; repository yang diterima fn
+// memakai transaction tersebut, begitu juga TransactionStore.WithTx(tx.DB)
+func (r *LedgerRepository) Transaction(ctx context.Context, fn func(tx *LedgerRepository) error) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&LedgerRepository{DB: tx})
+	})
+}
+
+// CreateEntry menyimpan journal entry beserta postingnya secara atomik.
+// Akun yang belum ada dibuat otomatis.
+func (r *LedgerRepository) CreateEntry(entry model.JournalEntry, accounts []model.LedgerAccount) (model.JournalEntry, error) {
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		for _, account := range accounts {
+			if err := tx.Where(model.LedgerAccount{Code: account.Code}).FirstOrCreate(&account).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(&entry).Error
+	})
+	if err != nil {
+		return model.JournalEntry{}, err
+	}
+	return entry, nil
+}
+
+// FindEntryByReference mengembalikan nil jika entry belum pernah diposting
+func (r *LedgerRepository) FindEntryByReference(reference string) (*model.JournalEntry, error) {
+	var entry model.JournalEntry
+	err := r.DB.Preload("Postings").Where("reference = ?", reference).First(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *LedgerRepository) GetEntries(merchantID string, entryType string, page int, limit int) ([]model.JournalEntry, int64, error) {
+	var entries []model.JournalEntry
+	var total int64
+
+	query := r.DB.Model(&model.JournalEntry{})
+	if merchantID != "" {
+		query = query.Where("merchant_id = ?", merchantID)
+	}
+	if entryType != "" {
+		query = query.Where("entry_type = ?", entryType)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Preload("Postings").Offset(offset).Limit(limit).Order("id DESC").Find(&entries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+// AccountTotals total debit dan kredit sebuah akun, dikelompokkan per tipe entry
+type AccountTotals struct {
+	EntryType string
+	Debit     float64
+	Credit    float64
+}
+
+func (r *LedgerRepository) GetAccountTotals(accountCode string) ([]AccountTotals, error) {
+	var totals []AccountTotals
+	err := r.DB.Table("postings").
+		Select(`journal_entries.entry_type AS entry_type,
+			COALESCE(SUM(CASE WHEN postings.direction = 'DEBIT' THEN postings.amount ELSE 0 END), 0) AS debit,
+			COALESCE(SUM(CASE WHEN postings.direction = 'CREDIT' THEN postings.amount ELSE 0 END), 0) AS credit`).
+		Joins("JOIN journal_entries ON journal_entries.id = postings.entry_id").
+		Where("postings.account_code = ?", accountCode).
+		Group("journal_entries.entry_type").
+		Scan(&totals).Error
+	return totals, err
+}
+
+// GetTotals total debit dan kredit seluruh ledger
+func (r *LedgerRepository) GetTotals() (float64, float64, error) {
+	var totals struct {
+		Debit  float64
+		Credit float64
+	}
+	err := r.DB.Table("postings").
+		Select(`COALESCE(SUM(CASE WHEN direction = 'DEBIT' THEN amount ELSE 0 END), 0) AS debit,
+			COALESCE(SUM(CASE WHEN direction = 'CREDIT' THEN amount ELSE 0 END), 0) AS credit`).
+		Scan(&totals).Error
+	return totals.Debit, totals.Credit, err
+}
+
+func (r *LedgerRepository) CountEntries() (int64, error) {
+	var count int64
+	err := r.DB.Model(&model.JournalEntry{}).Count(&count).Error
+	return count, err
+}
+
+// GetUnbalancedEntries mengambil ID entry yang total debit dan kreditnya berbeda
+func (r *LedgerRepository) GetUnbalancedEntries() ([]uint, error) {
+	var ids []uint
+	err := r.DB.Table("postings").
+		Select("entry_id").
+		Group("entry_id").
+		Having("ABS(SUM(CASE WHEN direction = 'DEBIT' THEN amount ELSE -amount END)) > 0.005").
+		Order("entry_id ASC").
+		Pluck("entry_id", &ids).Error
+	return ids, err
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"errors"
+	"qr-service/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MerchantRepository struct {
+	DB *gorm.DB
+}
+
+func NewMerchantRepository(db *gorm.DB) *MerchantRepository {
+	return &MerchantRepository{DB: db}
+}
+
+// SaveMerchant membuat atau mengupdate profil merchant
+func (r *MerchantRepository) SaveMerchant(merchant model.Merchant) (model.Merchant, error) {
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "merchant_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "category", "report_email", "updated_at"}),
+	}).Create(&merchant).Error
+	if err != nil {
+		return model.Merchant{}, err
+	}
+
+	saved, err := r.FindMerchant(merchant.MerchantID)
+	if err != nil {
+		return model.Merchant{}, err
+	}
+	if saved == nil {
+		return model.Merchant{}, errors.New("merchant not found")
+	}
+	return *saved, nil
+}
+
+func (r *MerchantRepository) FindMerchant(merchantID string) (*model.Merchant, error) {
+	var merchant model.Merchant
+	err := r.DB.Where("merchant_id = ?", merchantID).First(&merchant).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &merchant, nil
+}
+
+func (r *MerchantRepository) SaveOutlet(outlet model.Outlet) (model.Outlet, error) {
+	if err := r.DB.Create(&outlet).Error; err != nil {
+		return model.Outlet{}, err
+	}
+	return outlet, nil
+}
+
+func (r *MerchantRepository) SaveTerminal(terminal model.Terminal) (model.Terminal, error) {
+	if err := r.DB.Create(&terminal).Error; err != nil {
+		return model.Terminal{}, err
+	}
+	return terminal, nil
+}
+
+func (r *MerchantRepository) FindOutlet(outletID string) (*model.Outlet, error) {
+	var outlet model.Outlet
+	err := r.DB.Where("outlet_id = ?", outletID).First(&outlet).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &outlet, nil
+}
+
+func (r *MerchantRepository) FindTerminal(terminalID string) (*model.Terminal, error) {
+	var terminal model.Terminal
+	err := r.DB.Where("terminal_id = ?", terminalID).First(&terminal).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &terminal, nil
+}
+
+// GetOutlets mengambil semua outlet milik merchant beserta terminalnya
+func (r *MerchantRepository) GetOutlets(merchantID string) ([]model.Outlet, error) {
+	var outlets []model.Outlet
+	err := r.DB.Preload("Terminals").
+		Where("merchant_id = ?", merchantID).
+		Order("outlet_id ASC").
+		Find(&outlets).Error
+	if err != nil {
+		return nil, err
+	}
+	return outlets, nil
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"qr-service/internal/model"
+	"qr-service/pkg/ratelimit"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RateLimitRepository store rate limit di tabel rate_limit_buckets, dipakai jika ada
+// lebih dari satu instance (RATE_LIMIT_STORE=postgres). Implementasi ratelimit.Store.
+type RateLimitRepository struct {
+	DB *gorm.DB
+}
+
+func NewRateLimitRepository(db *gorm.DB) *RateLimitRepository {
+	return &RateLimitRepository{DB: db}
+}
+
+// takeSQL refill dan ambil satu token dalam satu statement upsert, jadi request paralel dari
+// instance berbeda tidak bisa memakai token yang sama. Semua ekspresi SET memakai nilai baris
+// lama; refilled menghitung token setelah refill (dibatasi burst, jam mundur diabaikan).
+const takeSQL = `
+INSERT INTO rate_limit_buckets (bucket_key, tokens, allowed, refilled_at)
+VALUES (@key, CAST(@burst AS DOUBLE PRECISION) - 1, TRUE, CAST(@now AS BIGINT))
+ON CONFLICT (bucket_key) DO UPDATE SET
+	tokens = CASE WHEN ` + refilledSQL + ` >= 1 THEN ` + refilledSQL + ` - 1 ELSE ` + refilledSQL + ` END,
+	allowed = ` + refilledSQL + ` >= 1,
+	refilled_at = CAST(@now AS BIGINT)
+RETURNING tokens, allowed`
+
+const refilledSQL = `(CASE
+		WHEN CAST(@now AS BIGINT) <= rate_limit_buckets.refilled_at THEN rate_limit_buckets.tokens
+		WHEN rate_limit_buckets.tokens + (CAST(@now AS BIGINT) - rate_limit_buckets.refilled_at) * CAST(@rate AS DOUBLE PRECISION) > CAST(@burst AS DOUBLE PRECISION) THEN CAST(@burst AS DOUBLE PRECISION)
+		ELSE rate_limit_buckets.tokens + (CAST(@now AS BIGINT) - rate_limit_buckets.refilled_at) * CAST(@rate AS DOUBLE PRECISION)
+	END)`
+
+func (r *RateLimitRepository) Take(ctx context.Context, key string, rule ratelimit.Rule, now time.Time) (ratelimit.Result, error) {
+	var bucket model.RateLimitBucket
+	err := r.DB.WithContext(ctx).Raw(takeSQL, map[string]interface{}{
+		"key":   key,
+		"burst": float64(rule.Burst),
+		"now":   now.UnixMicro(),
+		"rate":  rule.Rate / float64(time.Second/time.Microsecond), // token per mikrodetik
+	}).Scan(&bucket).Error
+	if err != nil {
+		return ratelimit.Result{}, err
+	}
+	return ratelimit.NewResult(rule, bucket.Tokens, bucket.Allowed), nil
+}
+
+func (r *RateLimitRepository) DeleteIdle(ctx context.Context, before time.Time) error {
+	return r.DB.WithContext(ctx).Where("refilled_at < ?", before.UnixMicro()).Delete(&model.RateLimitBucket{}).Error
+}
@@ -0,0 +1,33 @@
+package repository_test
+
+import (
+	"path/filepath"
+	"qr-service/internal/model"
+	"qr-service/internal/repository"
+	"qr-service/pkg/ratelimit"
+	"qr-service/pkg/ratelimit/ratelimittest"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Statement upsert yang sama dipakai di Postgres; SQLite dipakai agar test tidak butuh Postgres
+func TestRateLimitRepository(t *testing.T) {
+	ratelimittest.RunStoreTests(t, func(t *testing.T) ratelimit.Store {
+		db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "ratelimit.db")), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		if err != nil {
+			t.Fatalf("failed to open sqlite: %v", err)
+		}
+		if err := db.AutoMigrate(&model.RateLimitBucket{}); err != nil {
+			t.Fatalf("failed to create schema: %v", err)
+		}
+		t.Cleanup(func() {
+			if sqlDB, err := db.DB(); err == nil {
+				sqlDB.Close()
+			}
+		})
+		return repository.NewRateLimitRepository(db)
+	})
+}
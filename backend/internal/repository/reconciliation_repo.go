@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"errors"
+	"qr-service/internal/model"
+	"qr-service/pkg/apperror"
+
+	"gorm.io/gorm"
+)
+
+type ReconciliationRepository struct {
+	DB *gorm.DB
+}
+
+func NewReconciliationRepository(db *gorm.DB) *ReconciliationRepository {
+	return &ReconciliationRepository{DB: db}
+}
+
+// SaveRun menyimpan run beserta semua item-nya
+func (r *ReconciliationRepository) SaveRun(run model.ReconciliationRun) (model.ReconciliationRun, error) {
+	if err := r.DB.CreateInBatches(&run, 500).Error; err != nil {
+		return model.ReconciliationRun{}, err
+	}
+	return run, nil
+}
+
+func (r *ReconciliationRepository) FindRun(id uint) (model.ReconciliationRun, error) {
+	var run model.ReconciliationRun
+	err := r.DB.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("category ASC, line ASC")
+	}).First(&run, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ReconciliationRun{}, apperror.New(apperror.NotFound, "reconciliation not found")
+		}
+		return model.ReconciliationRun{}, err
+	}
+	return run, nil
+}
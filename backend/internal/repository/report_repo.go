@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"errors"
+	"qr-service/internal/model"
+	"qr-service/pkg/apperror"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReportRepository struct {
+	DB *gorm.DB
+}
+
+// Schema dibuat lewat migration di database/migrations (qr-service migrate up)
+func NewReportRepository(db *gorm.DB) *ReportRepository {
+	return &ReportRepository{DB: db}
+}
+
+// SaveDailyReport menyimpan laporan; laporan merchant dan tanggal yang sama ditimpa (generate ulang)
+func (r *ReportRepository) SaveDailyReport(report model.DailyReport) (model.DailyReport, error) {
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "merchant_id"}, {Name: "report_date"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"updated_at", "total_count", "total_amount", "paid_count", "paid_amount",
+			"failed_count", "failed_amount", "expired_count", "expired_amount",
+			"pending_count", "pending_amount", "success_rate", "top_hours", "html", "csv",
+		}),
+	}).Create(&report).Error
+	if err != nil {
+		return model.DailyReport{}, err
+	}
+	return r.FindDailyReport(report.MerchantID, report.ReportDate)
+}
+
+func (r *ReportRepository) FindDailyReport(merchantID string, reportDate string) (model.DailyReport, error) {
+	var report model.DailyReport
+	err := r.DB.Where("merchant_id = ? AND report_date = ?", merchantID, reportDate).First(&report).Error
+	return report, err
+}
+
+func (r *ReportRepository) FindDailyReportByID(id uint) (model.DailyReport, error) {
+	var report model.DailyReport
+	err := r.DB.First(&report, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.DailyReport{}, apperror.New(apperror.NotFound, "report not found")
+		}
+		return model.DailyReport{}, err
+	}
+	return report, nil
+}
+
+// GetDailyReports list laporan tanpa isi HTML/CSV, terbaru dulu
+func (r *ReportRepository) GetDailyReports(merchantID string, startDate string, endDate string, page int, limit int) ([]model.DailyReport, int64, error) {
+	var reports []model.DailyReport
+	var total int64
+
+	query := r.DB.Model(&model.DailyReport{})
+	if merchantID != "" {
+		query = query.Where("merchant_id = ?", merchantID)
+	}
+	// report_date berformat YYYY-MM-DD sehingga bisa dibandingkan sebagai teks
+	if startDate != "" {
+		query = query.Where("report_date >= ?", startDate)
+	}
+	if endDate != "" {
+		query = query.Where("report_date <= ?", endDate)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Omit("html", "csv").Offset(offset).Limit(limit).Order("report_date DESC, merchant_id ASC").Find(&reports).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return reports, total, nil
+}
+
+// MarkEmailed mencatat hasil pengiriman email; emailErr kosong berarti berhasil
+func (r *ReportRepository) MarkEmailed(id uint, to string, at time.Time, emailErr string) error {
+	updates := map[string]interface{}{
+		"emailed_to":  to,
+		"email_error": emailErr,
+	}
+	if emailErr == "" {
+		updates["emailed_at"] = at
+	}
+	return r.DB.Model(&model.DailyReport{}).Where("id = ?", id).Updates(updates).Error
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"qr-service/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RiskRepository struct {
+	DB *gorm.DB
+}
+
+func NewRiskRepository(db *gorm.DB) *RiskRepository {
+	return &RiskRepository{DB: db}
+}
+
+// FindLimit mengambil limit merchant, fallback ke limit default ("*").
+// Mengembalikan nil jika keduanya tidak ada.
+func (r *RiskRepository) FindLimit(merchantID string) (*model.RiskLimit, error) {
+	var limits []model.RiskLimit
+	err := r.DB.Where("merchant_id IN ?", []string{merchantID, model.DefaultRiskMerchantID}).Find(&limits).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var fallback *model.RiskLimit
+	for i := range limits {
+		if limits[i].MerchantID == merchantID {
+			return &limits[i], nil
+		}
+		fallback = &limits[i]
+	}
+	return fallback, nil
+}
+
+// SaveLimit membuat atau mengganti limit milik merchant
+func (r *RiskRepository) SaveLimit(limit model.RiskLimit) (model.RiskLimit, error) {
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "merchant_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"min_amount", "max_amount", "daily_volume_cap", "monthly_volume_cap",
+			"max_generates_per_minute", "max_terminal_generates_per_minute",
+			"monitor_only", "updated_at",
+		}),
+	}).Create(&limit).Error
+	if err != nil {
+		return model.RiskLimit{}, err
+	}
+
+	var saved model.RiskLimit
+	if err := r.DB.Where("merchant_id = ?", limit.MerchantID).First(&saved).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.RiskLimit{}, errors.New("risk limit not found")
+		}
+		return model.RiskLimit{}, err
+	}
+	return saved, nil
+}
+
+// VolumeStatuses status transaksi yang dihitung ke volume harian/bulanan merchant. PENDING ikut
+// dihitung karena QR yang belum dibayar masih bisa dibayar; tanpa itu beberapa QR yang dibuat
+// bersamaan bisa melewati cap setelah semuanya dibayar. FAILED dan EXPIRED tidak dihitung.
+var VolumeStatuses = []string{"PENDING", "PAID"}
+
+// lockMerchantSQL upsert yang mengunci baris merchant sampai database transaction selesai
+// (row lock di Postgres, write lock database di SQLite)
+const lockMerchantSQL = `
+INSERT INTO risk_merchant_locks (merchant_id, locked_at) VALUES (?, ?)
+ON CONFLICT (merchant_id) DO UPDATE SET locked_at = EXCLUDED.locked_at`
+
+// LockMerchant menjalankan fn dalam satu database transaction yang memegang lock merchant.
+// Hitung volume/velocity dan insert transaksi lewat TransactionStore.WithTx(tx.DB) di dalam fn
+// menjadi atomik: request paralel untuk merchant yang sama menunggu sampai transaction sebelumnya
+// commit atau rollback.
+func (r *RiskRepository) LockMerchant(ctx context.Context, merchantID string, fn func(tx *RiskRepository) error) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(lockMerchantSQL, merchantID, time.Now()).Error; err != nil {
+			return err
+		}
+		return fn(&RiskRepository{DB: tx})
+	})
+}
+
+func (r *RiskRepository) SaveHit(hit model.RiskRuleHit) error {
+	return r.DB.Create(&hit).Error
+}
+
+func (r *RiskRepository) GetHits(merchantID string, rule string, since time.Time, page int, limit int) ([]model.RiskRuleHit, int64, error) {
+	var hits []model.RiskRuleHit
+	var total int64
+
+	query := r.DB.Model(&model.RiskRuleHit{})
+	if merchantID != "" {
+		query = query.Where("merchant_id = ?", merchantID)
+	}
+	if rule != "" {
+		query = query.Where("rule = ?", rule)
+	}
+	if !since.IsZero() {
+		query = query.Where("created_at >= ?", since)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	if err := query.Offset(offset).Limit(limit).Order("created_at DESC").Find(&hits).Error; err != nil {
+		return nil, 0, err
+	}
+	return hits, total, nil
+}
+
+// SumVolume menjumlahkan amount transaksi merchant berstatus VolumeStatuses sejak waktu tertentu
+func (r *RiskRepository) SumVolume(merchantID string, since time.Time) (float64, error) {
+	var total float64
+	err := r.DB.Model(&model.Transaction{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("merchant_id = ? AND created_at >= ? AND status IN ?", merchantID, since, VolumeStatuses).
+		Scan(&total).Error
+	return total, err
+}
+
+// CountGenerated menghitung jumlah QR yang dibuat sejak waktu tertentu.
+// column harus "merchant_id" atau "terminal_id".
+func (r *RiskRepository) CountGenerated(column string, value string, since time.Time) (int64, error) {
+	if column != "merchant_id" && column != "terminal_id" {
+		return 0, errors.New("invalid count column")
+	}
+	var count int64
+	err := r.DB.Model(&model.Transaction{}).
+		Where(column+" = ? AND created_at >= ?", value, since).
+		Count(&count).Error
+	return count, err
+}
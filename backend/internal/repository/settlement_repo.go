@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"qr-service/internal/model"
+	"qr-service/pkg/apperror"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SettlementRepository struct {
+	DB *gorm.DB
+}
+
+func NewSettlementRepository(db *gorm.DB) *SettlementRepository {
+	return &SettlementRepository{DB: db}
+}
+
+// Transaction menjalankan fn dalam satu database transaction, rollback jika fn mengembalikan error
+func (r *SettlementRepository) Transaction(ctx context.Context, fn func(tx *SettlementRepository) error) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&SettlementRepository{DB: tx})
+	})
+}
+
+// Ledger repository ledger yang memakai koneksi repository ini
+func (r *SettlementRepository) Ledger() *LedgerRepository {
+	return NewLedgerRepository(r.DB)
+}
+
+func (r *SettlementRepository) GetFeeRules() ([]model.FeeRule, error) {
+	var rules []model.FeeRule
+	err := r.DB.Order("category ASC").Find(&rules).Error
+	return rules, err
+}
+
+// SaveFeeRule membuat atau mengganti aturan MDR sebuah kategori
+func (r *SettlementRepository) SaveFeeRule(rule model.FeeRule) (model.FeeRule, error) {
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "category"}},
+		DoUpdates: clause.AssignmentColumns([]string{"mdr_percent", "fixed_fee", "exempt_up_to", "updated_at"}),
+	}).Create(&rule).Error
+	if err != nil {
+		return model.FeeRule{}, err
+	}
+
+	var saved model.FeeRule
+	if err := r.DB.Where("category = ?", rule.Category).First(&saved).Error; err != nil {
+		return model.FeeRule{}, err
+	}
+	return saved, nil
+}
+
+// GetMerchantCategories mengambil kategori untuk daftar merchant (merchant tanpa profil tidak ikut)
+func (r *SettlementRepository) GetMerchantCategories(merchantIDs []string) (map[string]string, error) {
+	var merchants []model.Merchant
+	categories := make(map[string]string, len(merchantIDs))
+	if len(merchantIDs) == 0 {
+		return categories, nil
+	}
+	if err := r.DB.Where("merchant_id IN ?", merchantIDs).Find(&merchants).Error; err != nil {
+		return nil, err
+	}
+	for _, merchant := range merchants {
+		categories[merchant.MerchantID] = merchant.Category
+	}
+	return categories, nil
+}
+
+// FindUnsettled mengambil transaksi PAID yang belum masuk batch dengan paid_date <= cutoff
+func (r *SettlementRepository) FindUnsettled(cutoff time.Time, merchantID string) ([]model.Transaction, error) {
+	var transactions []model.Transaction
+	query := r.DB.Where("status = ? AND settlement_batch_id IS NULL AND paid_date <= ?", "PAID", cutoff)
+	if merchantID != "" {
+		query = query.Where("merchant_id = ?", merchantID)
+	}
+	err := query.Order("merchant_id ASC, paid_date ASC").Find(&transactions).Error
+	return transactions, err
+}
+
+// CreateBatch menyimpan batch beserta line-nya dan menandai transaksi sebagai settled
+// dalam satu database transaction.
+func (r *SettlementRepository) CreateBatch(batch model.SettlementBatch, transactionIDs []uint, settledAt time.Time) (model.SettlementBatch, error) {
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&batch).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&model.Transaction{}).
+			Where("id IN ? AND settlement_batch_id IS NULL", transactionIDs).
+			Updates(map[string]interface{}{
+				"settlement_batch_id": batch.ID,
+				"settled_at":          settledAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		// Transaksi sudah di-settle oleh proses lain, batalkan batch ini
+		if result.RowsAffected != int64(len(transactionIDs)) {
+			return apperror.New(apperror.Conflict, "transactions already settled by another batch")
+		}
+		return nil
+	})
+	if err != nil {
+		return model.SettlementBatch{}, err
+	}
+	return batch, nil
+}
+
+func (r *SettlementRepository) GetBatches(merchantID string, start time.Time, end time.Time, page int, limit int) ([]model.SettlementBatch, int64, error) {
+	var batches []model.SettlementBatch
+	var total int64
+
+	query := r.DB.Model(&model.SettlementBatch{})
+	if merchantID != "" {
+		query = query.Where("merchant_id = ?", merchantID)
+	}
+	if !start.IsZero() {
+		query = query.Where("cutoff >= ?", start)
+	}
+	if !end.IsZero() {
+		query = query.Where("cutoff <= ?", end)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("paid_date ASC")
+	}).Offset(offset).Limit(limit).Order("cutoff DESC, merchant_id ASC").Find(&batches).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return batches, total, nil
+}
+
+func (r *SettlementRepository) FindBatch(id uint) (model.SettlementBatch, error) {
+	var batch model.SettlementBatch
+	err := r.DB.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("paid_date ASC")
+	}).First(&batch, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.SettlementBatch{}, apperror.New(apperror.NotFound, "settlement not found")
+		}
+		return model.SettlementBatch{}, err
+	}
+	return batch, nil
+}
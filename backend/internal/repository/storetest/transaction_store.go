@@ -0,0 +1,639 @@
+// Package storetest berisi suite conformance yang wajib dilewati setiap
+// implementasi repository.TransactionStore.
+package storetest
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"qr-service/internal/model"
+	"qr-service/internal/repository"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// NewStoreFunc membuat store kosong untuk satu subtest beserta database tempat
+// WithTx-nya dipakai; nil untuk store tanpa database (MemoryTransactionStore)
+type NewStoreFunc func(t *testing.T) (repository.TransactionStore, *gorm.DB)
+
+// RunTransactionStoreTests menjalankan seluruh suite terhadap implementasi store
+func RunTransactionStoreTests(t *testing.T, newStore NewStoreFunc) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, store repository.TransactionStore)
+	}{
+		{"SaveAssignsIDAndTimestamps", testSaveAssignsIDAndTimestamps},
+		{"SaveRejectsDuplicates", testSaveRejectsDuplicates},
+		{"FindByReferenceNo", testFindByReferenceNo},
+		{"FindByPartnerReference", testFindByPartnerReference},
+		{"FindByReferenceNos", testFindByReferenceNos},
+		{"UpdateStatus", testUpdateStatus},
+		{"UpdatePayer", testUpdatePayer},
+		{"FindPaidBetween", testFindPaidBetween},
+		{"MerchantIDsBetween", testMerchantIDsBetween},
+		{"GetTransactionsFilters", testGetTransactionsFilters},
+		{"GetTransactionsSorting", testGetTransactionsSorting},
+		{"GetTransactionsKeyset", testGetTransactionsKeyset},
+		{"GetTransactionsPagination", testGetTransactionsPagination},
+		{"GetStats", testGetStats},
+		{"StreamTransactions", testStreamTransactions},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			store, _ := newStore(t)
+			tc.run(t, store)
+		})
+	}
+	t.Run("WithTx", func(t *testing.T) {
+		store, db := newStore(t)
+		testWithTx(t, store, db)
+	})
+}
+
+// newTransaction membuat transaksi PENDING dengan reference unik berdasarkan suffix
+func newTransaction(suffix string) model.Transaction {
+	return model.Transaction{
+		MerchantID:         "M001",
+		Amount:             10000,
+		TrxID:              "TRX-P" + suffix,
+		PartnerReferenceNo: "P" + suffix,
+		ReferenceNo:        "A" + suffix,
+		Status:             "PENDING",
+		Currency:           "IDR",
+		TransactionDate:    time.Now(),
+	}
+}
+
+func mustSave(t *testing.T, store repository.TransactionStore, trx model.Transaction) model.Transaction {
+	t.Helper()
+	saved, err := store.Save(trx)
+	if err != nil {
+		t.Fatalf("Save(%s) returned error: %v", trx.ReferenceNo, err)
+	}
+	return saved
+}
+
+func referenceNos(transactions []model.Transaction) []string {
+	refs := make([]string, 0, len(transactions))
+	for _, trx := range transactions {
+		refs = append(refs, trx.ReferenceNo)
+	}
+	return refs
+}
+
+func assertRefs(t *testing.T, got []model.Transaction, want ...string) {
+	t.Helper()
+	refs := referenceNos(got)
+	if fmt.Sprint(refs) != fmt.Sprint(want) {
+		t.Fatalf("got references %v, want %v", refs, want)
+	}
+}
+
+func testSaveAssignsIDAndTimestamps(t *testing.T, store repository.TransactionStore) {
+	first := mustSave(t, store, newTransaction("001"))
+	second := mustSave(t, store, newTransaction("002"))
+
+	if first.ID == 0 || second.ID == 0 || first.ID == second.ID {
+		t.Fatalf("expected distinct non-zero IDs, got %d and %d", first.ID, second.ID)
+	}
+	if first.CreatedAt.IsZero() || first.UpdatedAt.IsZero() {
+		t.Fatalf("expected CreatedAt and UpdatedAt to be set")
+	}
+}
+
+func testSaveRejectsDuplicates(t *testing.T, store repository.TransactionStore) {
+	mustSave(t, store, newTransaction("001"))
+
+	duplicates := map[string]func(trx *model.Transaction){
+		"trx_id":               func(trx *model.Transaction) { trx.TrxID = "TRX-P001" },
+		"partner_reference_no": func(trx *model.Transaction) { trx.PartnerReferenceNo = "P001" },
+		"reference_no":         func(trx *model.Transaction) { trx.ReferenceNo = "A001" },
+	}
+	for column, mutate := range duplicates {
+		trx := newTransaction("002")
+		mutate(&trx)
+		if _, err := store.Save(trx); !errors.Is(err, repository.ErrDuplicateTransaction) {
+			t.Errorf("duplicate %s: got error %v, want ErrDuplicateTransaction", column, err)
+		}
+	}
+
+	// Transaksi yang ditolak tidak boleh tersimpan
+	_, total, err := store.GetTransactions(model.TransactionFilter{Limit: 10})
+	if err != nil {
+		t.Fatalf("GetTransactions returned error: %v", err)
+	}
+	if total != 1 {
+		t.Fatalf("expected 1 stored transaction after rejected duplicates, got %d", total)
+	}
+}
+
+func testFindByReferenceNo(t *testing.T, store repository.TransactionStore) {
+	saved := mustSave(t, store, newTransaction("001"))
+
+	found, err := store.FindByReferenceNo("A001")
+	if err != nil {
+		t.Fatalf("FindByReferenceNo returned error: %v", err)
+	}
+	if found.ID != saved.ID || found.PartnerReferenceNo != "P001" || found.Amount != 10000 {
+		t.Fatalf("unexpected transaction: %+v", found)
+	}
+
+	if _, err := store.FindByReferenceNo("A404"); !errors.Is(err, repository.ErrTransactionNotFound) {
+		t.Fatalf("got error %v, want ErrTransactionNotFound", err)
+	}
+}
+
+func testFindByPartnerReference(t *testing.T, store repository.TransactionStore) {
+	mustSave(t, store, newTransaction("001"))
+
+	found, err := store.FindByPartnerReference("P001")
+	if err != nil || found == nil || found.ReferenceNo != "A001" {
+		t.Fatalf("FindByPartnerReference(P001) = %+v, %v", found, err)
+	}
+
+	missing, err := store.FindByPartnerReference("P404")
+	if err != nil || missing != nil {
+		t.Fatalf("FindByPartnerReference(P404) = %+v, %v; want nil, nil", missing, err)
+	}
+}
+
+func testFindByReferenceNos(t *testing.T, store repository.TransactionStore) {
+	mustSave(t, store, newTransaction("001"))
+	mustSave(t, store, newTransaction("002"))
+	mustSave(t, store, newTransaction("003"))
+
+	found, err := store.FindByReferenceNos([]string{"A001", "A003", "A404"})
+	if err != nil {
+		t.Fatalf("FindByReferenceNos returned error: %v", err)
+	}
+	if len(found) != 2 {
+		t.Fatalf("expected 2 transactions, got %v", referenceNos(found))
+	}
+
+	empty, err := store.FindByReferenceNos(nil)
+	if err != nil || len(empty) != 0 {
+		t.Fatalf("FindByReferenceNos(nil) = %v, %v", empty, err)
+	}
+}
+
+func testUpdateStatus(t *testing.T, store repository.TransactionStore) {
+	mustSave(t, store, newTransaction("001"))
+	paidDate := time.Date(2025, 9, 21, 9, 25, 0, 0, time.UTC)
+
+	if err := store.UpdateStatus("A001", "PAID", paidDate); err != nil {
+		t.Fatalf("UpdateStatus returned error: %v", err)
+	}
+
+	updated, err := store.FindByReferenceNo("A001")
+	if err != nil {
+		t.Fatalf("FindByReferenceNo returned error: %v", err)
+	}
+	if updated.Status != "PAID" || updated.PaidDate == nil || !updated.PaidDate.Equal(paidDate) {
+		t.Fatalf("unexpected status/paid date: %s %v", updated.Status, updated.PaidDate)
+	}
+
+	if err := store.UpdateStatus("A404", "PAID", paidDate); !errors.Is(err, repository.ErrTransactionNotFound) {
+		t.Fatalf("got error %v, want ErrTransactionNotFound", err)
+	}
+}
+
+func testUpdatePayer(t *testing.T, store repository.TransactionStore) {
+	mustSave(t, store, newTransaction("001"))
+
+	payer := model.PayerInfo{IssuerID: "93600014", IssuerName: "BCA", CustomerPAN: "936000*********1234", RRN: "123456789012"}
+	if err := store.UpdatePayer("A001", payer); err != nil {
+		t.Fatalf("UpdatePayer returned error: %v", err)
+	}
+	// Update berikutnya hanya mengisi field yang dikirim, field lain tetap
+	if err := store.UpdatePayer("A001", model.PayerInfo{CustomerName: "BUDI", ApprovalCode: "A1B2C3"}); err != nil {
+		t.Fatalf("UpdatePayer returned error: %v", err)
+	}
+
+	updated, err := store.FindByReferenceNo("A001")
+	if err != nil {
+		t.Fatalf("FindByReferenceNo returned error: %v", err)
+	}
+	payer.CustomerName, payer.ApprovalCode = "BUDI", "A1B2C3"
+	if updated.PayerInfo != payer {
+		t.Fatalf("payer = %+v, want %+v", updated.PayerInfo, payer)
+	}
+
+	if err := store.UpdatePayer("A404", payer); !errors.Is(err, repository.ErrTransactionNotFound) {
+		t.Fatalf("got error %v, want ErrTransactionNotFound", err)
+	}
+}
+
+func testFindPaidBetween(t *testing.T, store repository.TransactionStore) {
+	start := time.Date(2025, 9, 21, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+
+	for i, paid := range []time.Time{
+		start.Add(10 * time.Hour), // dalam periode
+		start.Add(-time.Second),   // sebelum periode
+		start,                     // batas awal termasuk
+		end,                       // batas akhir tidak termasuk
+	} {
+		suffix := fmt.Sprintf("%03d", i+1)
+		mustSave(t, store, newTransaction(suffix))
+		if err := store.UpdateStatus("A"+suffix, "PAID", paid); err != nil {
+			t.Fatalf("UpdateStatus returned error: %v", err)
+		}
+	}
+	// Status selain PAID tidak ikut walaupun paid_date dalam periode
+	mustSave(t, store, newTransaction("005"))
+	if err := store.UpdateStatus("A005", "FAILED", start.Add(time.Hour)); err != nil {
+		t.Fatalf("UpdateStatus returned error: %v", err)
+	}
+
+	paid, err := store.FindPaidBetween(start, end)
+	if err != nil {
+		t.Fatalf("FindPaidBetween returned error: %v", err)
+	}
+	assertRefs(t, paid, "A003", "A001")
+}
+
+func testMerchantIDsBetween(t *testing.T, store repository.TransactionStore) {
+	day := time.Date(2025, 9, 21, 0, 0, 0, 0, time.UTC)
+	for i, merchantID := range []string{"M002", "M001", "M002", "M003"} {
+		trx := newTransaction(fmt.Sprintf("%03d", i+1))
+		trx.MerchantID = merchantID
+		trx.CreatedAt = day.Add(time.Duration(i*8) * time.Hour) // M003 jatuh di hari berikutnya
+		mustSave(t, store, trx)
+	}
+
+	merchantIDs, err := store.MerchantIDsBetween(day, day.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("MerchantIDsBetween returned error: %v", err)
+	}
+	if fmt.Sprint(merchantIDs) != "[M001 M002]" {
+		t.Fatalf("merchant IDs = %v, want [M001 M002]", merchantIDs)
+	}
+}
+
+func testGetTransactionsFilters(t *testing.T, store repository.TransactionStore) {
+	paidAt := time.Date(2025, 9, 21, 2, 0, 0, 0, time.UTC)
+
+	a := newTransaction("001")
+	a.OutletID, a.TerminalID, a.Amount = "OUT1", "T1", 5000
+	b := newTransaction("002")
+	b.OutletID, b.TerminalID, b.Amount = "OUT1", "T2", 25000
+	c := newTransaction("003")
+	c.MerchantID, c.Amount = "M002", 100000
+	d := newTransaction("010")
+	d.Currency = "USD"
+	for _, trx := range []model.Transaction{a, b, c, d} {
+		mustSave(t, store, trx)
+	}
+	if err := store.UpdateStatus("A002", "PAID", paidAt); err != nil {
+		t.Fatalf("UpdateStatus returned error: %v", err)
+	}
+	if err := store.UpdateStatus("A003", "FAILED", paidAt.Add(48*time.Hour)); err != nil {
+		t.Fatalf("UpdateStatus returned error: %v", err)
+	}
+	if err := store.UpdatePayer("A002", model.PayerInfo{
+		IssuerID: "93600014", IssuerName: "BCA", CustomerPAN: "936000*********1234", CustomerName: "BUDI", RRN: "123456789012",
+	}); err != nil {
+		t.Fatalf("UpdatePayer returned error: %v", err)
+	}
+
+	amount := func(v float64) *float64 { return &v }
+	now := time.Now()
+
+	cases := []struct {
+		name   string
+		filter model.TransactionFilter
+		want   []string
+	}{
+		{"no filter", model.TransactionFilter{}, []string{"A010", "A003", "A002", "A001"}},
+		{"exact reference", model.TransactionFilter{ReferenceNo: "A00"}, []string{}},
+		{"exact reference match", model.TransactionFilter{ReferenceNo: "A002"}, []string{"A002"}},
+		{"prefix reference", model.TransactionFilter{ReferenceNo: "A00", ReferencePrefix: true}, []string{"A003", "A002", "A001"}},
+		{"prefix with wildcard is literal", model.TransactionFilter{ReferenceNo: "A_0", ReferencePrefix: true}, []string{}},
+		{"merchant", model.TransactionFilter{MerchantID: "M001"}, []string{"A010", "A002", "A001"}},
+		{"outlet", model.TransactionFilter{OutletID: "OUT1"}, []string{"A002", "A001"}},
+		{"terminal", model.TransactionFilter{TerminalID: "T2"}, []string{"A002"}},
+		{"single status", model.TransactionFilter{Statuses: []string{"PAID"}}, []string{"A002"}},
+		{"multiple statuses", model.TransactionFilter{Statuses: []string{"PAID", "FAILED"}}, []string{"A003", "A002"}},
+		{"min amount", model.TransactionFilter{MinAmount: amount(25000)}, []string{"A003", "A002"}},
+		{"max amount", model.TransactionFilter{MaxAmount: amount(10000)}, []string{"A010", "A001"}},
+		{"amount range", model.TransactionFilter{MinAmount: amount(6000), MaxAmount: amount(50000)}, []string{"A010", "A002"}},
+		{"currency", model.TransactionFilter{Currency: "USD"}, []string{"A010"}},
+		{"paid range", model.TransactionFilter{PaidFrom: paidAt, PaidTo: paidAt.Add(time.Hour)}, []string{"A002"}},
+		{"paid from excludes unpaid", model.TransactionFilter{PaidFrom: paidAt.Add(-time.Hour)}, []string{"A003", "A002"}},
+		{"paid to is exclusive", model.TransactionFilter{PaidTo: paidAt}, []string{}},
+		{"created from future", model.TransactionFilter{CreatedFrom: now.Add(time.Hour)}, []string{}},
+		{"created to future", model.TransactionFilter{CreatedTo: now.Add(time.Hour)}, []string{"A010", "A003", "A002", "A001"}},
+		{"created to past", model.TransactionFilter{CreatedTo: now.Add(-time.Hour)}, []string{}},
+		{"search partner reference", model.TransactionFilter{Search: "P003"}, []string{"A003"}},
+		{"customer pan", model.TransactionFilter{CustomerPAN: "936000*********1234"}, []string{"A002"}},
+		{"rrn", model.TransactionFilter{RRN: "123456789012"}, []string{"A002"}},
+		{"issuer", model.TransactionFilter{IssuerID: "93600014"}, []string{"A002"}},
+		{"search payer name", model.TransactionFilter{Search: "BUDI"}, []string{"A002"}},
+		{"search rrn", model.TransactionFilter{Search: "3456789"}, []string{"A002"}},
+		{"combined", model.TransactionFilter{MerchantID: "M001", Statuses: []string{"PENDING"}, Currency: "IDR"}, []string{"A001"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.filter.Limit = 10
+			got, total, err := store.GetTransactions(tc.filter)
+			if err != nil {
+				t.Fatalf("GetTransactions returned error: %v", err)
+			}
+			if total != int64(len(tc.want)) {
+				t.Fatalf("total = %d, want %d (got %v)", total, len(tc.want), referenceNos(got))
+			}
+			assertRefs(t, got, tc.want...)
+		})
+	}
+}
+
+func testGetTransactionsSorting(t *testing.T, store repository.TransactionStore) {
+	base := time.Date(2025, 9, 21, 2, 0, 0, 0, time.UTC)
+	amounts := []float64{20000, 5000, 20000, 75000}
+	for i, amount := range amounts {
+		trx := newTransaction(fmt.Sprintf("%03d", i+1))
+		trx.Amount = amount
+		mustSave(t, store, trx)
+	}
+	// A001 dibayar paling akhir, A003 paling awal, A002 & A004 belum dibayar
+	if err := store.UpdateStatus("A001", "PAID", base.Add(2*time.Hour)); err != nil {
+		t.Fatalf("UpdateStatus returned error: %v", err)
+	}
+	if err := store.UpdateStatus("A003", "PAID", base); err != nil {
+		t.Fatalf("UpdateStatus returned error: %v", err)
+	}
+	if err := store.UpdateStatus("A004", "FAILED", base.Add(time.Hour)); err != nil {
+		t.Fatalf("UpdateStatus returned error: %v", err)
+	}
+
+	cases := []struct {
+		name    string
+		sortBy  string
+		sortAsc bool
+		want    []string
+	}{
+		{"default created_at desc", "", false, []string{"A004", "A003", "A002", "A001"}},
+		{"created_at asc", model.SortCreatedAt, true, []string{"A001", "A002", "A003", "A004"}},
+		{"amount asc ties by id", model.SortAmount, true, []string{"A002", "A001", "A003", "A004"}},
+		{"amount desc ties by id", model.SortAmount, false, []string{"A004", "A003", "A001", "A002"}},
+		{"paid_date asc nulls last", model.SortPaidDate, true, []string{"A003", "A004", "A001", "A002"}},
+		{"paid_date desc nulls last", model.SortPaidDate, false, []string{"A001", "A004", "A003", "A002"}},
+		{"status asc", model.SortStatus, true, []string{"A004", "A001", "A003", "A002"}},
+		{"status desc", model.SortStatus, false, []string{"A002", "A003", "A001", "A004"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, _, err := store.GetTransactions(model.TransactionFilter{SortBy: tc.sortBy, SortAsc: tc.sortAsc, Limit: 10})
+			if err != nil {
+				t.Fatalf("GetTransactions returned error: %v", err)
+			}
+			assertRefs(t, got, tc.want...)
+		})
+	}
+}
+
+func testGetTransactionsPagination(t *testing.T, store repository.TransactionStore) {
+	for i := 1; i <= 5; i++ {
+		mustSave(t, store, newTransaction(fmt.Sprintf("%03d", i)))
+	}
+
+	// Terbaru lebih dulu; transaksi dengan created_at sama diurutkan berdasarkan id
+	pages := [][]string{{"A005", "A004"}, {"A003", "A002"}, {"A001"}, {}}
+	for i, want := range pages {
+		got, total, err := store.GetTransactions(model.TransactionFilter{Offset: i * 2, Limit: 2})
+		if err != nil {
+			t.Fatalf("page %d: GetTransactions returned error: %v", i+1, err)
+		}
+		if total != 5 {
+			t.Fatalf("page %d: total = %d, want 5", i+1, total)
+		}
+		assertRefs(t, got, want...)
+	}
+}
+
+func testGetTransactionsKeyset(t *testing.T, store repository.TransactionStore) {
+	saved := map[string]model.Transaction{}
+	for i := 1; i <= 5; i++ {
+		trx := mustSave(t, store, newTransaction(fmt.Sprintf("%03d", i)))
+		saved[trx.ReferenceNo] = trx
+	}
+	// Cursor dibuat dari data yang dibaca ulang, sama seperti yang diterima client
+	cursor := func(ref string) *model.TransactionCursor {
+		trx, err := store.FindByReferenceNo(ref)
+		if err != nil {
+			t.Fatalf("FindByReferenceNo(%s) returned error: %v", ref, err)
+		}
+		return &model.TransactionCursor{CreatedAt: trx.CreatedAt, ID: trx.ID}
+	}
+
+	cases := []struct {
+		name   string
+		filter model.TransactionFilter
+		want   []string
+	}{
+		{"after desc", model.TransactionFilter{After: cursor("A004"), Limit: 2}, []string{"A003", "A002"}},
+		{"after desc last page", model.TransactionFilter{After: cursor("A002"), Limit: 2}, []string{"A001"}},
+		{"before desc", model.TransactionFilter{Before: cursor("A002"), Limit: 2}, []string{"A004", "A003"}},
+		{"before desc first page", model.TransactionFilter{Before: cursor("A004"), Limit: 2}, []string{"A005"}},
+		{"after asc", model.TransactionFilter{After: cursor("A002"), SortAsc: true, Limit: 2}, []string{"A003", "A004"}},
+		{"before asc", model.TransactionFilter{Before: cursor("A004"), SortAsc: true, Limit: 2}, []string{"A002", "A003"}},
+		{"after with filter", model.TransactionFilter{After: cursor("A005"), ReferenceNo: "A00", ReferencePrefix: true, Statuses: []string{"PENDING"}, Limit: 10}, []string{"A004", "A003", "A002", "A001"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, _, err := store.GetTransactions(tc.filter)
+			if err != nil {
+				t.Fatalf("GetTransactions returned error: %v", err)
+			}
+			assertRefs(t, got, tc.want...)
+		})
+	}
+
+	// Transaksi baru yang masuk tidak menggeser halaman berikutnya
+	next := cursor("A004")
+	mustSave(t, store, newTransaction("006"))
+	got, _, err := store.GetTransactions(model.TransactionFilter{After: next, Limit: 2})
+	if err != nil {
+		t.Fatalf("GetTransactions returned error: %v", err)
+	}
+	assertRefs(t, got, "A003", "A002")
+
+	// Total tetap dihitung dari seluruh hasil filter, kecuali SkipTotal
+	_, total, err := store.GetTransactions(model.TransactionFilter{After: next, Limit: 2})
+	if err != nil || total != 6 {
+		t.Fatalf("total = %d, %v; want 6", total, err)
+	}
+	_, total, err = store.GetTransactions(model.TransactionFilter{After: next, Limit: 2, SkipTotal: true})
+	if err != nil || total != 0 {
+		t.Fatalf("total with SkipTotal = %d, %v; want 0", total, err)
+	}
+}
+
+func testGetStats(t *testing.T, store repository.TransactionStore) {
+	wib := time.FixedZone("WIB", 7*60*60)
+	origin := time.Date(2025, 9, 21, 0, 0, 0, 0, wib)
+	at := func(hour, minute int) time.Time {
+		return origin.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute).UTC()
+	}
+
+	a := newTransaction("001")
+	a.CreatedAt = at(9, 15)
+	b := newTransaction("002")
+	b.CreatedAt, b.Amount = at(9, 45), 20000
+	c := newTransaction("003")
+	c.CreatedAt, c.Amount = at(11, 0), 5000
+	other := newTransaction("004")
+	other.CreatedAt, other.MerchantID = at(9, 30), "M002"
+	yesterday := newTransaction("005")
+	yesterday.CreatedAt = at(0, -1)
+	for _, trx := range []model.Transaction{a, b, c, other, yesterday} {
+		mustSave(t, store, trx)
+	}
+	for _, ref := range []string{"A001", "A003"} {
+		if err := store.UpdateStatus(ref, "PAID", at(12, 0)); err != nil {
+			t.Fatalf("UpdateStatus returned error: %v", err)
+		}
+	}
+
+	filter := model.TransactionFilter{MerchantID: "M001", CreatedFrom: origin, CreatedTo: origin.Add(24 * time.Hour)}
+	stats, err := store.GetStats(model.TransactionStatsQuery{Filter: filter, Origin: origin, Interval: time.Hour})
+	if err != nil {
+		t.Fatalf("GetStats returned error: %v", err)
+	}
+
+	wantStatus := []model.StatusStats{{Status: "PAID", Count: 2, Amount: 15000}, {Status: "PENDING", Count: 1, Amount: 20000}}
+	if len(stats.ByStatus) != len(wantStatus) {
+		t.Fatalf("by status = %+v, want %+v", stats.ByStatus, wantStatus)
+	}
+	for i, want := range wantStatus {
+		if stats.ByStatus[i] != want {
+			t.Fatalf("by status = %+v, want %+v", stats.ByStatus, wantStatus)
+		}
+	}
+
+	wantSeries := []model.StatsBucket{
+		{Start: at(9, 0), Count: 2, Amount: 30000, PaidCount: 1, PaidAmount: 10000},
+		{Start: at(11, 0), Count: 1, Amount: 5000, PaidCount: 1, PaidAmount: 5000},
+	}
+	if len(stats.Series) != len(wantSeries) {
+		t.Fatalf("series = %+v, want %+v", stats.Series, wantSeries)
+	}
+	for i, want := range wantSeries {
+		got := stats.Series[i]
+		if !got.Start.Equal(want.Start) || got.Count != want.Count || got.Amount != want.Amount ||
+			got.PaidCount != want.PaidCount || got.PaidAmount != want.PaidAmount {
+			t.Fatalf("series[%d] = %+v, want %+v", i, got, want)
+		}
+	}
+
+	// Tanpa interval hanya agregat per status
+	stats, err = store.GetStats(model.TransactionStatsQuery{Filter: filter})
+	if err != nil {
+		t.Fatalf("GetStats returned error: %v", err)
+	}
+	if len(stats.ByStatus) != 2 || stats.Series != nil {
+		t.Fatalf("unexpected stats without interval: %+v", stats)
+	}
+}
+
+func testStreamTransactions(t *testing.T, store repository.TransactionStore) {
+	for i, amount := range []float64{30000, 10000, 20000} {
+		trx := newTransaction(fmt.Sprintf("%03d", i+1))
+		trx.Amount = amount
+		mustSave(t, store, trx)
+	}
+	other := newTransaction("004")
+	other.MerchantID = "M002"
+	mustSave(t, store, other)
+
+	collect := func(filter model.TransactionFilter) []model.Transaction {
+		t.Helper()
+		var got []model.Transaction
+		if err := store.StreamTransactions(filter, func(trx model.Transaction) error {
+			got = append(got, trx)
+			return nil
+		}); err != nil {
+			t.Fatalf("StreamTransactions returned error: %v", err)
+		}
+		return got
+	}
+
+	// Offset diabaikan, urutan mengikuti sort
+	assertRefs(t, collect(model.TransactionFilter{MerchantID: "M001", SortBy: model.SortAmount, SortAsc: true, Offset: 2}), "A002", "A003", "A001")
+	assertRefs(t, collect(model.TransactionFilter{MerchantID: "M001", Limit: 2}), "A003", "A002")
+
+	// Error dari callback menghentikan iterasi
+	stop := errors.New("stop")
+	calls := 0
+	err := store.StreamTransactions(model.TransactionFilter{}, func(model.Transaction) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Fatalf("got error %v after %d calls, want stop after 1", err, calls)
+	}
+}
+
+// testWithTx perubahan lewat store.WithTx terlihat di dalam transaction dan lewat store setelah
+// commit. Untuk store di atas database, rollback juga membatalkan perubahan tersebut.
+func testWithTx(t *testing.T, store repository.TransactionStore, db *gorm.DB) {
+	owned := db != nil
+	if !owned {
+		// Store tanpa database tetap dipanggil dengan transaction sungguhan
+		var err error
+		db, err = gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "tx.db")), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		if err != nil {
+			t.Fatalf("open sqlite: %v", err)
+		}
+	}
+	paidAt := time.Date(2025, 9, 21, 3, 0, 0, 0, time.UTC)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		scoped := store.WithTx(tx)
+		mustSave(t, scoped, newTransaction("1"))
+		if err := scoped.UpdateStatus("A1", "PAID", paidAt); err != nil {
+			return err
+		}
+		got, err := scoped.FindByReferenceNo("A1")
+		if err != nil {
+			return err
+		}
+		if got.Status != "PAID" {
+			t.Errorf("status inside tx = %s, want PAID", got.Status)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("committed tx returned error: %v", err)
+	}
+	if got, err := store.FindByReferenceNo("A1"); err != nil || got.Status != "PAID" {
+		t.Fatalf("after commit = %+v, %v; want PAID", got, err)
+	}
+	if !owned {
+		return
+	}
+
+	rollback := errors.New("rollback")
+	err = db.Transaction(func(tx *gorm.DB) error {
+		scoped := store.WithTx(tx)
+		mustSave(t, scoped, newTransaction("2"))
+		if err := scoped.UpdateStatus("A1", "FAILED", paidAt); err != nil {
+			return err
+		}
+		return rollback
+	})
+	if !errors.Is(err, rollback) {
+		t.Fatalf("rolled back tx returned %v, want %v", err, rollback)
+	}
+	if _, err := store.FindByReferenceNo("A2"); !errors.Is(err, repository.ErrTransactionNotFound) {
+		t.Fatalf("A2 after rollback: %v, want ErrTransactionNotFound", err)
+	}
+	if got, _ := store.FindByReferenceNo("A1"); got.Status != "PAID" {
+		t.Fatalf("A1 status after rollback = %s, want PAID", got.Status)
+	}
+}
@@ -0,0 +1,422 @@
+package repository
+
+import (
+	"context"
+	"qr-service/internal/model"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var _ TransactionStore = (*MemoryTransactionStore)(nil)
+
+// MemoryTransactionStore menyimpan transaksi di memori dengan aturan yang sama
+// seperti tabel transactions (unique trx_id, partner_reference_no, reference_no).
+// Dipakai untuk test dan menjalankan service tanpa database.
+type MemoryTransactionStore struct {
+	mu           sync.RWMutex
+	nextID       uint
+	transactions []*model.Transaction
+	byReference  map[string]*model.Transaction
+}
+
+func NewMemoryTransactionStore() *MemoryTransactionStore {
+	return &MemoryTransactionStore{byReference: map[string]*model.Transaction{}}
+}
+
+// WithContext tidak mengubah apa pun karena store memori tidak melakukan I/O
+func (s *MemoryTransactionStore) WithContext(ctx context.Context) TransactionStore {
+	return s
+}
+
+// WithTx mengembalikan store yang sama: store memori tidak ikut database transaction,
+// perubahan lewat store ini tetap tersimpan walaupun tx di-rollback
+func (s *MemoryTransactionStore) WithTx(tx *gorm.DB) TransactionStore {
+	return s
+}
+
+func (s *MemoryTransactionStore) Save(transaction model.Transaction) (model.Transaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.transactions {
+		if existing.TrxID == transaction.TrxID ||
+			existing.PartnerReferenceNo == transaction.PartnerReferenceNo ||
+			existing.ReferenceNo == transaction.ReferenceNo {
+			return model.Transaction{}, ErrDuplicateTransaction
+		}
+	}
+
+	// Default kolom sama seperti di tag gorm
+	now := time.Now()
+	s.nextID++
+	transaction.ID = s.nextID
+	if transaction.CreatedAt.IsZero() {
+		transaction.CreatedAt = now
+	}
+	if transaction.UpdatedAt.IsZero() {
+		transaction.UpdatedAt = now
+	}
+	if transaction.Status == "" {
+		transaction.Status = "PENDING"
+	}
+	if transaction.Currency == "" {
+		transaction.Currency = "IDR"
+	}
+
+	stored := cloneTransaction(transaction)
+	s.transactions = append(s.transactions, &stored)
+	s.byReference[stored.ReferenceNo] = &stored
+	return cloneTransaction(stored), nil
+}
+
+func (s *MemoryTransactionStore) FindByReferenceNo(referenceNo string) (model.Transaction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	trx, ok := s.byReference[referenceNo]
+	if !ok {
+		return model.Transaction{}, ErrTransactionNotFound
+	}
+	return cloneTransaction(*trx), nil
+}
+
+func (s *MemoryTransactionStore) FindByPartnerReference(partnerRef string) (*model.Transaction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, trx := range s.transactions {
+		if trx.PartnerReferenceNo == partnerRef {
+			found := cloneTransaction(*trx)
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *MemoryTransactionStore) FindByReferenceNos(referenceNos []string) ([]model.Transaction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	wanted := make(map[string]bool, len(referenceNos))
+	for _, ref := range referenceNos {
+		wanted[ref] = true
+	}
+	return s.filter(func(trx *model.Transaction) bool { return wanted[trx.ReferenceNo] }), nil
+}
+
+func (s *MemoryTransactionStore) FindPaidBetween(start time.Time, end time.Time) ([]model.Transaction, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	transactions := s.filter(func(trx *model.Transaction) bool {
+		return trx.Status == "PAID" && trx.PaidDate != nil &&
+			!trx.PaidDate.Before(start) && trx.PaidDate.Before(end)
+	})
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].PaidDate.Before(*transactions[j].PaidDate)
+	})
+	return transactions, nil
+}
+
+func (s *MemoryTransactionStore) MerchantIDsBetween(start time.Time, end time.Time) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	merchantIDs := []string{}
+	for _, trx := range s.transactions {
+		if !trx.CreatedAt.Before(start) && trx.CreatedAt.Before(end) && !slices.Contains(merchantIDs, trx.MerchantID) {
+			merchantIDs = append(merchantIDs, trx.MerchantID)
+		}
+	}
+	slices.Sort(merchantIDs)
+	return merchantIDs, nil
+}
+
+func (s *MemoryTransactionStore) UpdateStatus(referenceNo string, status string, paidDate time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	trx, ok := s.byReference[referenceNo]
+	if !ok {
+		return ErrTransactionNotFound
+	}
+	trx.Status = status
+	trx.PaidDate = &paidDate
+	trx.UpdatedAt = time.Now()
+	return nil
+}
+
+func (s *MemoryTransactionStore) UpdatePayer(referenceNo string, payer model.PayerInfo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	trx, ok := s.byReference[referenceNo]
+	if !ok {
+		return ErrTransactionNotFound
+	}
+	mergeString(&trx.IssuerID, payer.IssuerID)
+	mergeString(&trx.IssuerName, payer.IssuerName)
+	mergeString(&trx.CustomerPAN, payer.CustomerPAN)
+	mergeString(&trx.CustomerName, payer.CustomerName)
+	mergeString(&trx.RRN, payer.RRN)
+	mergeString(&trx.ApprovalCode, payer.ApprovalCode)
+	trx.UpdatedAt = time.Now()
+	return nil
+}
+
+// mergeString menimpa dst hanya jika value terisi, seperti Updates(struct) di GORM
+func mergeString(dst *string, value string) {
+	if value != "" {
+		*dst = value
+	}
+}
+
+func (s *MemoryTransactionStore) GetTransactions(filter model.TransactionFilter) ([]model.Transaction, int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	transactions := s.filter(func(trx *model.Transaction) bool {
+		return matchesFilter(trx, filter)
+	})
+	total := int64(len(transactions))
+	if filter.SkipTotal {
+		total = 0
+	}
+
+	// Keyset pagination, padanan kondisi (created_at, id) < / > cursor
+	order := filter
+	if cursor := filter.After; cursor != nil {
+		transactions = slices.DeleteFunc(transactions, func(trx model.Transaction) bool {
+			return !afterCursor(trx, *cursor, filter.SortAsc)
+		})
+	}
+	if cursor := filter.Before; cursor != nil {
+		transactions = slices.DeleteFunc(transactions, func(trx model.Transaction) bool {
+			return !afterCursor(trx, *cursor, !filter.SortAsc)
+		})
+		order.SortAsc = !filter.SortAsc
+	}
+
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return lessTransaction(transactions[i], transactions[j], order)
+	})
+
+	if filter.Offset >= len(transactions) {
+		return []model.Transaction{}, total, nil
+	}
+	end := filter.Offset + filter.Limit
+	if end > len(transactions) {
+		end = len(transactions)
+	}
+	page := transactions[filter.Offset:end]
+	if filter.Before != nil {
+		slices.Reverse(page)
+	}
+	return page, total, nil
+}
+
+func (s *MemoryTransactionStore) StreamTransactions(filter model.TransactionFilter, fn func(model.Transaction) error) error {
+	s.mu.RLock()
+	transactions := s.filter(func(trx *model.Transaction) bool {
+		return matchesFilter(trx, filter)
+	})
+	s.mu.RUnlock()
+
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return lessTransaction(transactions[i], transactions[j], filter)
+	})
+	if filter.Limit > 0 && len(transactions) > filter.Limit {
+		transactions = transactions[:filter.Limit]
+	}
+
+	// fn dipanggil di luar lock agar boleh memakai store lagi
+	for _, trx := range transactions {
+		if err := fn(trx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// afterCursor true jika trx berada setelah cursor pada arah sort created_at, id
+func afterCursor(trx model.Transaction, cursor model.TransactionCursor, asc bool) bool {
+	cmp := trx.CreatedAt.Compare(cursor.CreatedAt)
+	if cmp == 0 {
+		cmp = compareFloat(float64(trx.ID), float64(cursor.ID))
+	}
+	if asc {
+		return cmp > 0
+	}
+	return cmp < 0
+}
+
+// matchesFilter padanan klausa WHERE di TransactionRepository.GetTransactions
+func matchesFilter(trx *model.Transaction, filter model.TransactionFilter) bool {
+	switch {
+	case filter.ReferenceNo != "" && filter.ReferencePrefix && !strings.HasPrefix(trx.ReferenceNo, filter.ReferenceNo):
+		return false
+	case filter.ReferenceNo != "" && !filter.ReferencePrefix && trx.ReferenceNo != filter.ReferenceNo:
+		return false
+	case filter.MerchantID != "" && trx.MerchantID != filter.MerchantID:
+		return false
+	case filter.OutletID != "" && trx.OutletID != filter.OutletID:
+		return false
+	case filter.TerminalID != "" && trx.TerminalID != filter.TerminalID:
+		return false
+	case filter.CustomerPAN != "" && trx.CustomerPAN != filter.CustomerPAN:
+		return false
+	case filter.RRN != "" && trx.RRN != filter.RRN:
+		return false
+	case filter.IssuerID != "" && trx.IssuerID != filter.IssuerID:
+		return false
+	case len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, trx.Status):
+		return false
+	case filter.MinAmount != nil && trx.Amount < *filter.MinAmount:
+		return false
+	case filter.MaxAmount != nil && trx.Amount > *filter.MaxAmount:
+		return false
+	case filter.Currency != "" && trx.Currency != filter.Currency:
+		return false
+	case !filter.CreatedFrom.IsZero() && trx.CreatedAt.Before(filter.CreatedFrom):
+		return false
+	case !filter.CreatedTo.IsZero() && !trx.CreatedAt.Before(filter.CreatedTo):
+		return false
+	case !filter.PaidFrom.IsZero() && (trx.PaidDate == nil || trx.PaidDate.Before(filter.PaidFrom)):
+		return false
+	case !filter.PaidTo.IsZero() && (trx.PaidDate == nil || !trx.PaidDate.Before(filter.PaidTo)):
+		return false
+	}
+	if filter.Search != "" {
+		return strings.Contains(trx.ReferenceNo, filter.Search) ||
+			strings.Contains(trx.PartnerReferenceNo, filter.Search) ||
+			strings.Contains(trx.MerchantID, filter.Search) ||
+			strings.Contains(trx.TrxID, filter.Search) ||
+			strings.Contains(trx.RRN, filter.Search) ||
+			strings.Contains(trx.CustomerPAN, filter.Search) ||
+			strings.Contains(trx.CustomerName, filter.Search) ||
+			strings.Contains(trx.IssuerName, filter.Search)
+	}
+	return true
+}
+
+// lessTransaction padanan ORDER BY di transactionOrder: paid_date kosong selalu di akhir,
+// id sebagai tie-break dengan arah yang sama
+func lessTransaction(a, b model.Transaction, filter model.TransactionFilter) bool {
+	cmp := 0
+	switch filter.SortBy {
+	case model.SortAmount:
+		cmp = compareFloat(a.Amount, b.Amount)
+	case model.SortStatus:
+		cmp = strings.Compare(a.Status, b.Status)
+	case model.SortPaidDate:
+		if (a.PaidDate == nil) != (b.PaidDate == nil) {
+			return b.PaidDate == nil
+		}
+		if a.PaidDate != nil {
+			cmp = a.PaidDate.Compare(*b.PaidDate)
+		}
+	default:
+		cmp = a.CreatedAt.Compare(b.CreatedAt)
+	}
+	if cmp == 0 {
+		cmp = compareFloat(float64(a.ID), float64(b.ID))
+	}
+	if filter.SortAsc {
+		return cmp < 0
+	}
+	return cmp > 0
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func (s *MemoryTransactionStore) GetStats(q model.TransactionStatsQuery) (model.TransactionAggregates, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	aggregates := model.TransactionAggregates{ByStatus: []model.StatusStats{}}
+	byStatus := map[string]*model.StatusStats{}
+	buckets := map[int64]*model.StatsBucket{}
+	for _, trx := range s.transactions {
+		if !matchesFilter(trx, q.Filter) {
+			continue
+		}
+		stat, ok := byStatus[trx.Status]
+		if !ok {
+			stat = &model.StatusStats{Status: trx.Status}
+			byStatus[trx.Status] = stat
+		}
+		stat.Count++
+		stat.Amount += trx.Amount
+
+		if q.Interval <= 0 || trx.CreatedAt.Before(q.Origin) {
+			continue
+		}
+		index := (trx.CreatedAt.Unix() - q.Origin.Unix()) / int64(q.Interval/time.Second)
+		bucket, ok := buckets[index]
+		if !ok {
+			bucket = &model.StatsBucket{Start: q.Origin.Add(time.Duration(index) * q.Interval)}
+			buckets[index] = bucket
+		}
+		bucket.Count++
+		bucket.Amount += trx.Amount
+		if trx.Status == "PAID" {
+			bucket.PaidCount++
+			bucket.PaidAmount += trx.Amount
+		}
+	}
+
+	for _, stat := range byStatus {
+		aggregates.ByStatus = append(aggregates.ByStatus, *stat)
+	}
+	slices.SortFunc(aggregates.ByStatus, func(a, b model.StatusStats) int { return strings.Compare(a.Status, b.Status) })
+
+	if q.Interval > 0 {
+		aggregates.Series = make([]model.StatsBucket, 0, len(buckets))
+		for _, bucket := range buckets {
+			aggregates.Series = append(aggregates.Series, *bucket)
+		}
+		slices.SortFunc(aggregates.Series, func(a, b model.StatsBucket) int { return a.Start.Compare(b.Start) })
+	}
+	return aggregates, nil
+}
+
+// filter mengembalikan salinan transaksi yang lolos predicate, urut sesuai insert
+func (s *MemoryTransactionStore) filter(match func(trx *model.Transaction) bool) []model.Transaction {
+	transactions := []model.Transaction{}
+	for _, trx := range s.transactions {
+		if match(trx) {
+			transactions = append(transactions, cloneTransaction(*trx))
+		}
+	}
+	return transactions
+}
+
+// cloneTransaction menyalin field pointer agar data di store tidak ikut berubah dari luar
+func cloneTransaction(trx model.Transaction) model.Transaction {
+	if trx.PaidDate != nil {
+		paidDate := *trx.PaidDate
+		trx.PaidDate = &paidDate
+	}
+	if trx.SettledAt != nil {
+		settledAt := *trx.SettledAt
+		trx.SettledAt = &settledAt
+	}
+	if trx.SettlementBatchID != nil {
+		batchID := *trx.SettlementBatchID
+		trx.SettlementBatchID = &batchID
+	}
+	return trx
+}
@@ -0,0 +1,15 @@
+package repository_test
+
+import (
+	"qr-service/internal/repository"
+	"qr-service/internal/repository/storetest"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestMemoryTransactionStore(t *testing.T) {
+	storetest.RunTransactionStoreTests(t, func(t *testing.T) (repository.TransactionStore, *gorm.DB) {
+		return repository.NewMemoryTransactionStore(), nil
+	})
+}
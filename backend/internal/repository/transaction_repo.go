@@ -1,8 +1,12 @@
 package repository
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"qr-service/internal/model"
+	"slices"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -12,15 +16,28 @@ type TransactionRepository struct {
 	DB *gorm.DB
 }
 
+var _ TransactionStore = (*TransactionRepository)(nil)
+
+// Schema dibuat lewat migration di database/migrations (qr-service migrate up)
 func NewTransactionRepository(db *gorm.DB) *TransactionRepository {
-	// AutoMigrate untuk membuat tabel
-	db.AutoMigrate(&model.Transaction{})
 	return &TransactionRepository{DB: db}
 }
 
+func (r *TransactionRepository) WithContext(ctx context.Context) TransactionStore {
+	return &TransactionRepository{DB: r.DB.WithContext(ctx)}
+}
+
+// WithTx store di atas tx; tx harus dibuka pada database yang sama dengan r.DB
+func (r *TransactionRepository) WithTx(tx *gorm.DB) TransactionStore {
+	return &TransactionRepository{DB: tx}
+}
+
 // Implementasi Penyimpanan Data Transaksi ke Database
 func (r *TransactionRepository) Save(transaction model.Transaction) (model.Transaction, error) {
 	if err := r.DB.Create(&transaction).Error; err != nil {
+		if isDuplicateKey(err) {
+			return model.Transaction{}, fmt.Errorf("%w: %v", ErrDuplicateTransaction, err)
+		}
 		return model.Transaction{}, err
 	}
 	return transaction, nil
@@ -32,7 +49,7 @@ func (r *TransactionRepository) FindByReferenceNo(referenceNo string) (model.Tra
 	err := r.DB.Where("reference_no = ?", referenceNo).First(&transaction).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return model.Transaction{}, errors.New("transaction not found")
+			return model.Transaction{}, ErrTransactionNotFound
 		}
 		return model.Transaction{}, err
 	}
@@ -42,10 +59,59 @@ func (r *TransactionRepository) FindByReferenceNo(referenceNo string) (model.Tra
 // Digunakan untuk Callback Payment
 func (r *TransactionRepository) UpdateStatus(referenceNo string, status string, paidDate time.Time) error {
 	// Pastikan hanya update status dan paid_date
-	return r.DB.Model(&model.Transaction{}).Where("reference_no = ?", referenceNo).Updates(map[string]interface{}{
+	result := r.DB.Model(&model.Transaction{}).Where("reference_no = ?", referenceNo).Updates(map[string]interface{}{
 		"status":    status,
-		"paid_date": paidDate,
-	}).Error
+		"paid_date": paidDate.UTC(),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTransactionNotFound
+	}
+	return nil
+}
+
+// UpdatePayer menyimpan data pembayar dari callback, hanya field yang terisi yang diupdate
+func (r *TransactionRepository) UpdatePayer(referenceNo string, payer model.PayerInfo) error {
+	result := r.DB.Model(&model.Transaction{}).Where("reference_no = ?", referenceNo).Updates(model.Transaction{PayerInfo: payer})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTransactionNotFound
+	}
+	return nil
+}
+
+// FindByReferenceNos mengambil banyak transaksi sekaligus, dipakai saat rekonsiliasi
+func (r *TransactionRepository) FindByReferenceNos(referenceNos []string) ([]model.Transaction, error) {
+	var transactions []model.Transaction
+	if len(referenceNos) == 0 {
+		return transactions, nil
+	}
+	err := r.DB.Where("reference_no IN ?", referenceNos).Find(&transactions).Error
+	return transactions, err
+}
+
+// MerchantIDsBetween dipakai laporan harian untuk menentukan merchant yang perlu direkap
+func (r *TransactionRepository) MerchantIDsBetween(start time.Time, end time.Time) ([]string, error) {
+	merchantIDs := []string{}
+	err := r.DB.Model(&model.Transaction{}).
+		Where("created_at >= ? AND created_at < ?", start.UTC(), end.UTC()).
+		Distinct().
+		Order("merchant_id").
+		Pluck("merchant_id", &merchantIDs).Error
+	return merchantIDs, err
+}
+
+// FindPaidBetween mengambil transaksi PAID dengan paid_date dalam rentang [start, end)
+func (r *TransactionRepository) FindPaidBetween(start time.Time, end time.Time) ([]model.Transaction, error) {
+	var transactions []model.Transaction
+	err := r.DB.Where("status = ? AND paid_date >= ? AND paid_date < ?", "PAID", start.UTC(), end.UTC()).
+		Order("paid_date ASC").
+		Find(&transactions).Error
+	return transactions, err
 }
 
 func (r *TransactionRepository) FindByPartnerReference(partnerRef string) (*model.Transaction, error) {
@@ -60,63 +126,247 @@ func (r *TransactionRepository) FindByPartnerReference(partnerRef string) (*mode
 	return &transaction, nil
 }
 
-func (r *TransactionRepository) GetTransactions(
-	referenceNumber string,
-	customerID string,
-	status string,
-	startDate time.Time,
-	endDate time.Time,
-	search string,
-	page int,
-	limit int,
-) ([]model.Transaction, int64, error) {
+// GetTransactions mencari transaksi sesuai filter, mengembalikan satu halaman dan total seluruh hasil
+func (r *TransactionRepository) GetTransactions(filter model.TransactionFilter) ([]model.Transaction, int64, error) {
 	var transactions []model.Transaction
 	var total int64
 
 	// Build query
 	query := r.DB.Model(&model.Transaction{})
 
-	// Apply filters
-	if referenceNumber != "" {
-		query = query.Where("reference_number LIKE ?", "%"+referenceNumber+"%")
+	query = applyTransactionFilter(query, filter)
+
+	// Count total records (opsional, mahal untuk tabel besar)
+	if !filter.SkipTotal {
+		if err := query.Count(&total).Error; err != nil {
+			return nil, 0, err
+		}
+	}
+
+	// Keyset pagination: lanjut dari posisi (created_at, id) cursor tanpa OFFSET
+	order := transactionOrder(filter)
+	reverse := false
+	if cursor := filter.After; cursor != nil {
+		query = query.Where("(created_at, id) "+keysetOperator(filter.SortAsc)+" (?, ?)", cursor.CreatedAt.UTC(), cursor.ID)
 	}
+	if cursor := filter.Before; cursor != nil {
+		// Ambil dengan urutan terbalik dari cursor, lalu dibalik lagi setelah query
+		query = query.Where("(created_at, id) "+keysetOperator(!filter.SortAsc)+" (?, ?)", cursor.CreatedAt.UTC(), cursor.ID)
+		reversed := filter
+		reversed.SortAsc = !filter.SortAsc
+		order = transactionOrder(reversed)
+		reverse = true
+	}
+
+	// Apply pagination
+	query = query.Offset(filter.Offset).Limit(filter.Limit).Order(order)
 
-	if customerID != "" {
-		query = query.Where("customer_id = ?", customerID)
+	// Execute query
+	if err := query.Find(&transactions).Error; err != nil {
+		return nil, 0, err
+	}
+	if reverse {
+		slices.Reverse(transactions)
 	}
 
-	if status != "" {
-		query = query.Where("status = ?", status)
+	return transactions, total, nil
+}
+
+// StreamTransactions membaca hasil filter baris per baris dari satu query (dipakai export)
+func (r *TransactionRepository) StreamTransactions(filter model.TransactionFilter, fn func(model.Transaction) error) error {
+	query := applyTransactionFilter(r.DB.Model(&model.Transaction{}), filter).Order(transactionOrder(filter))
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
 	}
 
-	if !startDate.IsZero() {
-		query = query.Where("created_at >= ?", startDate)
+	rows, err := query.Rows()
+	if err != nil {
+		return err
 	}
+	defer rows.Close()
 
-	if !endDate.IsZero() {
-		query = query.Where("created_at <= ?", endDate)
+	for rows.Next() {
+		var transaction model.Transaction
+		if err := r.DB.ScanRows(rows, &transaction); err != nil {
+			return err
+		}
+		if err := fn(transaction); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// GetStats menghitung jumlah dan nominal per status serta per bucket waktu langsung di SQL
+func (r *TransactionRepository) GetStats(q model.TransactionStatsQuery) (model.TransactionAggregates, error) {
+	var aggregates model.TransactionAggregates
+
+	byStatus := []model.StatusStats{}
+	err := applyTransactionFilter(r.DB.Model(&model.Transaction{}), q.Filter).
+		Select("status, COUNT(*) AS count, COALESCE(SUM(amount), 0) AS amount").
+		Group("status").
+		Order("status").
+		Scan(&byStatus).Error
+	if err != nil {
+		return aggregates, err
+	}
+	aggregates.ByStatus = byStatus
+
+	if q.Interval <= 0 {
+		return aggregates, nil
+	}
+
+	// Nomor bucket = (epoch created_at - epoch origin) / panjang interval (pembagian integer)
+	var rows []struct {
+		Bucket     int64
+		Count      int64
+		Amount     float64
+		PaidCount  int64
+		PaidAmount float64
+	}
+	bucket := "(" + r.epochExpr("created_at") + " - ?) / ?"
+	err = applyTransactionFilter(r.DB.Model(&model.Transaction{}), q.Filter).
+		Where("created_at >= ?", q.Origin.UTC()).
+		Select(bucket+` AS bucket, COUNT(*) AS count, COALESCE(SUM(amount), 0) AS amount,
+			SUM(CASE WHEN status = 'PAID' THEN 1 ELSE 0 END) AS paid_count,
+			COALESCE(SUM(CASE WHEN status = 'PAID' THEN amount ELSE 0 END), 0) AS paid_amount`,
+			q.Origin.Unix(), int64(q.Interval/time.Second)).
+		Group("bucket").
+		Order("bucket").
+		Scan(&rows).Error
+	if err != nil {
+		return aggregates, err
 	}
 
-	if search != "" {
-		searchPattern := "%" + search + "%"
+	aggregates.Series = make([]model.StatsBucket, 0, len(rows))
+	for _, row := range rows {
+		aggregates.Series = append(aggregates.Series, model.StatsBucket{
+			Start:      q.Origin.Add(time.Duration(row.Bucket) * q.Interval),
+			Count:      row.Count,
+			Amount:     row.Amount,
+			PaidCount:  row.PaidCount,
+			PaidAmount: row.PaidAmount,
+		})
+	}
+	return aggregates, nil
+}
+
+// epochExpr ekspresi detik unix dari kolom timestamp sesuai dialect database
+func (r *TransactionRepository) epochExpr(column string) string {
+	if r.DB.Dialector.Name() == "sqlite" {
+		return "CAST(strftime('%s', " + column + ") AS INTEGER)"
+	}
+	return "CAST(FLOOR(EXTRACT(EPOCH FROM " + column + ")) AS BIGINT)"
+}
+
+// applyTransactionFilter menerapkan klausa WHERE dari filter, dipakai list dan statistik
+func applyTransactionFilter(query *gorm.DB, filter model.TransactionFilter) *gorm.DB {
+	if filter.ReferenceNo != "" {
+		if filter.ReferencePrefix {
+			query = query.Where(`reference_no LIKE ? ESCAPE '\'`, escapeLike(filter.ReferenceNo)+"%")
+		} else {
+			query = query.Where("reference_no = ?", filter.ReferenceNo)
+		}
+	}
+
+	if filter.MerchantID != "" {
+		query = query.Where("merchant_id = ?", filter.MerchantID)
+	}
+
+	if filter.OutletID != "" {
+		query = query.Where("outlet_id = ?", filter.OutletID)
+	}
+
+	if filter.TerminalID != "" {
+		query = query.Where("terminal_id = ?", filter.TerminalID)
+	}
+
+	if filter.CustomerPAN != "" {
+		query = query.Where("customer_pan = ?", filter.CustomerPAN)
+	}
+
+	if filter.RRN != "" {
+		query = query.Where("rrn = ?", filter.RRN)
+	}
+
+	if filter.IssuerID != "" {
+		query = query.Where("issuer_id = ?", filter.IssuerID)
+	}
+
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+
+	if filter.MinAmount != nil {
+		query = query.Where("amount >= ?", *filter.MinAmount)
+	}
+
+	if filter.MaxAmount != nil {
+		query = query.Where("amount <= ?", *filter.MaxAmount)
+	}
+
+	if filter.Currency != "" {
+		query = query.Where("currency = ?", filter.Currency)
+	}
+
+	if !filter.CreatedFrom.IsZero() {
+		query = query.Where("created_at >= ?", filter.CreatedFrom.UTC())
+	}
+
+	if !filter.CreatedTo.IsZero() {
+		query = query.Where("created_at < ?", filter.CreatedTo.UTC())
+	}
+
+	if !filter.PaidFrom.IsZero() {
+		query = query.Where("paid_date >= ?", filter.PaidFrom.UTC())
+	}
+
+	if !filter.PaidTo.IsZero() {
+		query = query.Where("paid_date < ?", filter.PaidTo.UTC())
+	}
+
+	if filter.Search != "" {
+		searchPattern := "%" + filter.Search + "%"
 		query = query.Where(
-			"reference_no LIKE ? OR partner_reference_no LIKE ? OR merchant_id LIKE ? OR trx_id LIKE ?",
+			"reference_no LIKE ? OR partner_reference_no LIKE ? OR merchant_id LIKE ? OR trx_id LIKE ? OR "+
+				"rrn LIKE ? OR customer_pan LIKE ? OR customer_name LIKE ? OR issuer_name LIKE ?",
+			searchPattern, searchPattern, searchPattern, searchPattern,
 			searchPattern, searchPattern, searchPattern, searchPattern,
 		)
 	}
-	// Count total records
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+
+	return query
+}
+
+// transactionOrder membangun ORDER BY dari kolom yang sudah di-whitelist.
+// paid_date kosong selalu di akhir, dan id dipakai sebagai tie-break agar paging stabil.
+func transactionOrder(filter model.TransactionFilter) string {
+	direction := "DESC"
+	if filter.SortAsc {
+		direction = "ASC"
 	}
 
-	// Apply pagination
-	offset := (page - 1) * limit
-	query = query.Offset(offset).Limit(limit).Order("created_at DESC")
+	switch filter.SortBy {
+	case model.SortAmount:
+		return "amount " + direction + ", id " + direction
+	case model.SortStatus:
+		return "status " + direction + ", id " + direction
+	case model.SortPaidDate:
+		return "paid_date IS NULL, paid_date " + direction + ", id " + direction
+	default:
+		return "created_at " + direction + ", id " + direction
+	}
+}
 
-	// Execute query
-	if err := query.Find(&transactions).Error; err != nil {
-		return nil, 0, err
+// keysetOperator perbandingan untuk baris setelah cursor pada arah sort tertentu
+func keysetOperator(asc bool) string {
+	if asc {
+		return ">"
 	}
+	return "<"
+}
 
-	return transactions, total, nil
+// escapeLike meng-escape karakter wildcard LIKE agar prefix dicocokkan apa adanya
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
 }
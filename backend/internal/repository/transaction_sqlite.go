@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"fmt"
+	"qr-service/internal/model"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// NewSQLiteTransactionRepository membuka database SQLite dan membuat tabel transactions.
+// Query-nya sama dengan versi Postgres, sehingga cocok untuk test tanpa Postgres.
+// Migration di database/migrations khusus Postgres, jadi tabel dibuat dari model.
+func NewSQLiteTransactionRepository(dsn string) (*TransactionRepository, error) {
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+		// SQLite membandingkan waktu sebagai teks, jadi semua timestamp disimpan dalam UTC
+		NowFunc: func() time.Time { return time.Now().UTC() },
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite: %w", err)
+	}
+
+	if err := db.AutoMigrate(&model.Transaction{}); err != nil {
+		return nil, fmt.Errorf("failed to create sqlite schema: %w", err)
+	}
+	return NewTransactionRepository(db), nil
+}
@@ -0,0 +1,25 @@
+package repository_test
+
+import (
+	"path/filepath"
+	"qr-service/internal/repository"
+	"qr-service/internal/repository/storetest"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestSQLiteTransactionRepository(t *testing.T) {
+	storetest.RunTransactionStoreTests(t, func(t *testing.T) (repository.TransactionStore, *gorm.DB) {
+		repo, err := repository.NewSQLiteTransactionRepository(filepath.Join(t.TempDir(), "transactions.db"))
+		if err != nil {
+			t.Fatalf("failed to open sqlite store: %v", err)
+		}
+		t.Cleanup(func() {
+			if sqlDB, err := repo.DB.DB(); err == nil {
+				sqlDB.Close()
+			}
+		})
+		return repo, repo.DB
+	})
+}
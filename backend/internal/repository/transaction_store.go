@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"qr-service/internal/model"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrTransactionNotFound dikembalikan jika reference transaksi tidak ada
+	ErrTransactionNotFound = errors.New("transaction not found")
+	// ErrDuplicateTransaction dikembalikan jika trx_id, partner_reference_no atau reference_no sudah dipakai
+	ErrDuplicateTransaction = errors.New("duplicate transaction")
+)
+
+// TransactionStore adalah penyimpanan transaksi yang dipakai TransactionService.
+// Implementasi: TransactionRepository (GORM, Postgres/SQLite) dan MemoryTransactionStore.
+// Semua implementasi wajib lolos suite di package storetest.
+type TransactionStore interface {
+	// WithContext mengembalikan store yang query-nya membawa ctx (trace span, pembatalan)
+	WithContext(ctx context.Context) TransactionStore
+	// WithTx mengembalikan store yang ikut database transaction tx milik repository lain
+	// (RiskRepository.LockMerchant, LedgerRepository.Transaction), sehingga insert dan update
+	// transaksi commit atau rollback bersama perubahan repository tersebut
+	WithTx(tx *gorm.DB) TransactionStore
+	// Save menyimpan transaksi baru dan mengisi ID serta timestamp-nya.
+	// Mengembalikan ErrDuplicateTransaction jika melanggar unique constraint.
+	Save(transaction model.Transaction) (model.Transaction, error)
+	// FindByReferenceNo mengembalikan ErrTransactionNotFound jika tidak ada
+	FindByReferenceNo(referenceNo string) (model.Transaction, error)
+	// FindByPartnerReference mengembalikan nil tanpa error jika tidak ada
+	FindByPartnerReference(partnerRef string) (*model.Transaction, error)
+	FindByReferenceNos(referenceNos []string) ([]model.Transaction, error)
+	FindPaidBetween(start time.Time, end time.Time) ([]model.Transaction, error)
+	// MerchantIDsBetween merchant (urut naik, tanpa duplikat) yang punya transaksi dengan created_at dalam [start, end)
+	MerchantIDsBetween(start time.Time, end time.Time) ([]string, error)
+	// UpdateStatus mengembalikan ErrTransactionNotFound jika reference tidak ada
+	UpdateStatus(referenceNo string, status string, paidDate time.Time) error
+	// UpdatePayer menyimpan data pembayar; field kosong tidak menimpa nilai lama.
+	// Mengembalikan ErrTransactionNotFound jika reference tidak ada
+	UpdatePayer(referenceNo string, payer model.PayerInfo) error
+	// GetTransactions mengembalikan satu halaman hasil filter beserta total seluruh hasil
+	GetTransactions(filter model.TransactionFilter) ([]model.Transaction, int64, error)
+	// StreamTransactions memanggil fn untuk setiap transaksi yang lolos filter sesuai urutan sort,
+	// tanpa memuat seluruh hasil ke memori. Limit > 0 membatasi jumlah baris; Offset dan cursor diabaikan.
+	// Error dari fn menghentikan iterasi dan dikembalikan apa adanya.
+	StreamTransactions(filter model.TransactionFilter, fn func(model.Transaction) error) error
+	// GetStats mengagregasi transaksi per status dan per bucket created_at (bucket kosong dilewati)
+	GetStats(query model.TransactionStatsQuery) (model.TransactionAggregates, error)
+}
+
+// isDuplicateKey mengenali pelanggaran unique constraint dari Postgres maupun SQLite
+func isDuplicateKey(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "23505") ||
+		strings.Contains(msg, "duplicate key") ||
+		strings.Contains(msg, "UNIQUE constraint failed")
+}
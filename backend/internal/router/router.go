@@ -1,31 +1,39 @@
 package router
 
 import (
+	"qr-service/config"
 	"qr-service/internal/handler"
+	"qr-service/pkg/ratelimit"
+	"qr-service/pkg/snap"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/swagger"
 	fiberws "github.com/gofiber/websocket/v2"
 )
 
-func SetupRoutes(app *fiber.App, transactionHandler *handler.TransactionHandler, wsHandler *handler.WebSocketHandler) {
+func SetupRoutes(app *fiber.App, cfg *config.Config, transactionHandler *handler.TransactionHandler, merchantHandler *handler.MerchantHandler, riskHandler *handler.RiskHandler, reconciliationHandler *handler.ReconciliationHandler, settlementHandler *handler.SettlementHandler, ledgerHandler *handler.LedgerHandler, reportHandler *handler.ReportHandler, healthHandler *handler.HealthHandler, wsHandler *handler.WebSocketHandler, limiter *ratelimit.Limiter) {
 	// Basic routes
 	app.Get("/", handler.WelcomeHandler)
 
-	// Health check
+	// Health check: /health dipertahankan untuk uptime check lama, probe baru memakai /livez dan /readyz
 	app.Get("/health", healthCheck)
+	app.Get("/livez", healthHandler.Livez)
+	app.Get("/readyz", healthHandler.Readyz)
+
+	// Prometheus scrape endpoint
+	app.Get("/metrics", handler.MetricsHandler)
 
 	// WebSocket routes
-	setupWebSocketRoutes(app, wsHandler)
+	setupWebSocketRoutes(app, wsHandler, cfg.Server.WebSocketURL())
 
 	// API v1 routes
-	setupAPIV1Routes(app, transactionHandler)
+	setupAPIV1Routes(app, cfg.Security, rateLimiter(cfg.RateLimit, limiter), transactionHandler, merchantHandler, riskHandler, reconciliationHandler, settlementHandler, ledgerHandler, reportHandler)
 
 	// Documentation routes
 	setupDocumentationRoutes(app)
 }
 
-func setupWebSocketRoutes(app *fiber.App, wsHandler *handler.WebSocketHandler) {
+func setupWebSocketRoutes(app *fiber.App, wsHandler *handler.WebSocketHandler, wsURL string) {
 	wsGroup := app.Group("/ws")
 
 	// WebSocket middleware
@@ -43,24 +51,98 @@ func setupWebSocketRoutes(app *fiber.App, wsHandler *handler.WebSocketHandler) {
 	// WebSocket info
 	app.Get("/websocket-info", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{
-			"websocket_endpoint": "ws://localhost:8000/ws",
+			"websocket_endpoint": wsURL,
 			"protocol":           "WebSocket",
 			"description":        "Realtime transaction updates",
 		})
 	})
 }
 
-func setupAPIV1Routes(app *fiber.App, transactionHandler *handler.TransactionHandler) {
+// rateLimiter membuat middleware rate limit per group route sesuai konfigurasi.
+// Group tanpa konfigurasi, atau rate limit yang dimatikan, tidak dibatasi.
+func rateLimiter(cfg config.RateLimitConfig, limiter *ratelimit.Limiter) func(group string) fiber.Handler {
+	return func(group string) fiber.Handler {
+		if !cfg.Enabled {
+			return handler.RateLimit(nil, group, "")
+		}
+		return handler.RateLimit(limiter, group, cfg.Groups[group].Key)
+	}
+}
+
+func setupAPIV1Routes(app *fiber.App, security config.SecurityConfig, rateLimit func(group string) fiber.Handler, transactionHandler *handler.TransactionHandler, merchantHandler *handler.MerchantHandler, riskHandler *handler.RiskHandler, reconciliationHandler *handler.ReconciliationHandler, settlementHandler *handler.SettlementHandler, ledgerHandler *handler.LedgerHandler, reportHandler *handler.ReportHandler) {
 	api := app.Group("/api/v1")
 
-	// QR routes dengan HMAC validation
+	// QR routes (SNAP): signature HMAC, rate limit per partner, lalu header wajib SNAP. Service
+	// code untuk response code diambil dari registry snap.Services berdasarkan path ini.
+	validateHMAC := handler.ValidateHMAC(security.HMACSecret)
+	qrRateLimit := rateLimit("qr")
+	snapHeaders := handler.SNAPHeaders(snap.NewExternalIDStore())
 	qr := api.Group("/qr")
-	qr.Post("/generate", handler.ValidateHMAC, transactionHandler.GenerateQR)
-	qr.Post("/payment", handler.ValidateHMAC, transactionHandler.ProcessPaymentCallback)
+	qr.Post("/generate", validateHMAC, qrRateLimit, snapHeaders, transactionHandler.GenerateQR)
+	qr.Post("/payment", validateHMAC, qrRateLimit, snapHeaders, transactionHandler.ProcessPaymentCallback)
+
+	// Callback per provider: rate limit per IP (budget sendiri, X-PARTNER-ID belum terautentikasi),
+	// autentikasi oleh adapter provider (pkg/provider), lalu header SNAP dan cek replay X-EXTERNAL-ID
+	api.Post("/callbacks/:provider", rateLimit("callbacks"), transactionHandler.AuthenticateProviderCallback, snapHeaders, transactionHandler.ProviderCallback)
+
+	// Route admin memakai key admin; route data per merchant juga menerima key merchant (X-API-KEY)
+	adminAuth := handler.AdminAuth(security.AdminAPIKey)
+	merchantAuth := handler.MerchantAuth(security.AdminAPIKey, security.MerchantAPIKeys)
 
-	// Transaction routes (tanpa HMAC)
-	transactions := api.Group("/transactions")
+	// Transaction routes (tanpa HMAC, budget sendiri karena list/export paling berat ke database).
+	// Inquiry memanggil provider eksternal sehingga butuh key, merchant hanya untuk transaksinya sendiri.
+	transactions := api.Group("/transactions", rateLimit("transactions"))
 	transactions.Get("/", transactionHandler.GetTransactions)
+	transactions.Get("/stats", transactionHandler.GetStats)
+	transactions.Get("/export", transactionHandler.ExportTransactions)
+	transactions.Post("/:referenceNo/inquiry", merchantAuth, transactionHandler.InquireStatus)
+
+	// Merchant hierarchy routes (merchant -> outlet -> terminal), master data hanya diubah admin
+	// Route lainnya berbagi budget group "api"
+	apiRateLimit := rateLimit("api")
+	merchants := api.Group("/merchants/:merchantId", apiRateLimit)
+	merchants.Get("/", merchantHandler.GetMerchant)
+	merchants.Put("/", adminAuth, merchantHandler.SaveMerchant)
+	merchants.Get("/outlets", merchantHandler.GetOutlets)
+	merchants.Post("/outlets", adminAuth, merchantHandler.CreateOutlet)
+	merchants.Post("/outlets/:outletId/terminals", adminAuth, merchantHandler.CreateTerminal)
+
+	// Risk rule routes (limit per merchant dan catatan rule hit), limit hanya bisa diubah admin
+	merchants.Get("/limits", riskHandler.GetLimit)
+	merchants.Put("/limits", adminAuth, riskHandler.SetLimit)
+	api.Get("/risk/hits", apiRateLimit, riskHandler.GetHits)
+
+	// Reconciliation routes (file settlement dari acquirer), hanya admin karena upload bisa
+	// mengoreksi status transaksi menjadi PAID
+	reconciliations := api.Group("/reconciliations", apiRateLimit, adminAuth)
+	reconciliations.Post("/", reconciliationHandler.Upload)
+	reconciliations.Get("/:id", reconciliationHandler.GetReconciliation)
+
+	// Settlement routes (batch payout merchant dengan MDR), hanya admin: memicu payout dan
+	// mengubah tarif MDR. Daftar fee rule tetap bisa dibaca tanpa key.
+	settlements := api.Group("/settlements", apiRateLimit, adminAuth)
+	settlements.Get("/", settlementHandler.GetSettlements)
+	settlements.Post("/run", settlementHandler.RunSettlement)
+	settlements.Get("/:id", settlementHandler.GetSettlement)
+	api.Get("/fee-rules", apiRateLimit, settlementHandler.GetFeeRules)
+	api.Put("/fee-rules/:category", apiRateLimit, adminAuth, settlementHandler.SaveFeeRule)
+
+	// Tabel pemetaan status per payment gateway (read-only, dari konfigurasi)
+	api.Get("/status-mappings", apiRateLimit, transactionHandler.GetStatusMappings)
+
+	// Ledger routes (double-entry, append-only)
+	merchants.Get("/balance", ledgerHandler.GetMerchantBalance)
+	ledger := api.Group("/ledger", apiRateLimit)
+	ledger.Get("/entries", ledgerHandler.GetEntries)
+	ledger.Get("/check", ledgerHandler.Check)
+
+	// Report routes (laporan harian per merchant, dibuat otomatis oleh scheduler). Membuat laporan
+	// (dan mengirim email) hanya admin; key merchant hanya bisa membaca laporan merchant-nya sendiri.
+	reports := api.Group("/reports/daily", apiRateLimit)
+	reports.Get("/", merchantAuth, reportHandler.GetDailyReports)
+	reports.Post("/run", adminAuth, reportHandler.RunDailyReports)
+	reports.Get("/:id", merchantAuth, reportHandler.GetDailyReport)
+	reports.Get("/:id/download", merchantAuth, reportHandler.DownloadDailyReport)
 
 	// Utility routes (jika ada)
 	// utils := api.Group("/utils")
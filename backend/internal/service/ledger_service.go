@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"qr-service/internal/model"
+	"qr-service/internal/repository"
+	"qr-service/pkg/apperror"
+	"qr-service/pkg/snap"
+)
+
+type LedgerService struct {
+	Repo *repository.LedgerRepository
+}
+
+func NewLedgerService(repo *repository.LedgerRepository) *LedgerService {
+	return &LedgerService{Repo: repo}
+}
+
+// Transaction menjalankan fn dalam satu database transaction: perubahan lewat store (store.WithTx)
+// dan posting lewat ledger commit bersama, atau rollback bersama jika fn mengembalikan error
+func (s *LedgerService) Transaction(ctx context.Context, store repository.TransactionStore, fn func(ledger *LedgerService, store repository.TransactionStore) error) error {
+	return s.Repo.Transaction(ctx, func(tx *repository.LedgerRepository) error {
+		return fn(&LedgerService{Repo: tx}, store.WithTx(tx.DB))
+	})
+}
+
+// PostPayment: dana masuk ke acquirer, tercatat sebagai hutang ke merchant
+//
+//	DR ACQUIRER_CLEARING / CR MERCHANT_PAYABLE:<merchant>
+func (s *LedgerService) PostPayment(trx model.Transaction) error {
+	return s.post(model.EntryPayment, "PAYMENT:"+paymentKey(trx), trx.MerchantID,
+		"Payment "+trx.ReferenceNo, model.AcquirerClearingAccount, model.MerchantPayableAccount(trx.MerchantID), trx.Amount)
+}
+
+// PostRefund: pembayaran dibatalkan/direfund, hutang ke merchant berkurang.
+// trx adalah kondisi transaksi sebelum status PAID-nya dicabut.
+//
+//	DR MERCHANT_PAYABLE:<merchant> / CR ACQUIRER_CLEARING
+func (s *LedgerService) PostRefund(trx model.Transaction) error {
+	return s.post(model.EntryRefund, "REFUND:"+paymentKey(trx), trx.MerchantID,
+		"Refund "+trx.ReferenceNo, model.MerchantPayableAccount(trx.MerchantID), model.AcquirerClearingAccount, trx.Amount)
+}
+
+// PostSettlement mencatat MDR dan payout sebuah batch settlement. Kedua entry diposting
+// dalam satu database transaction, jadi tidak pernah hanya salah satu yang tercatat.
+//
+//	FEE:        DR MERCHANT_PAYABLE:<merchant> / CR FEE_REVENUE
+//	SETTLEMENT: DR MERCHANT_PAYABLE:<merchant> / CR ACQUIRER_CLEARING
+func (s *LedgerService) PostSettlement(ctx context.Context, batch model.SettlementBatch) error {
+	payable := model.MerchantPayableAccount(batch.MerchantID)
+
+	return s.Repo.Transaction(ctx, func(tx *repository.LedgerRepository) error {
+		ledger := &LedgerService{Repo: tx}
+		if batch.FeeAmount > 0 {
+			err := ledger.post(model.EntryFee, "FEE:"+batch.BatchNo, batch.MerchantID,
+				"MDR "+batch.BatchNo, payable, model.FeeRevenueAccount, batch.FeeAmount)
+			if err != nil {
+				return err
+			}
+		}
+
+		return ledger.post(model.EntrySettlement, "SETTLEMENT:"+batch.BatchNo, batch.MerchantID,
+			"Payout "+batch.BatchNo, payable, model.AcquirerClearingAccount, batch.NetAmount)
+	})
+}
+
+// post membuat entry dua kaki yang seimbang. Entry dengan reference yang sama
+// hanya diposting sekali sehingga aman dipanggil ulang.
+func (s *LedgerService) post(entryType, reference, merchantID, description, debitAccount, creditAccount string, amount float64) error {
+	amount = roundAmount(amount)
+	if amount <= 0 {
+		return nil
+	}
+
+	existing, err := s.Repo.FindEntryByReference(reference)
+	if err != nil {
+		return apperror.Wrap(err, apperror.Internal, fmt.Sprintf("failed to check ledger entry %s", reference))
+	}
+	if existing != nil {
+		return nil
+	}
+
+	entry := model.JournalEntry{
+		EntryType:   entryType,
+		Reference:   reference,
+		MerchantID:  merchantID,
+		Description: description,
+		Postings: []model.Posting{
+			{AccountCode: debitAccount, Direction: model.Debit, Amount: amount},
+			{AccountCode: creditAccount, Direction: model.Credit, Amount: amount},
+		},
+	}
+	if err := validateBalanced(entry); err != nil {
+		return err
+	}
+
+	accounts := []model.LedgerAccount{
+		ledgerAccount(debitAccount, merchantID),
+		ledgerAccount(creditAccount, merchantID),
+	}
+	if _, err := s.Repo.CreateEntry(entry, accounts); err != nil {
+		return apperror.Wrap(err, apperror.Internal, fmt.Sprintf("failed to post ledger entry %s", reference))
+	}
+	return nil
+}
+
+// Implementasi Endpoint GET /api/v1/merchants/:merchantId/balance
+func (s *LedgerService) GetMerchantBalance(merchantID string) (*model.MerchantBalanceResponse, error) {
+	totals, err := s.Repo.GetAccountTotals(model.MerchantPayableAccount(merchantID))
+	if err != nil {
+		return nil, err
+	}
+
+	balance := model.MerchantBalance{MerchantID: merchantID, Currency: "IDR"}
+	for _, t := range totals {
+		// Akun liability: saldo bertambah di sisi kredit
+		balance.Balance += t.Credit - t.Debit
+		switch t.EntryType {
+		case model.EntryPayment:
+			balance.TotalPayments += t.Credit
+		case model.EntryRefund:
+			balance.TotalRefunds += t.Debit
+		case model.EntryFee:
+			balance.TotalFees += t.Debit
+		case model.EntrySettlement:
+			balance.TotalSettled += t.Debit
+		}
+	}
+	balance.Balance = roundAmount(balance.Balance)
+
+	return &model.MerchantBalanceResponse{
+		ResponseCode:    snap.Internal.SuccessCode(),
+		ResponseMessage: "Success",
+		Data:            balance,
+	}, nil
+}
+
+// Implementasi Endpoint GET /api/v1/ledger/check
+// Memastikan total debit sama dengan total kredit, baik global maupun per entry.
+func (s *LedgerService) Check() (*model.LedgerCheckResponse, error) {
+	debit, credit, err := s.Repo.GetTotals()
+	if err != nil {
+		return nil, err
+	}
+	count, err := s.Repo.CountEntries()
+	if err != nil {
+		return nil, err
+	}
+	unbalanced, err := s.Repo.GetUnbalancedEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	check := model.LedgerCheck{
+		EntryCount:        count,
+		TotalDebit:        roundAmount(debit),
+		TotalCredit:       roundAmount(credit),
+		UnbalancedEntries: unbalanced,
+	}
+	check.Balanced = len(unbalanced) == 0 && math.Abs(debit-credit) < amountTolerance
+
+	return &model.LedgerCheckResponse{
+		ResponseCode:    snap.Internal.SuccessCode(),
+		ResponseMessage: "Success",
+		Data:            check,
+	}, nil
+}
+
+// Implementasi Endpoint GET /api/v1/ledger/entries
+func (s *LedgerService) GetEntries(req model.GetJournalEntriesRequest) (*model.JournalEntriesResponse, error) {
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+
+	entries, total, err := s.Repo.GetEntries(req.MerchantID, req.EntryType, req.Page, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPage := int((total + int64(req.Limit) - 1) / int64(req.Limit))
+
+	return &model.JournalEntriesResponse{
+		ResponseCode:    snap.Internal.SuccessCode(),
+		ResponseMessage: "Success",
+		Data:            entries,
+		Pagination: &model.PaginationInfo{
+			Page:      req.Page,
+			Limit:     req.Limit,
+			Total:     int(total),
+			TotalPage: totalPage,
+		},
+	}, nil
+}
+
+func validateBalanced(entry model.JournalEntry) error {
+	var debit, credit float64
+	for _, posting := range entry.Postings {
+		switch posting.Direction {
+		case model.Debit:
+			debit += posting.Amount
+		case model.Credit:
+			credit += posting.Amount
+		default:
+			return fmt.Errorf("invalid posting direction %s", posting.Direction)
+		}
+	}
+	if math.Abs(debit-credit) >= amountTolerance {
+		return errors.New("unbalanced journal entry: debit and credit differ")
+	}
+	return nil
+}
+
+func ledgerAccount(code string, merchantID string) model.LedgerAccount {
+	switch code {
+	case model.AcquirerClearingAccount:
+		return model.LedgerAccount{Code: code, Type: model.AccountAsset}
+	case model.FeeRevenueAccount:
+		return model.LedgerAccount{Code: code, Type: model.AccountRevenue}
+	default:
+		return model.LedgerAccount{Code: code, Type: model.AccountLiability, MerchantID: merchantID}
+	}
+}
+
+// paymentKey memasangkan entry PAYMENT dan REFUND dari pembayaran yang sama,
+// sehingga transaksi yang dibayar ulang setelah refund tetap tercatat
+func paymentKey(trx model.Transaction) string {
+	if trx.PaidDate == nil {
+		return trx.ReferenceNo
+	}
+	return fmt.Sprintf("%s:%d", trx.ReferenceNo, trx.PaidDate.Unix())
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"qr-service/internal/model"
+	"qr-service/internal/repository"
+	"slices"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newLedgerService(t *testing.T) (*LedgerService, *gorm.DB) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "ledger.db")), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&model.LedgerAccount{}, &model.JournalEntry{}, &model.Posting{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return NewLedgerService(repository.NewLedgerRepository(db)), db
+}
+
+func TestLedgerPostingsBalance(t *testing.T) {
+	s, _ := newLedgerService(t)
+	paidDate := time.Date(2025, 9, 21, 10, 0, 0, 0, time.UTC)
+	paid := model.Transaction{MerchantID: "M001", ReferenceNo: "A001", Amount: 10000, PaidDate: &paidDate}
+	refunded := model.Transaction{MerchantID: "M001", ReferenceNo: "A002", Amount: 5000, PaidDate: &paidDate}
+	batch := model.SettlementBatch{BatchNo: "STL-20250921-M001-1", MerchantID: "M001", FeeAmount: 70, NetAmount: 9930}
+
+	posts := []struct {
+		reference string
+		post      func() error
+	}{
+		{"PAYMENT:" + paymentKey(paid), func() error { return s.PostPayment(paid) }},
+		{"PAYMENT:" + paymentKey(refunded), func() error { return s.PostPayment(refunded) }},
+		{"REFUND:" + paymentKey(refunded), func() error { return s.PostRefund(refunded) }},
+		{"FEE:" + batch.BatchNo, func() error { return s.PostSettlement(context.Background(), batch) }},
+		{"SETTLEMENT:" + batch.BatchNo, func() error { return s.PostSettlement(context.Background(), batch) }},
+	}
+	for _, p := range posts {
+		// Posting ulang dengan reference yang sama tidak membuat entry baru
+		for range 2 {
+			if err := p.post(); err != nil {
+				t.Fatalf("post %s returned error: %v", p.reference, err)
+			}
+		}
+		entry, err := s.Repo.FindEntryByReference(p.reference)
+		if err != nil || entry == nil {
+			t.Fatalf("entry %s = %v, %v; want posted", p.reference, entry, err)
+		}
+		var sum float64
+		for _, posting := range entry.Postings {
+			if posting.Direction == model.Debit {
+				sum += posting.Amount
+			} else {
+				sum -= posting.Amount
+			}
+		}
+		if len(entry.Postings) != 2 || sum != 0 {
+			t.Fatalf("entry %s postings = %+v, want two legs summing to zero", p.reference, entry.Postings)
+		}
+	}
+
+	check, err := s.Check()
+	if err != nil {
+		t.Fatalf("Check returned error: %v", err)
+	}
+	if !check.Data.Balanced || check.Data.EntryCount != 5 || check.Data.TotalDebit != 30000 || check.Data.TotalCredit != 30000 {
+		t.Fatalf("check = %+v, want 5 balanced entries totalling 30000", check.Data)
+	}
+	balance, err := s.GetMerchantBalance("M001")
+	if err != nil {
+		t.Fatalf("GetMerchantBalance returned error: %v", err)
+	}
+	want := model.MerchantBalance{MerchantID: "M001", Currency: "IDR", Balance: 0, TotalPayments: 15000, TotalRefunds: 5000, TotalFees: 70, TotalSettled: 9930}
+	if balance.Data != want {
+		t.Fatalf("balance = %+v, want %+v", balance.Data, want)
+	}
+}
+
+func TestLedgerCheckDetectsImbalance(t *testing.T) {
+	s, db := newLedgerService(t)
+	paidDate := time.Date(2025, 9, 21, 10, 0, 0, 0, time.UTC)
+	for _, ref := range []string{"A001", "A002"} {
+		if err := s.PostPayment(model.Transaction{MerchantID: "M001", ReferenceNo: ref, Amount: 10000, PaidDate: &paidDate}); err != nil {
+			t.Fatalf("PostPayment returned error: %v", err)
+		}
+	}
+	entry, err := s.Repo.FindEntryByReference("PAYMENT:" + paymentKey(model.Transaction{ReferenceNo: "A002", PaidDate: &paidDate}))
+	if err != nil || entry == nil {
+		t.Fatalf("payment entry = %v, %v; want posted", entry, err)
+	}
+
+	// Posting tanpa pasangan (bukan lewat LedgerService) membuat entry A002 tidak seimbang
+	if err := db.Create(&model.Posting{EntryID: entry.ID, AccountCode: model.AcquirerClearingAccount, Direction: model.Debit, Amount: 1}).Error; err != nil {
+		t.Fatalf("create posting: %v", err)
+	}
+	check, err := s.Check()
+	if err != nil {
+		t.Fatalf("Check returned error: %v", err)
+	}
+	if check.Data.Balanced || !slices.Equal(check.Data.UnbalancedEntries, []uint{entry.ID}) || check.Data.TotalDebit != 20001 || check.Data.TotalCredit != 20000 {
+		t.Fatalf("check = %+v, want entry %d unbalanced", check.Data, entry.ID)
+	}
+}
+
+func TestValidateBalancedRejectsUnbalancedEntry(t *testing.T) {
+	cases := []struct {
+		name     string
+		postings []model.Posting
+		wantErr  bool
+	}{
+		{"balanced", []model.Posting{{Direction: model.Debit, Amount: 100}, {Direction: model.Credit, Amount: 100}}, false},
+		{"within rounding tolerance", []model.Posting{{Direction: model.Debit, Amount: 100.001}, {Direction: model.Credit, Amount: 100}}, false},
+		{"debit exceeds credit", []model.Posting{{Direction: model.Debit, Amount: 100.01}, {Direction: model.Credit, Amount: 100}}, true},
+		{"invalid direction", []model.Posting{{Direction: "SIDEWAYS", Amount: 100}, {Direction: model.Credit, Amount: 100}}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := validateBalanced(model.JournalEntry{Postings: tc.postings}); (err != nil) != tc.wantErr {
+				t.Fatalf("validateBalanced error = %v, wantErr %t", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"qr-service/internal/model"
+	"qr-service/internal/repository"
+	"qr-service/pkg/apperror"
+	"qr-service/pkg/snap"
+)
+
+type MerchantService struct {
+	Repo *repository.MerchantRepository
+}
+
+func NewMerchantService(repo *repository.MerchantRepository) *MerchantService {
+	return &MerchantService{Repo: repo}
+}
+
+// Implementasi Endpoint PUT /api/v1/merchants/:merchantId
+func (s *MerchantService) SaveMerchant(merchantID string, req model.SaveMerchantRequest) (*model.MerchantResponse, error) {
+	merchant, err := s.Repo.SaveMerchant(model.Merchant{
+		MerchantID:  merchantID,
+		Name:        req.Name,
+		Category:    req.Category,
+		ReportEmail: req.ReportEmail,
+	})
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.Internal, "failed to save merchant")
+	}
+
+	return &model.MerchantResponse{
+		ResponseCode:    snap.Internal.SuccessCode(),
+		ResponseMessage: "Success",
+		Data:            merchant,
+	}, nil
+}
+
+// Implementasi Endpoint GET /api/v1/merchants/:merchantId
+func (s *MerchantService) GetMerchant(merchantID string) (*model.MerchantResponse, error) {
+	merchant, err := s.Repo.FindMerchant(merchantID)
+	if err != nil {
+		return nil, err
+	}
+	if merchant == nil {
+		return nil, apperror.New(apperror.NotFound, "merchant not found")
+	}
+
+	return &model.MerchantResponse{
+		ResponseCode:    snap.Internal.SuccessCode(),
+		ResponseMessage: "Success",
+		Data:            *merchant,
+	}, nil
+}
+
+// Implementasi Endpoint POST /api/v1/merchants/:merchantId/outlets
+func (s *MerchantService) CreateOutlet(merchantID string, req model.CreateOutletRequest) (*model.OutletResponse, error) {
+	// 1. Cek apakah outlet_id sudah dipakai
+	existing, err := s.Repo.FindOutlet(req.OutletID)
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.Internal, "failed to check existing outlet")
+	}
+	if existing != nil {
+		return nil, apperror.Newf(apperror.Conflict, "outlet %s already exists", req.OutletID)
+	}
+
+	// 2. Simpan outlet baru
+	outlet, err := s.Repo.SaveOutlet(model.Outlet{
+		OutletID:   req.OutletID,
+		MerchantID: merchantID,
+		Name:       req.Name,
+		Address:    req.Address,
+	})
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.Internal, "failed to save outlet")
+	}
+
+	return &model.OutletResponse{
+		ResponseCode:    snap.Internal.SuccessCode(),
+		ResponseMessage: "Success",
+		Data:            outlet,
+	}, nil
+}
+
+// Implementasi Endpoint POST /api/v1/merchants/:merchantId/outlets/:outletId/terminals
+func (s *MerchantService) CreateTerminal(merchantID, outletID string, req model.CreateTerminalRequest) (*model.TerminalResponse, error) {
+	// 1. Outlet harus ada dan milik merchant yang sama
+	outlet, err := s.Repo.FindOutlet(outletID)
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.Internal, "failed to find outlet")
+	}
+	if outlet == nil || outlet.MerchantID != merchantID {
+		return nil, apperror.New(apperror.NotFound, "outlet not found")
+	}
+
+	// 2. Cek apakah terminal_id sudah dipakai
+	existing, err := s.Repo.FindTerminal(req.TerminalID)
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.Internal, "failed to check existing terminal")
+	}
+	if existing != nil {
+		return nil, apperror.Newf(apperror.Conflict, "terminal %s already exists", req.TerminalID)
+	}
+
+	// 3. Simpan terminal baru
+	terminal, err := s.Repo.SaveTerminal(model.Terminal{
+		TerminalID: req.TerminalID,
+		OutletID:   outlet.OutletID,
+		MerchantID: merchantID,
+		Name:       req.Name,
+	})
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.Internal, "failed to save terminal")
+	}
+
+	return &model.TerminalResponse{
+		ResponseCode:    snap.Internal.SuccessCode(),
+		ResponseMessage: "Success",
+		Data:            terminal,
+	}, nil
+}
+
+// Implementasi Endpoint GET /api/v1/merchants/:merchantId/outlets
+func (s *MerchantService) GetOutlets(merchantID string) (*model.GetOutletsResponse, error) {
+	outlets, err := s.Repo.GetOutlets(merchantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.GetOutletsResponse{
+		ResponseCode:    snap.Internal.SuccessCode(),
+		ResponseMessage: "Success",
+		Data:            outlets,
+	}, nil
+}
+
+// ResolveHierarchy memvalidasi outlet/terminal terhadap merchant dan
+// mengembalikan outlet_id yang benar (diturunkan dari terminal bila perlu).
+func (s *MerchantService) ResolveHierarchy(merchantID, outletID, terminalID string) (string, error) {
+	if terminalID != "" {
+		terminal, err := s.Repo.FindTerminal(terminalID)
+		if err != nil {
+			return "", apperror.Wrap(err, apperror.Internal, "failed to find terminal")
+		}
+		if terminal == nil || terminal.MerchantID != merchantID {
+			return "", apperror.New(apperror.InvalidTerminal, "invalid terminal: terminal not registered for merchant")
+		}
+		if outletID != "" && outletID != terminal.OutletID {
+			return "", apperror.New(apperror.InvalidTerminal, "invalid terminal: terminal does not belong to outlet")
+		}
+		return terminal.OutletID, nil
+	}
+
+	if outletID != "" {
+		outlet, err := s.Repo.FindOutlet(outletID)
+		if err != nil {
+			return "", apperror.Wrap(err, apperror.Internal, "failed to find outlet")
+		}
+		if outlet == nil || outlet.MerchantID != merchantID {
+			return "", apperror.New(apperror.InvalidMerchant, "invalid outlet: outlet not registered for merchant")
+		}
+	}
+
+	return outletID, nil
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"math"
+	"qr-service/internal/model"
+	"qr-service/internal/repository"
+	"qr-service/pkg/apperror"
+	"qr-service/pkg/settlement"
+	"qr-service/pkg/snap"
+	"qr-service/pkg/util"
+	"strings"
+	"time"
+)
+
+// Toleransi selisih amount (pembulatan 2 desimal)
+const amountTolerance = 0.005
+
+type ReconciliationService struct {
+	Repo         *repository.ReconciliationRepository
+	Transactions *TransactionService
+}
+
+func NewReconciliationService(repo *repository.ReconciliationRepository, transactions *TransactionService) *ReconciliationService {
+	return &ReconciliationService{Repo: repo, Transactions: transactions}
+}
+
+// Reconcile mencocokkan file settlement dengan transaksi yang tercatat dari callback.
+// Dipakai oleh endpoint POST /api/v1/reconciliations dan command `qr-service reconcile`.
+func (s *ReconciliationService) Reconcile(ctx context.Context, file io.Reader, req model.ReconcileRequest) (*model.ReconciliationResponse, error) {
+	// 1. Pilih parser sesuai format file
+	if req.Format == "" {
+		req.Format = "csv"
+	}
+	parser, err := settlement.Get(req.Format)
+	if err != nil {
+		return nil, apperror.New(apperror.InvalidFieldFormat, err.Error())
+	}
+
+	// 2. Parse file settlement
+	records, err := parser.Parse(file)
+	if err != nil {
+		return nil, apperror.Newf(apperror.InvalidFieldFormat, "invalid settlement file: %v", err)
+	}
+
+	// 3. Tentukan periode settlement (untuk mencari transaksi yang tidak ada di file)
+	periodStart, periodEnd, err := settlementPeriod(req.Date, records)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Ambil transaksi kita untuk semua reference di file
+	referenceNos := make([]string, 0, len(records))
+	for _, record := range records {
+		referenceNos = append(referenceNos, record.ReferenceNo)
+	}
+	transactions, err := s.Transactions.Repo.FindByReferenceNos(referenceNos)
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.Internal, "failed to load transactions")
+	}
+	ours := make(map[string]model.Transaction, len(transactions))
+	for _, trx := range transactions {
+		ours[trx.ReferenceNo] = trx
+	}
+
+	run := model.ReconciliationRun{
+		FileName:     req.FileName,
+		Format:       req.Format,
+		PeriodStart:  periodStart,
+		PeriodEnd:    periodEnd,
+		TotalRecords: len(records),
+	}
+
+	// 5. Cocokkan setiap baris file dengan transaksi kita; reference yang muncul lagi di baris
+	// berikutnya dicatat sebagai DUPLICATE dan tidak dihitung matched dua kali
+	inFile := make(map[string]bool, len(records))
+	for _, record := range records {
+		duplicate := inFile[record.ReferenceNo]
+		inFile[record.ReferenceNo] = true
+		theirAmount := record.Amount
+
+		item := model.ReconciliationItem{
+			Line:               record.Line,
+			ReferenceNo:        record.ReferenceNo,
+			PartnerReferenceNo: record.PartnerReferenceNo,
+			TheirAmount:        &theirAmount,
+			TheirCurrency:      record.Currency,
+			TheirStatus:        record.Status,
+			PaidTime:           record.PaidTime,
+		}
+
+		trx, found := ours[record.ReferenceNo]
+		switch {
+		case duplicate:
+			item.Category = model.ReconDuplicate
+			run.Duplicate++
+		case !found:
+			item.Category = model.ReconMissingOurs
+			run.MissingOurs++
+		case math.Abs(trx.Amount-record.Amount) > amountTolerance:
+			item.Category = model.ReconAmountMismatch
+			run.AmountMismatch++
+		case record.Currency != "" && !strings.EqualFold(record.Currency, trx.Currency):
+			item.Category = model.ReconCurrencyMismatch
+			run.CurrencyMismatch++
+		default:
+			item.Category = model.ReconMatched
+			run.Matched++
+		}
+
+		if found {
+			ourAmount := trx.Amount
+			item.OurAmount = &ourAmount
+			item.OurCurrency = trx.Currency
+			item.OurStatus = trx.Status
+			if item.PartnerReferenceNo == "" {
+				item.PartnerReferenceNo = trx.PartnerReferenceNo
+			}
+		}
+
+		// 6. Koreksi status: baris cocok dan dibayar menurut bank, tapi callback tidak pernah datang
+		if req.Correct && item.Category == model.ReconMatched && trx.Status != "PAID" && s.settledStatus(record.Status) {
+			paidTime := time.Now()
+			if record.PaidTime != nil {
+				paidTime = *record.PaidTime
+			}
+			if err := s.Transactions.UpdateStatus(ctx, trx, "PAID", paidTime); err != nil {
+				slog.ErrorContext(ctx, "Failed to correct transaction status", "reference_no", trx.ReferenceNo, "error", err)
+			} else {
+				item.Corrected = true
+				run.Corrected++
+			}
+		}
+
+		run.Items = append(run.Items, item)
+	}
+
+	// 7. Transaksi PAID di periode yang sama tetapi tidak ada di file settlement
+	if !periodStart.IsZero() {
+		paid, err := s.Transactions.Repo.FindPaidBetween(periodStart, periodEnd)
+		if err != nil {
+			return nil, apperror.Wrap(err, apperror.Internal, "failed to load paid transactions")
+		}
+		for _, trx := range paid {
+			if inFile[trx.ReferenceNo] {
+				continue
+			}
+			ourAmount := trx.Amount
+			run.Items = append(run.Items, model.ReconciliationItem{
+				Category:           model.ReconMissingTheirs,
+				ReferenceNo:        trx.ReferenceNo,
+				PartnerReferenceNo: trx.PartnerReferenceNo,
+				OurAmount:          &ourAmount,
+				OurCurrency:        trx.Currency,
+				OurStatus:          trx.Status,
+				PaidTime:           trx.PaidDate,
+			})
+			run.MissingTheirs++
+		}
+	}
+
+	// 8. Simpan laporan
+	saved, err := s.Repo.SaveRun(run)
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.Internal, "failed to save reconciliation")
+	}
+
+	return &model.ReconciliationResponse{
+		ResponseCode:    snap.Internal.SuccessCode(),
+		ResponseMessage: "Success",
+		Data:            saved,
+	}, nil
+}
+
+// Implementasi Endpoint GET /api/v1/reconciliations/:id
+func (s *ReconciliationService) GetReconciliation(id uint) (*model.ReconciliationResponse, error) {
+	run, err := s.Repo.FindRun(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ReconciliationResponse{
+		ResponseCode:    snap.Internal.SuccessCode(),
+		ResponseMessage: "Success",
+		Data:            run,
+	}, nil
+}
+
+// settledStatus mengecek apakah status di file berarti sudah dibayar. Baris tanpa status
+// tidak pernah dianggap settled, jadi koreksi hanya terjadi jika bank menyatakan dibayar.
+func (s *ReconciliationService) settledStatus(status string) bool {
+	if status == "" {
+		return false
+	}
+	internal, ok := s.Transactions.StatusMapper.Map(util.DefaultGateway, status)
+	return ok && internal == util.StatusPaid
+}
+
+// settlementPeriod menentukan rentang [start, end) dalam WIB dari parameter date,
+// atau dari paid_time paling awal dan paling akhir di file.
+func settlementPeriod(date string, records []settlement.Record) (time.Time, time.Time, error) {
+	if date != "" {
+		start, err := time.ParseInLocation("2006-01-02", date, util.WIB)
+		if err != nil {
+			return time.Time{}, time.Time{}, apperror.New(apperror.InvalidFieldFormat, "invalid date format, use YYYY-MM-DD")
+		}
+		return start, start.AddDate(0, 0, 1), nil
+	}
+
+	var first, last time.Time
+	for _, record := range records {
+		if record.PaidTime == nil {
+			continue
+		}
+		if first.IsZero() || record.PaidTime.Before(first) {
+			first = *record.PaidTime
+		}
+		if last.IsZero() || record.PaidTime.After(last) {
+			last = *record.PaidTime
+		}
+	}
+	if first.IsZero() {
+		return time.Time{}, time.Time{}, nil
+	}
+	return util.StartOfDay(first), util.StartOfDay(last).AddDate(0, 0, 1), nil
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"qr-service/internal/model"
+	"qr-service/internal/repository"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newReconciliationService ReconciliationService dengan laporan di SQLite dan transaksi di memori:
+// A001 dan A002 PAID pada 21-09-2025, A003 (15000), A004 (5000) dan A010-A013 (10000) PENDING
+func newReconciliationService(t *testing.T) (*ReconciliationService, repository.TransactionStore) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "reconciliation.db")), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&model.ReconciliationRun{}, &model.ReconciliationItem{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	store := repository.NewMemoryTransactionStore()
+	paidDate := time.Date(2025, 9, 21, 10, 0, 0, 0, time.FixedZone("WIB", 7*3600))
+	seed := []struct {
+		ref    string
+		amount float64
+		status string
+	}{
+		{"A001", 10000, "PAID"}, {"A002", 20000, "PAID"}, {"A003", 15000, "PENDING"}, {"A004", 5000, "PENDING"},
+		{"A010", 10000, "PENDING"}, {"A011", 10000, "PENDING"}, {"A012", 10000, "PENDING"}, {"A013", 10000, "PENDING"},
+	}
+	for _, trx := range seed {
+		saved := model.Transaction{
+			MerchantID: "M001", Amount: trx.amount, TrxID: "TRX-" + trx.ref, PartnerReferenceNo: "P" + trx.ref, ReferenceNo: trx.ref,
+			Status: trx.status, TransactionDate: paidDate,
+		}
+		if trx.status == "PAID" {
+			saved.PaidDate = &paidDate
+		}
+		if _, err := store.Save(saved); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+
+	transactions := NewTransactionService(store, nil, nil, nil, nil)
+	return NewReconciliationService(repository.NewReconciliationRepository(db), transactions), store
+}
+
+// reconciliationItems "REF:CATEGORY" per item, urut
+func reconciliationItems(run model.ReconciliationRun) []string {
+	items := make([]string, 0, len(run.Items))
+	for _, item := range run.Items {
+		items = append(items, item.ReferenceNo+":"+item.Category)
+	}
+	slices.Sort(items)
+	return items
+}
+
+func TestReconcileCategories(t *testing.T) {
+	const header = "reference_no,amount,currency,status,paid_time\n"
+	const paid = "A001,10000.00,IDR,SUCCESS,2025-09-21T10:00:00+07:00\nA002,20000.00,IDR,SUCCESS,2025-09-21T10:00:00+07:00\n"
+
+	cases := []struct {
+		name string
+		file string
+		want []string
+	}{
+		{"matched", header + paid, []string{"A001:MATCHED", "A002:MATCHED"}},
+		{"missing on our side", header + paid + "A999,1000.00,IDR,SUCCESS,\n",
+			[]string{"A001:MATCHED", "A002:MATCHED", "A999:MISSING_ON_OUR_SIDE"}},
+		{"missing on their side", header + "A001,10000.00,IDR,SUCCESS,\n",
+			[]string{"A001:MATCHED", "A002:MISSING_ON_THEIR_SIDE"}},
+		{"amount mismatch beyond rounding", header + paid + "A003,15500.00,IDR,SUCCESS,\nA004,5000.004,IDR,SUCCESS,\n",
+			[]string{"A001:MATCHED", "A002:MATCHED", "A003:AMOUNT_MISMATCH", "A004:MATCHED"}},
+		{"duplicate line counted once", header + paid + "A001,10000.00,IDR,SUCCESS,\n",
+			[]string{"A001:DUPLICATE", "A001:MATCHED", "A002:MATCHED"}},
+		{"currency compared when present", header + "A001,10000.00,idr,SUCCESS,\nA002,20000.00,,SUCCESS,\nA004,5000.00,USD,SUCCESS,\n",
+			[]string{"A001:MATCHED", "A002:MATCHED", "A004:CURRENCY_MISMATCH"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, _ := newReconciliationService(t)
+			resp, err := s.Reconcile(context.Background(), strings.NewReader(tc.file), model.ReconcileRequest{Date: "2025-09-21"})
+			if err != nil {
+				t.Fatalf("Reconcile returned error: %v", err)
+			}
+			run := resp.Data
+			if got := reconciliationItems(run); !slices.Equal(got, tc.want) {
+				t.Fatalf("items = %v, want %v", got, tc.want)
+			}
+
+			// Counter run sama dengan jumlah item per kategori
+			count := map[string]int{}
+			for _, item := range run.Items {
+				count[item.Category]++
+			}
+			counters := map[string]int{
+				model.ReconMatched: run.Matched, model.ReconMissingOurs: run.MissingOurs, model.ReconMissingTheirs: run.MissingTheirs,
+				model.ReconAmountMismatch: run.AmountMismatch, model.ReconCurrencyMismatch: run.CurrencyMismatch, model.ReconDuplicate: run.Duplicate,
+			}
+			for category, got := range counters {
+				if got != count[category] {
+					t.Errorf("%s counter = %d, want %d", category, got, count[category])
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileCorrectsOnlySettledStatus(t *testing.T) {
+	file := "reference_no,amount,status,paid_time\n" +
+		"A010,10000.00,SUCCESS,2025-09-21T11:00:00+07:00\n" +
+		"A010,10000.00,SUCCESS,2025-09-21T11:00:00+07:00\n" +
+		"A011,10000.00,,2025-09-21T11:00:00+07:00\n" +
+		"A012,10000.00,PENDING,2025-09-21T11:00:00+07:00\n" +
+		"A013,10000.00,FAILED,2025-09-21T11:00:00+07:00\n" +
+		"A003,15500.00,SUCCESS,2025-09-21T11:00:00+07:00\n"
+
+	s, store := newReconciliationService(t)
+	if _, err := s.Reconcile(context.Background(), strings.NewReader(file), model.ReconcileRequest{}); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	if got, _ := store.FindByReferenceNo("A010"); got.Status != "PENDING" {
+		t.Fatalf("A010 status without correct = %s, want PENDING", got.Status)
+	}
+
+	s, store = newReconciliationService(t)
+	resp, err := s.Reconcile(context.Background(), strings.NewReader(file), model.ReconcileRequest{Correct: true})
+	if err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	if resp.Data.Corrected != 1 {
+		t.Fatalf("corrected = %d, want 1 (A010 only)", resp.Data.Corrected)
+	}
+	want := map[string]string{"A010": "PAID", "A011": "PENDING", "A012": "PENDING", "A013": "PENDING", "A003": "PENDING"}
+	for ref, status := range want {
+		if got, _ := store.FindByReferenceNo(ref); got.Status != status {
+			t.Errorf("%s status = %s, want %s", ref, got.Status, status)
+		}
+	}
+	for _, item := range resp.Data.Items {
+		if item.Corrected != (item.ReferenceNo == "A010" && item.Category == model.ReconMatched) {
+			t.Errorf("item %s %s corrected = %t", item.ReferenceNo, item.Category, item.Corrected)
+		}
+	}
+}
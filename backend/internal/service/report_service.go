@@ -0,0 +1,478 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"math"
+	"qr-service/internal/model"
+	"qr-service/internal/repository"
+	"qr-service/pkg/apperror"
+	"qr-service/pkg/health"
+	"qr-service/pkg/mail"
+	"qr-service/pkg/snap"
+	"qr-service/pkg/util"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Jumlah jam tersibuk yang ditampilkan di laporan
+const reportTopHours = 3
+
+type ReportService struct {
+	Repo         *repository.ReportRepository
+	Transactions repository.TransactionStore
+	Merchants    *repository.MerchantRepository
+	Mail         mail.Sender // nil = laporan tidak dikirim via email
+}
+
+func NewReportService(repo *repository.ReportRepository, transactions repository.TransactionStore, merchants *repository.MerchantRepository, sender mail.Sender) *ReportService {
+	return &ReportService{Repo: repo, Transactions: transactions, Merchants: merchants, Mail: sender}
+}
+
+// Implementasi Endpoint POST /api/v1/reports/daily/run
+// Membuat (atau membuat ulang) laporan harian per merchant untuk satu tanggal WIB.
+func (s *ReportService) GenerateDailyReports(req model.RunDailyReportsRequest) (*model.DailyReportsResponse, error) {
+	return s.generateDailyReports(req, nil)
+}
+
+// generateDailyReports memperbarui heartbeat (boleh nil) sebelum setiap merchant, sehingga
+// scheduler tetap dianggap hidup selama run berjalan dan baru dianggap macet jika satu merchant tertahan
+func (s *ReportService) generateDailyReports(req model.RunDailyReportsRequest, heartbeat *health.Heartbeat) (*model.DailyReportsResponse, error) {
+	// 1. Tentukan tanggal, default kemarin
+	day := util.StartOfDay(time.Now()).AddDate(0, 0, -1)
+	if req.Date != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", req.Date, util.WIB)
+		if err != nil {
+			return nil, apperror.New(apperror.InvalidFieldFormat, "invalid date format, use YYYY-MM-DD")
+		}
+		day = parsed
+	}
+
+	// 2. Merchant yang direkap: yang diminta, atau semua yang bertransaksi di tanggal tersebut
+	merchantIDs := []string{req.MerchantID}
+	if req.MerchantID == "" {
+		var err error
+		merchantIDs, err = s.Transactions.MerchantIDsBetween(day, day.AddDate(0, 0, 1))
+		if err != nil {
+			return nil, apperror.Wrap(err, apperror.Internal, "failed to load merchants")
+		}
+	}
+
+	// 3. Bangun, simpan, lalu kirim email per merchant. Kegagalan satu merchant dicatat
+	// dan tidak menghentikan merchant lain.
+	reports := []model.DailyReport{}
+	var failed []model.DailyReportFailure
+	var errs []error
+	for _, merchantID := range merchantIDs {
+		heartbeat.Beat()
+		report, err := s.generateDailyReport(merchantID, day, req.SendEmail)
+		if err != nil {
+			slog.Error("Failed to generate daily report", "merchant_id", merchantID, "date", day.Format("2006-01-02"), "error", err)
+			failed = append(failed, model.DailyReportFailure{MerchantID: merchantID, Error: err.Error()})
+			errs = append(errs, fmt.Errorf("%s: %w", merchantID, err))
+			continue
+		}
+		reports = append(reports, report)
+	}
+
+	// Semua merchant gagal: tidak ada yang bisa dilaporkan sebagai hasil
+	if len(reports) == 0 && len(errs) > 0 {
+		return nil, apperror.Wrap(errors.Join(errs...), apperror.Internal, "failed to generate daily reports")
+	}
+
+	message := "Success"
+	if len(failed) > 0 {
+		message = "Partial Success"
+	}
+	return &model.DailyReportsResponse{
+		ResponseCode:    snap.Internal.SuccessCode(),
+		ResponseMessage: message,
+		Data:            reports,
+		Failed:          failed,
+	}, nil
+}
+
+// generateDailyReport membangun dan menyimpan laporan satu merchant, lalu mengirim email jika diminta.
+// Kegagalan email dicatat di laporan, bukan dikembalikan sebagai error.
+func (s *ReportService) generateDailyReport(merchantID string, day time.Time, sendEmail bool) (model.DailyReport, error) {
+	report, err := s.BuildDailyReport(merchantID, day)
+	if err != nil {
+		return model.DailyReport{}, fmt.Errorf("build report: %w", err)
+	}
+	report, err = s.Repo.SaveDailyReport(report)
+	if err != nil {
+		return model.DailyReport{}, fmt.Errorf("save report: %w", err)
+	}
+	if sendEmail {
+		report = s.emailReport(report)
+	}
+	return report, nil
+}
+
+// BuildDailyReport menghitung rekap satu merchant untuk hari day (00:00 WIB) dan me-render HTML/CSV-nya
+func (s *ReportService) BuildDailyReport(merchantID string, day time.Time) (model.DailyReport, error) {
+	aggregates, err := s.Transactions.GetStats(model.TransactionStatsQuery{
+		Filter:   model.TransactionFilter{MerchantID: merchantID, CreatedFrom: day, CreatedTo: day.AddDate(0, 0, 1)},
+		Origin:   day,
+		Interval: time.Hour,
+	})
+	if err != nil {
+		return model.DailyReport{}, err
+	}
+
+	report := model.DailyReport{MerchantID: merchantID, ReportDate: day.Format("2006-01-02")}
+	for _, stat := range aggregates.ByStatus {
+		report.TotalCount += stat.Count
+		report.TotalAmount += stat.Amount
+		switch stat.Status {
+		case "PAID":
+			report.PaidCount, report.PaidAmount = stat.Count, stat.Amount
+		case "FAILED":
+			report.FailedCount, report.FailedAmount = stat.Count, stat.Amount
+		case "EXPIRED":
+			report.ExpiredCount, report.ExpiredAmount = stat.Count, stat.Amount
+		case "PENDING":
+			report.PendingCount, report.PendingAmount = stat.Count, stat.Amount
+		}
+	}
+	if report.TotalCount > 0 {
+		report.SuccessRate = float64(report.PaidCount) / float64(report.TotalCount)
+	}
+
+	hourly := make([]model.HourStat, 24)
+	for hour := range hourly {
+		hourly[hour].Hour = hour
+	}
+	for _, bucket := range aggregates.Series {
+		hour := bucket.Start.In(util.WIB).Hour()
+		hourly[hour] = model.HourStat{
+			Hour:       hour,
+			Count:      bucket.Count,
+			Amount:     bucket.Amount,
+			PaidCount:  bucket.PaidCount,
+			PaidAmount: bucket.PaidAmount,
+		}
+	}
+	report.TopHours = topHours(hourly, reportTopHours)
+
+	if report.HTML, err = renderDailyReportHTML(report, hourly); err != nil {
+		return model.DailyReport{}, err
+	}
+	if report.CSV, err = renderDailyReportCSV(report, hourly); err != nil {
+		return model.DailyReport{}, err
+	}
+	return report, nil
+}
+
+// topHours jam dengan nominal PAID terbesar (lalu jumlah transaksi terbanyak), jam tanpa transaksi dilewati
+func topHours(hourly []model.HourStat, n int) []model.HourStat {
+	top := []model.HourStat{}
+	for _, stat := range hourly {
+		if stat.Count > 0 {
+			top = append(top, stat)
+		}
+	}
+	sort.SliceStable(top, func(i, j int) bool {
+		if top[i].PaidAmount != top[j].PaidAmount {
+			return top[i].PaidAmount > top[j].PaidAmount
+		}
+		return top[i].Count > top[j].Count
+	})
+	if len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
+
+// emailReport mengirim laporan ke email merchant dan mencatat hasilnya di laporan
+func (s *ReportService) emailReport(report model.DailyReport) model.DailyReport {
+	if s.Mail == nil || s.Merchants == nil {
+		return report
+	}
+	merchant, err := s.Merchants.FindMerchant(report.MerchantID)
+	if err != nil || merchant == nil || merchant.ReportEmail == "" {
+		return report
+	}
+
+	now := time.Now()
+	err = s.Mail.Send(mail.Message{
+		To:      []string{merchant.ReportEmail},
+		Subject: fmt.Sprintf("Laporan Harian %s - %s", report.MerchantID, report.ReportDate),
+		HTML:    report.HTML,
+		Attachments: []mail.Attachment{{
+			Filename:    reportFilename(report, model.ReportFormatCSV),
+			ContentType: "text/csv",
+			Data:        []byte(report.CSV),
+		}},
+	})
+
+	report.EmailedTo, report.EmailError = merchant.ReportEmail, ""
+	if err != nil {
+		slog.Error("Failed to email daily report", "merchant_id", report.MerchantID, "report_date", report.ReportDate, "error", err)
+		report.EmailError = err.Error()
+	} else {
+		report.EmailedAt = &now
+	}
+	if markErr := s.Repo.MarkEmailed(report.ID, report.EmailedTo, now, report.EmailError); markErr != nil {
+		slog.Error("Failed to record report email status", "report_id", report.ID, "error", markErr)
+	}
+	return report
+}
+
+// Implementasi Endpoint GET /api/v1/reports/daily
+func (s *ReportService) GetDailyReports(req model.GetDailyReportsRequest) (*model.DailyReportsResponse, error) {
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+	for _, date := range []string{req.StartDate, req.EndDate} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return nil, apperror.New(apperror.InvalidFieldFormat, "invalid date format, use YYYY-MM-DD")
+		}
+	}
+
+	reports, total, err := s.Repo.GetDailyReports(req.MerchantID, req.StartDate, req.EndDate, req.Page, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.DailyReportsResponse{
+		ResponseCode:    snap.Internal.SuccessCode(),
+		ResponseMessage: "Success",
+		Data:            reports,
+		Pagination: &model.PaginationInfo{
+			Page:      req.Page,
+			Limit:     req.Limit,
+			Total:     int(total),
+			TotalPage: int((total + int64(req.Limit) - 1) / int64(req.Limit)),
+		},
+	}, nil
+}
+
+// Implementasi Endpoint GET /api/v1/reports/daily/:id
+// merchantID tidak kosong membatasi ke laporan merchant tersebut.
+func (s *ReportService) GetDailyReport(id uint, merchantID string) (*model.DailyReportResponse, error) {
+	report, err := s.findDailyReport(id, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.DailyReportResponse{
+		ResponseCode:    snap.Internal.SuccessCode(),
+		ResponseMessage: "Success",
+		Data:            report,
+	}, nil
+}
+
+// Implementasi Endpoint GET /api/v1/reports/daily/:id/download
+// Mengembalikan isi file, content type dan nama file. merchantID tidak kosong membatasi ke
+// laporan merchant tersebut.
+func (s *ReportService) DownloadDailyReport(id uint, format string, merchantID string) ([]byte, string, string, error) {
+	if format == "" {
+		format = model.ReportFormatHTML
+	}
+	if format != model.ReportFormatHTML && format != model.ReportFormatCSV {
+		return nil, "", "", apperror.Newf(apperror.InvalidFieldFormat, "invalid format: %s, use html or csv", format)
+	}
+
+	report, err := s.findDailyReport(id, merchantID)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if format == model.ReportFormatCSV {
+		return []byte(report.CSV), "text/csv; charset=utf-8", reportFilename(report, format), nil
+	}
+	return []byte(report.HTML), "text/html; charset=utf-8", reportFilename(report, format), nil
+}
+
+// findDailyReport laporan berdasarkan ID; laporan merchant lain dilaporkan tidak ditemukan
+// agar ID laporan tidak bisa ditebak
+func (s *ReportService) findDailyReport(id uint, merchantID string) (model.DailyReport, error) {
+	report, err := s.Repo.FindDailyReportByID(id)
+	if err != nil {
+		return model.DailyReport{}, err
+	}
+	if merchantID != "" && report.MerchantID != merchantID {
+		return model.DailyReport{}, apperror.New(apperror.NotFound, "report not found")
+	}
+	return report, nil
+}
+
+func reportFilename(report model.DailyReport, format string) string {
+	return fmt.Sprintf("daily_report_%s_%s.%s", report.MerchantID, report.ReportDate, format)
+}
+
+const (
+	// ReportHeartbeatInterval seberapa sering scheduler laporan mengirim heartbeat saat menunggu jadwal
+	ReportHeartbeatInterval = 30 * time.Second
+	// ReportHeartbeatMaxAge batas heartbeat scheduler; selama run heartbeat diperbarui per merchant,
+	// jadi batas ini memberi ruang untuk laporan dan pengiriman email satu merchant
+	ReportHeartbeatMaxAge = 5 * time.Minute
+)
+
+// RunScheduler membuat laporan hari sebelumnya setiap hari pada runAt setelah tengah malam WIB,
+// sampai ctx dibatalkan. Email dikirim jika Mail dikonfigurasi. Heartbeat (boleh nil) untuk probe
+// readiness diperbarui setiap ReportHeartbeatInterval selama menunggu jadwal dan per merchant selama run.
+func (s *ReportService) RunScheduler(ctx context.Context, runAt time.Duration, heartbeat *health.Heartbeat) {
+	ticker := time.NewTicker(ReportHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		heartbeat.Beat()
+		next := nextReportRun(time.Now(), runAt)
+		slog.Info("Next daily report run scheduled", "run_at", next.Format(time.RFC3339))
+
+		timer := time.NewTimer(time.Until(next))
+	wait:
+		for {
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-ticker.C:
+				heartbeat.Beat()
+			case <-timer.C:
+				break wait
+			}
+		}
+
+		date := util.StartOfDay(next).AddDate(0, 0, -1).Format("2006-01-02")
+		resp, err := s.generateDailyReports(model.RunDailyReportsRequest{Date: date, SendEmail: s.Mail != nil}, heartbeat)
+		if err != nil {
+			slog.Error("Daily report run failed", "date", date, "error", err)
+			continue
+		}
+		slog.Info("Daily reports generated", "date", date, "merchants", len(resp.Data), "failed", len(resp.Failed))
+	}
+}
+
+// nextReportRun jadwal berikutnya (tengah malam WIB + runAt) yang masih setelah now
+func nextReportRun(now time.Time, runAt time.Duration) time.Time {
+	next := util.StartOfDay(now).Add(runAt)
+	if !next.After(now) {
+		next = util.StartOfDay(now).AddDate(0, 0, 1).Add(runAt)
+	}
+	return next
+}
+
+var dailyReportTemplate = template.Must(template.New("daily_report").Funcs(template.FuncMap{
+	"rupiah":  formatRupiah,
+	"percent": func(rate float64) string { return strconv.FormatFloat(rate*100, 'f', 1, 64) + "%" },
+	"hour":    func(hour int) string { return fmt.Sprintf("%02d:00 - %02d:59", hour, hour) },
+}).Parse(`<!DOCTYPE html>
+<html lang="id">
+<head>
+<meta charset="utf-8">
+<title>Laporan Harian {{.Report.MerchantID}} - {{.Report.ReportDate}}</title>
+<style>
+body { font-family: Arial, sans-serif; color: #222; }
+table { border-collapse: collapse; margin-bottom: 24px; }
+th, td { border: 1px solid #ccc; padding: 6px 12px; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+th { background: #f3f3f3; }
+</style>
+</head>
+<body>
+<h2>Laporan Harian Transaksi QRIS</h2>
+<p>Merchant: <strong>{{.Report.MerchantID}}</strong><br>Tanggal: <strong>{{.Report.ReportDate}} (WIB)</strong></p>
+
+<h3>Ringkasan</h3>
+<table>
+<tr><th>Status</th><th>Jumlah</th><th>Nominal</th></tr>
+<tr><td>Dibayar (PAID)</td><td>{{.Report.PaidCount}}</td><td>{{rupiah .Report.PaidAmount}}</td></tr>
+<tr><td>Gagal (FAILED)</td><td>{{.Report.FailedCount}}</td><td>{{rupiah .Report.FailedAmount}}</td></tr>
+<tr><td>Kedaluwarsa (EXPIRED)</td><td>{{.Report.ExpiredCount}}</td><td>{{rupiah .Report.ExpiredAmount}}</td></tr>
+<tr><td>Menunggu (PENDING)</td><td>{{.Report.PendingCount}}</td><td>{{rupiah .Report.PendingAmount}}</td></tr>
+<tr><th>Total</th><th>{{.Report.TotalCount}}</th><th>{{rupiah .Report.TotalAmount}}</th></tr>
+</table>
+<p>Tingkat keberhasilan: <strong>{{percent .Report.SuccessRate}}</strong></p>
+
+<h3>Jam Tersibuk</h3>
+{{if .Report.TopHours}}<table>
+<tr><th>Jam</th><th>Transaksi</th><th>Dibayar</th><th>Nominal Dibayar</th></tr>
+{{range .Report.TopHours}}<tr><td>{{hour .Hour}}</td><td>{{.Count}}</td><td>{{.PaidCount}}</td><td>{{rupiah .PaidAmount}}</td></tr>
+{{end}}</table>{{else}}<p>Tidak ada transaksi.</p>{{end}}
+
+<h3>Per Jam</h3>
+<table>
+<tr><th>Jam</th><th>Transaksi</th><th>Nominal</th><th>Dibayar</th><th>Nominal Dibayar</th></tr>
+{{range .Hourly}}<tr><td>{{hour .Hour}}</td><td>{{.Count}}</td><td>{{rupiah .Amount}}</td><td>{{.PaidCount}}</td><td>{{rupiah .PaidAmount}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+func renderDailyReportHTML(report model.DailyReport, hourly []model.HourStat) (string, error) {
+	var buf bytes.Buffer
+	err := dailyReportTemplate.Execute(&buf, struct {
+		Report model.DailyReport
+		Hourly []model.HourStat
+	}{report, hourly})
+	return buf.String(), err
+}
+
+// renderDailyReportCSV ringkasan per status lalu rincian per jam dalam satu file
+func renderDailyReportCSV(report model.DailyReport, hourly []model.HourStat) (string, error) {
+	amount := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	count := func(v int64) string { return strconv.FormatInt(v, 10) }
+
+	records := [][]string{
+		{"merchant_id", report.MerchantID},
+		{"report_date", report.ReportDate},
+		{},
+		{"status", "count", "amount"},
+		{"PAID", count(report.PaidCount), amount(report.PaidAmount)},
+		{"FAILED", count(report.FailedCount), amount(report.FailedAmount)},
+		{"EXPIRED", count(report.ExpiredCount), amount(report.ExpiredAmount)},
+		{"PENDING", count(report.PendingCount), amount(report.PendingAmount)},
+		{"TOTAL", count(report.TotalCount), amount(report.TotalAmount)},
+		{"success_rate", strconv.FormatFloat(report.SuccessRate, 'f', 4, 64)},
+		{},
+		{"hour", "count", "amount", "paid_count", "paid_amount"},
+	}
+	for _, stat := range hourly {
+		records = append(records, []string{
+			fmt.Sprintf("%02d", stat.Hour), count(stat.Count), amount(stat.Amount), count(stat.PaidCount), amount(stat.PaidAmount),
+		})
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.WriteAll(records); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// formatRupiah contoh: 1234567.5 -> "Rp 1.234.567,50"
+func formatRupiah(amount float64) string {
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	cents := int64(math.Round(amount * 100))
+	whole := strconv.FormatInt(cents/100, 10)
+
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+	return fmt.Sprintf("%sRp %s,%02d", sign, grouped.String(), cents%100)
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"qr-service/internal/model"
+	"qr-service/internal/repository"
+	"qr-service/pkg/apperror"
+	"qr-service/pkg/health"
+	"qr-service/pkg/util"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestFormatRupiah(t *testing.T) {
+	cases := map[float64]string{
+		0:         "Rp 0,00",
+		999:       "Rp 999,00",
+		1000:      "Rp 1.000,00",
+		1234567.5: "Rp 1.234.567,50",
+		-25000:    "-Rp 25.000,00",
+	}
+	for amount, want := range cases {
+		if got := formatRupiah(amount); got != want {
+			t.Errorf("formatRupiah(%v) = %q, want %q", amount, got, want)
+		}
+	}
+}
+
+func TestNextReportRun(t *testing.T) {
+	runAt := 5 * time.Minute
+	before := time.Date(2025, 9, 22, 0, 1, 0, 0, util.WIB)
+	if got := nextReportRun(before, runAt); !got.Equal(time.Date(2025, 9, 22, 0, 5, 0, 0, util.WIB)) {
+		t.Fatalf("next run before runAt = %v", got)
+	}
+	after := time.Date(2025, 9, 22, 0, 5, 0, 0, util.WIB)
+	if got := nextReportRun(after, runAt); !got.Equal(time.Date(2025, 9, 23, 0, 5, 0, 0, util.WIB)) {
+		t.Fatalf("next run at runAt = %v", got)
+	}
+}
+
+func TestBuildDailyReport(t *testing.T) {
+	store := repository.NewMemoryTransactionStore()
+	s := NewReportService(nil, store, nil, nil)
+
+	// 21/09/2025 WIB: dua transaksi jam 09, satu jam 14, satu di hari berikutnya (tidak ikut)
+	seed := []struct {
+		at     time.Time
+		amount float64
+		status string
+	}{
+		{time.Date(2025, 9, 21, 9, 10, 0, 0, util.WIB), 10000, "PAID"},
+		{time.Date(2025, 9, 21, 9, 40, 0, 0, util.WIB), 20000, "FAILED"},
+		{time.Date(2025, 9, 21, 14, 0, 0, 0, util.WIB), 50000, "PAID"},
+		{time.Date(2025, 9, 22, 1, 0, 0, 0, util.WIB), 99000, "PAID"},
+	}
+	for i, item := range seed {
+		ref := fmt.Sprintf("R%03d", i+1)
+		trx := model.Transaction{MerchantID: "M001", Amount: item.amount, TrxID: "TRX-" + ref, PartnerReferenceNo: "P" + ref, ReferenceNo: ref}
+		trx.CreatedAt = item.at.UTC()
+		if _, err := store.Save(trx); err != nil {
+			t.Fatalf("Save returned error: %v", err)
+		}
+		if err := store.UpdateStatus(ref, item.status, item.at.Add(time.Minute).UTC()); err != nil {
+			t.Fatalf("UpdateStatus returned error: %v", err)
+		}
+	}
+
+	report, err := s.BuildDailyReport("M001", time.Date(2025, 9, 21, 0, 0, 0, 0, util.WIB))
+	if err != nil {
+		t.Fatalf("BuildDailyReport returned error: %v", err)
+	}
+	if report.ReportDate != "2025-09-21" || report.TotalCount != 3 || report.PaidCount != 2 ||
+		report.PaidAmount != 60000 || report.FailedCount != 1 || report.FailedAmount != 20000 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if len(report.TopHours) != 2 || report.TopHours[0].Hour != 14 || report.TopHours[1].Hour != 9 || report.TopHours[1].Count != 2 {
+		t.Fatalf("unexpected top hours: %+v", report.TopHours)
+	}
+	if !strings.Contains(report.CSV, "PAID,2,60000.00\n") || !strings.Contains(report.CSV, "09,2,30000.00,1,10000.00\n") {
+		t.Fatalf("unexpected csv:\n%s", report.CSV)
+	}
+	if !strings.Contains(report.HTML, "Rp 60.000,00") {
+		t.Fatalf("html does not contain paid amount:\n%s", report.HTML)
+	}
+}
+
+func TestGenerateDailyReportsContinuesAfterMerchantFailure(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "report.db")), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&model.DailyReport{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	// Simpan laporan M002 selalu gagal
+	if err := db.Exec(`CREATE TRIGGER fail_m002 BEFORE INSERT ON daily_reports WHEN NEW.merchant_id = 'M002'
+		BEGIN SELECT RAISE(ABORT, 'disk full'); END`).Error; err != nil {
+		t.Fatalf("create trigger: %v", err)
+	}
+
+	store := repository.NewMemoryTransactionStore()
+	day := time.Date(2025, 9, 21, 0, 0, 0, 0, util.WIB)
+	for i, merchantID := range []string{"M001", "M002", "M003"} {
+		ref := fmt.Sprintf("R%03d", i+1)
+		trx := model.Transaction{MerchantID: merchantID, Amount: 10000, TrxID: "TRX-" + ref, PartnerReferenceNo: "P" + ref, ReferenceNo: ref, Status: "PAID"}
+		trx.CreatedAt = day.Add(10 * time.Hour).UTC()
+		if _, err := store.Save(trx); err != nil {
+			t.Fatalf("Save returned error: %v", err)
+		}
+	}
+	s := NewReportService(repository.NewReportRepository(db), store, nil, nil)
+
+	// Heartbeat scheduler ikut diperbarui selama run
+	heartbeat := health.NewHeartbeat(ReportHeartbeatMaxAge)
+	started := heartbeat.Last()
+	resp, err := s.generateDailyReports(model.RunDailyReportsRequest{Date: "2025-09-21"}, heartbeat)
+	if err != nil {
+		t.Fatalf("GenerateDailyReports returned error: %v", err)
+	}
+	if !heartbeat.Last().After(started) {
+		t.Fatal("heartbeat not updated while generating reports")
+	}
+	if len(resp.Data) != 2 || resp.Data[0].MerchantID != "M001" || resp.Data[1].MerchantID != "M003" {
+		t.Fatalf("reports = %+v, want M001 and M003", resp.Data)
+	}
+	if len(resp.Failed) != 1 || resp.Failed[0].MerchantID != "M002" || !strings.Contains(resp.Failed[0].Error, "disk full") {
+		t.Fatalf("failed = %+v, want M002", resp.Failed)
+	}
+	if resp.ResponseMessage != "Partial Success" {
+		t.Fatalf("message = %q, want Partial Success", resp.ResponseMessage)
+	}
+
+	// Laporan merchant lain tidak bisa dibaca dengan scope merchant
+	if _, _, _, err := s.DownloadDailyReport(resp.Data[0].ID, "csv", "M003"); apperror.CodeOf(err) != apperror.NotFound {
+		t.Fatalf("download M001 report as M003 = %v, want NOT_FOUND", err)
+	}
+	if _, _, _, err := s.DownloadDailyReport(resp.Data[0].ID, "csv", "M001"); err != nil {
+		t.Fatalf("download own report returned error: %v", err)
+	}
+
+	// Semua merchant gagal: error
+	if _, err := s.GenerateDailyReports(model.RunDailyReportsRequest{Date: "2025-09-21", MerchantID: "M002"}); err == nil {
+		t.Fatal("GenerateDailyReports succeeded although every merchant failed")
+	}
+}
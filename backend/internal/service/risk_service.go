@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"qr-service/internal/model"
+	"qr-service/internal/repository"
+	"qr-service/pkg/apperror"
+	"qr-service/pkg/snap"
+	"qr-service/pkg/util"
+	"time"
+)
+
+// RiskInput data transaksi yang dievaluasi sebelum disimpan
+type RiskInput struct {
+	MerchantID         string
+	OutletID           string
+	TerminalID         string
+	PartnerReferenceNo string
+	Amount             float64
+	At                 time.Time
+}
+
+// riskServiceCode service code SNAP untuk response code rule hit; rule hanya dijalankan saat generate QR
+const riskServiceCode = "47"
+
+// RiskViolation hasil rule yang menolak transaksi. Code menentukan HTTP status dan
+// response code SNAP, Message pesan standar SNAP untuk client.
+type RiskViolation struct {
+	Rule    string
+	Code    apperror.Code
+	Message string
+	Detail  string
+}
+
+func (v *RiskViolation) Error() string {
+	return v.Rule + ": " + v.Detail
+}
+
+// RiskRule satu aturan risiko. Mengembalikan nil jika transaksi lolos. repo berada di dalam
+// database transaction yang memegang lock merchant.
+type RiskRule interface {
+	Name() string
+	Evaluate(repo *repository.RiskRepository, limit model.RiskLimit, in RiskInput) (*RiskViolation, error)
+}
+
+type RiskService struct {
+	Repo  *repository.RiskRepository
+	Rules []RiskRule
+}
+
+func NewRiskService(repo *repository.RiskRepository) *RiskService {
+	return &RiskService{
+		Repo: repo,
+		Rules: []RiskRule{
+			amountRule{},
+			volumeRule{period: "daily"},
+			volumeRule{period: "monthly"},
+			velocityRule{column: "merchant_id"},
+			velocityRule{column: "terminal_id"},
+		},
+	}
+}
+
+// Enforce menjalankan semua rule terhadap transaksi lalu memanggil save dengan store yang ikut
+// database transaction yang sama (store.WithTx). Lock merchant dipegang dari hitung volume/velocity
+// sampai transaksi tersimpan, jadi request paralel tidak bisa sama-sama lolos limit. Setiap hit dicatat;
+// violation pertama dikembalikan (save tidak dipanggil) kecuali limit merchant dalam mode monitor.
+// Error dari save dikembalikan apa adanya.
+func (s *RiskService) Enforce(ctx context.Context, in RiskInput, store repository.TransactionStore, save func(repository.TransactionStore) error) error {
+	var (
+		limit      *model.RiskLimit
+		violations []*RiskViolation
+	)
+	err := s.Repo.LockMerchant(ctx, in.MerchantID, func(tx *repository.RiskRepository) error {
+		var err error
+		limit, err = tx.FindLimit(in.MerchantID)
+		if err != nil {
+			return apperror.Wrap(err, apperror.Internal, "failed to load risk limit")
+		}
+		if limit != nil {
+			violations, err = s.evaluate(tx, *limit, in)
+			if err != nil {
+				return err
+			}
+			if len(violations) > 0 && !limit.MonitorOnly {
+				first := violations[0]
+				return apperror.Wrap(first, first.Code, first.Message)
+			}
+		}
+		return save(store.WithTx(tx.DB))
+	})
+
+	// Hit dicatat di luar transaction agar tetap tersimpan walaupun transaksi ditolak
+	for _, violation := range violations {
+		s.recordHit(ctx, *limit, in, violation)
+	}
+	return err
+}
+
+func (s *RiskService) evaluate(repo *repository.RiskRepository, limit model.RiskLimit, in RiskInput) ([]*RiskViolation, error) {
+	var violations []*RiskViolation
+	for _, rule := range s.Rules {
+		violation, err := rule.Evaluate(repo, limit, in)
+		if err != nil {
+			return nil, apperror.Wrap(err, apperror.Internal, fmt.Sprintf("failed to evaluate risk rule %s", rule.Name()))
+		}
+		if violation != nil {
+			violations = append(violations, violation)
+		}
+	}
+	return violations, nil
+}
+
+func (s *RiskService) recordHit(ctx context.Context, limit model.RiskLimit, in RiskInput, violation *RiskViolation) {
+	err := s.Repo.SaveHit(model.RiskRuleHit{
+		MerchantID:         in.MerchantID,
+		OutletID:           in.OutletID,
+		TerminalID:         in.TerminalID,
+		PartnerReferenceNo: in.PartnerReferenceNo,
+		Amount:             in.Amount,
+		Rule:               violation.Rule,
+		ResponseCode:       violation.Code.ResponseCode(riskServiceCode),
+		Detail:             violation.Detail,
+		Enforced:           !limit.MonitorOnly,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to record risk rule hit", "rule", violation.Rule, "merchant_id", in.MerchantID, "error", err)
+	}
+}
+
+// Implementasi Endpoint GET /api/v1/merchants/:merchantId/limits
+func (s *RiskService) GetLimit(merchantID string) (*model.RiskLimitResponse, error) {
+	limit, err := s.Repo.FindLimit(merchantID)
+	if err != nil {
+		return nil, err
+	}
+	if limit == nil {
+		return nil, apperror.New(apperror.NotFound, "risk limit not found")
+	}
+
+	return &model.RiskLimitResponse{
+		ResponseCode:    snap.Internal.SuccessCode(),
+		ResponseMessage: "Success",
+		Data:            *limit,
+	}, nil
+}
+
+// Implementasi Endpoint PUT /api/v1/merchants/:merchantId/limits
+func (s *RiskService) SetLimit(merchantID string, req model.SetRiskLimitRequest) (*model.RiskLimitResponse, error) {
+	if req.MaxAmount > 0 && req.MinAmount > req.MaxAmount {
+		return nil, apperror.New(apperror.InvalidFieldFormat, "invalid limit: minAmount greater than maxAmount")
+	}
+
+	limit, err := s.Repo.SaveLimit(model.RiskLimit{
+		MerchantID:                    merchantID,
+		MinAmount:                     req.MinAmount,
+		MaxAmount:                     req.MaxAmount,
+		DailyVolumeCap:                req.DailyVolumeCap,
+		MonthlyVolumeCap:              req.MonthlyVolumeCap,
+		MaxGeneratesPerMinute:         req.MaxGeneratesPerMinute,
+		MaxTerminalGeneratesPerMinute: req.MaxTerminalGeneratesPerMinute,
+		MonitorOnly:                   req.MonitorOnly,
+	})
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.Internal, "failed to save risk limit")
+	}
+
+	return &model.RiskLimitResponse{
+		ResponseCode:    snap.Internal.SuccessCode(),
+		ResponseMessage: "Success",
+		Data:            limit,
+	}, nil
+}
+
+// Implementasi Endpoint GET /api/v1/risk/hits
+func (s *RiskService) GetHits(req model.GetRiskHitsRequest) (*model.GetRiskHitsResponse, error) {
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+
+	var since time.Time
+	if req.StartDate != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", req.StartDate, util.WIB)
+		if err != nil {
+			return nil, apperror.New(apperror.InvalidFieldFormat, "invalid start date format, use YYYY-MM-DD")
+		}
+		since = parsed
+	}
+
+	hits, total, err := s.Repo.GetHits(req.MerchantID, req.Rule, since, req.Page, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPage := int((total + int64(req.Limit) - 1) / int64(req.Limit))
+
+	return &model.GetRiskHitsResponse{
+		ResponseCode:    snap.Internal.SuccessCode(),
+		ResponseMessage: "Success",
+		Data:            hits,
+		Pagination: &model.PaginationInfo{
+			Page:      req.Page,
+			Limit:     req.Limit,
+			Total:     int(total),
+			TotalPage: totalPage,
+		},
+	}, nil
+}
+
+// amountRule: batas minimum dan maksimum amount per transaksi
+type amountRule struct{}
+
+func (amountRule) Name() string { return "AMOUNT_LIMIT" }
+
+func (r amountRule) Evaluate(_ *repository.RiskRepository, limit model.RiskLimit, in RiskInput) (*RiskViolation, error) {
+	if limit.MinAmount > 0 && in.Amount < limit.MinAmount {
+		return &RiskViolation{
+			Rule:    r.Name(),
+			Code:    apperror.InvalidAmount,
+			Message: "Invalid Amount",
+			Detail:  fmt.Sprintf("amount %.2f below minimum %.2f", in.Amount, limit.MinAmount),
+		}, nil
+	}
+	if limit.MaxAmount > 0 && in.Amount > limit.MaxAmount {
+		return &RiskViolation{
+			Rule:    r.Name(),
+			Code:    apperror.AmountLimitExceeded,
+			Message: "Exceeds Transaction Amount Limit",
+			Detail:  fmt.Sprintf("amount %.2f above maximum %.2f", in.Amount, limit.MaxAmount),
+		}, nil
+	}
+	return nil, nil
+}
+
+// volumeRule: total amount transaksi harian/bulanan merchant (dihitung dalam WIB) berstatus
+// repository.VolumeStatuses (PENDING dan PAID) ditambah transaksi baru
+type volumeRule struct {
+	period string // "daily" atau "monthly"
+}
+
+func (r volumeRule) Name() string {
+	if r.period == "monthly" {
+		return "MONTHLY_VOLUME_CAP"
+	}
+	return "DAILY_VOLUME_CAP"
+}
+
+func (r volumeRule) Evaluate(repo *repository.RiskRepository, limit model.RiskLimit, in RiskInput) (*RiskViolation, error) {
+	capAmount, since := limit.DailyVolumeCap, util.StartOfDay(in.At)
+	if r.period == "monthly" {
+		capAmount, since = limit.MonthlyVolumeCap, util.StartOfMonth(in.At)
+	}
+	if capAmount <= 0 {
+		return nil, nil
+	}
+
+	volume, err := repo.SumVolume(in.MerchantID, since)
+	if err != nil {
+		return nil, err
+	}
+	if volume+in.Amount > capAmount {
+		return &RiskViolation{
+			Rule:    r.Name(),
+			Code:    apperror.MerchantLimitExceeded,
+			Message: "Merchant Limit Exceed",
+			Detail:  fmt.Sprintf("%s volume %.2f + %.2f exceeds cap %.2f", r.period, volume, in.Amount, capAmount),
+		}, nil
+	}
+	return nil, nil
+}
+
+// velocityRule: jumlah generate QR per menit per merchant atau per terminal
+type velocityRule struct {
+	column string // "merchant_id" atau "terminal_id"
+}
+
+func (r velocityRule) Name() string {
+	if r.column == "terminal_id" {
+		return "TERMINAL_VELOCITY"
+	}
+	return "MERCHANT_VELOCITY"
+}
+
+func (r velocityRule) Evaluate(repo *repository.RiskRepository, limit model.RiskLimit, in RiskInput) (*RiskViolation, error) {
+	maxCount, value := limit.MaxGeneratesPerMinute, in.MerchantID
+	if r.column == "terminal_id" {
+		maxCount, value = limit.MaxTerminalGeneratesPerMinute, in.TerminalID
+	}
+	if maxCount <= 0 || value == "" {
+		return nil, nil
+	}
+
+	count, err := repo.CountGenerated(r.column, value, in.At.Add(-time.Minute))
+	if err != nil {
+		return nil, err
+	}
+	if count >= int64(maxCount) {
+		return &RiskViolation{
+			Rule:    r.Name(),
+			Code:    apperror.ActivityLimitExceeded,
+			Message: "Activity Count Limit Exceeded",
+			Detail:  fmt.Sprintf("%d generates in the last minute for %s %s (max %d)", count, r.column, value, maxCount),
+		}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"qr-service/internal/model"
+	"qr-service/internal/repository"
+	"qr-service/pkg/apperror"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestGenerateQREnforcesRiskLimitsUnderConcurrency(t *testing.T) {
+	dsn := filepath.Join(t.TempDir(), "risk.db") + "?_busy_timeout=10000"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&model.Transaction{}, &model.RiskLimit{}, &model.RiskRuleHit{}, &model.RiskMerchantLock{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	riskRepo := repository.NewRiskRepository(db)
+	if _, err := riskRepo.SaveLimit(model.RiskLimit{MerchantID: "M001", DailyVolumeCap: 50000, MaxGeneratesPerMinute: 8}); err != nil {
+		t.Fatalf("SaveLimit: %v", err)
+	}
+	// Transaksi FAILED tidak dihitung ke volume
+	if _, err := repository.NewTransactionRepository(db).Save(model.Transaction{
+		MerchantID: "M001", Amount: 40000, TrxID: "TRX-OLD", PartnerReferenceNo: "OLD", ReferenceNo: "A-OLD", Status: "FAILED",
+	}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	s := NewTransactionService(repository.NewTransactionRepository(db), nil, NewRiskService(riskRepo), nil, nil)
+
+	const requests = 20
+	errs := make([]error, requests)
+	var wg sync.WaitGroup
+	for i := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = s.GenerateQR(context.Background(), model.GenerateQRRequest{
+				PartnerReferenceNo: fmt.Sprintf("P%02d", i),
+				Amount:             model.Amount{Value: "10000.00", Currency: "IDR"},
+				MerchantID:         "M001",
+			})
+		}()
+	}
+	wg.Wait()
+
+	accepted := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			accepted++
+		case apperror.CodeOf(err) != apperror.MerchantLimitExceeded:
+			t.Fatalf("GenerateQR returned unexpected error: %v", err)
+		}
+	}
+	// Cap 50.000 dengan amount 10.000: tepat 5 lolos, velocity (8) tidak tercapai
+	if accepted != 5 {
+		t.Fatalf("accepted %d transactions, want 5", accepted)
+	}
+	volume, err := riskRepo.SumVolume("M001", time.Time{})
+	if err != nil || volume != 50000 {
+		t.Fatalf("volume = %v, %v; want 50000", volume, err)
+	}
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"qr-service/internal/model"
+	"qr-service/internal/repository"
+	"qr-service/pkg/apperror"
+	"qr-service/pkg/snap"
+	"qr-service/pkg/util"
+	"time"
+)
+
+// Jam cut-off settlement harian (WIB)
+const defaultCutoffHour = 23
+
+// Kategori yang dipakai untuk merchant tanpa profil, sama dengan kriteria di template QR
+const defaultMerchantCategory = "UMI"
+
+type SettlementService struct {
+	Repo   *repository.SettlementRepository
+	Ledger *LedgerService
+}
+
+func NewSettlementService(repo *repository.SettlementRepository, ledger *LedgerService) *SettlementService {
+	return &SettlementService{Repo: repo, Ledger: ledger}
+}
+
+// Implementasi Endpoint POST /api/v1/settlements/run
+// Mengelompokkan transaksi PAID yang belum di-settle per merchant sampai jam cut-off,
+// menghitung MDR per transaksi, lalu menandai transaksinya sebagai settled. Batch dan posting
+// ledger-nya commit bersama per merchant; kegagalan menghentikan run dan dikembalikan sebagai error,
+// batch merchant sebelumnya tetap tersimpan dan run ulang melanjutkan merchant sisanya.
+func (s *SettlementService) RunSettlement(ctx context.Context, req model.RunSettlementRequest) (*model.SettlementsResponse, error) {
+	// 1. Tentukan cut-off
+	cutoff, err := resolveCutoff(req.Cutoff, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Ambil transaksi PAID yang belum masuk batch
+	transactions, err := s.Repo.FindUnsettled(cutoff, req.MerchantID)
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.Internal, "failed to load unsettled transactions")
+	}
+
+	// 3. Kelompokkan per merchant
+	var merchantIDs []string
+	byMerchant := map[string][]model.Transaction{}
+	for _, trx := range transactions {
+		if _, ok := byMerchant[trx.MerchantID]; !ok {
+			merchantIDs = append(merchantIDs, trx.MerchantID)
+		}
+		byMerchant[trx.MerchantID] = append(byMerchant[trx.MerchantID], trx)
+	}
+
+	// 4. Ambil kategori merchant dan aturan MDR
+	categories, err := s.Repo.GetMerchantCategories(merchantIDs)
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.Internal, "failed to load merchant categories")
+	}
+	rules, err := s.Repo.GetFeeRules()
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.Internal, "failed to load fee rules")
+	}
+	feeRules := make(map[string]model.FeeRule, len(rules))
+	for _, rule := range rules {
+		feeRules[rule.Category] = rule
+	}
+
+	// 5. Buat satu batch per merchant
+	settledAt := time.Now()
+	batches := []model.SettlementBatch{}
+	for _, merchantID := range merchantIDs {
+		category := categories[merchantID]
+		if category == "" {
+			category = defaultMerchantCategory
+		}
+		rule, ok := feeRules[category]
+		if !ok {
+			slog.Warn("No fee rule for category, skipping settlement", "category", category, "merchant_id", merchantID)
+			continue
+		}
+
+		batch, transactionIDs := buildBatch(merchantID, category, rule, cutoff, byMerchant[merchantID])
+		var saved model.SettlementBatch
+		err := s.Repo.Transaction(ctx, func(tx *repository.SettlementRepository) error {
+			var err error
+			if saved, err = tx.CreateBatch(batch, transactionIDs, settledAt); err != nil {
+				return err
+			}
+			// 6. Posting MDR dan payout ke ledger dalam database transaction yang sama
+			if s.Ledger == nil {
+				return nil
+			}
+			return NewLedgerService(tx.Ledger()).PostSettlement(ctx, saved)
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to settle merchant", "merchant_id", merchantID, "error", err)
+			var appErr *apperror.Error
+			if errors.As(err, &appErr) {
+				return nil, err
+			}
+			return nil, apperror.Wrap(err, apperror.Internal, fmt.Sprintf("failed to settle merchant %s", merchantID))
+		}
+		batches = append(batches, saved)
+	}
+
+	return &model.SettlementsResponse{
+		ResponseCode:    snap.Internal.SuccessCode(),
+		ResponseMessage: "Success",
+		Data:            batches,
+	}, nil
+}
+
+// Implementasi Endpoint GET /api/v1/settlements
+func (s *SettlementService) GetSettlements(req model.GetSettlementsRequest) (*model.SettlementsResponse, error) {
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 10
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+
+	var startTime, endTime time.Time
+	var err error
+
+	if req.StartDate != "" {
+		startTime, err = time.ParseInLocation("2006-01-02", req.StartDate, util.WIB)
+		if err != nil {
+			return nil, apperror.New(apperror.InvalidFieldFormat, "invalid start date format, use YYYY-MM-DD")
+		}
+	}
+
+	if req.EndDate != "" {
+		endTime, err = time.ParseInLocation("2006-01-02", req.EndDate, util.WIB)
+		if err != nil {
+			return nil, apperror.New(apperror.InvalidFieldFormat, "invalid end date format, use YYYY-MM-DD")
+		}
+		endTime = endTime.Add(23*time.Hour + 59*time.Minute + 59*time.Second)
+	}
+
+	batches, total, err := s.Repo.GetBatches(req.MerchantID, startTime, endTime, req.Page, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPage := int((total + int64(req.Limit) - 1) / int64(req.Limit))
+
+	return &model.SettlementsResponse{
+		ResponseCode:    snap.Internal.SuccessCode(),
+		ResponseMessage: "Success",
+		Data:            batches,
+		Pagination: &model.PaginationInfo{
+			Page:      req.Page,
+			Limit:     req.Limit,
+			Total:     int(total),
+			TotalPage: totalPage,
+		},
+	}, nil
+}
+
+// Implementasi Endpoint GET /api/v1/settlements/:id
+func (s *SettlementService) GetSettlement(id uint) (*model.SettlementResponse, error) {
+	batch, err := s.Repo.FindBatch(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.SettlementResponse{
+		ResponseCode:    snap.Internal.SuccessCode(),
+		ResponseMessage: "Success",
+		Data:            batch,
+	}, nil
+}
+
+// Implementasi Endpoint GET /api/v1/fee-rules
+func (s *SettlementService) GetFeeRules() (*model.FeeRulesResponse, error) {
+	rules, err := s.Repo.GetFeeRules()
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.FeeRulesResponse{
+		ResponseCode:    snap.Internal.SuccessCode(),
+		ResponseMessage: "Success",
+		Data:            rules,
+	}, nil
+}
+
+// Implementasi Endpoint PUT /api/v1/fee-rules/:category
+func (s *SettlementService) SaveFeeRule(category string, req model.SaveFeeRuleRequest) (*model.FeeRulesResponse, error) {
+	rule, err := s.Repo.SaveFeeRule(model.FeeRule{
+		Category:   category,
+		MDRPercent: req.MDRPercent,
+		FixedFee:   req.FixedFee,
+		ExemptUpTo: req.ExemptUpTo,
+	})
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.Internal, "failed to save fee rule")
+	}
+
+	return &model.FeeRulesResponse{
+		ResponseCode:    snap.Internal.SuccessCode(),
+		ResponseMessage: "Success",
+		Data:            []model.FeeRule{rule},
+	}, nil
+}
+
+// buildBatch menghitung gross, fee dan net untuk transaksi satu merchant
+func buildBatch(merchantID, category string, rule model.FeeRule, cutoff time.Time, transactions []model.Transaction) (model.SettlementBatch, []uint) {
+	batch := model.SettlementBatch{
+		BatchNo:    fmt.Sprintf("STL-%s-%s-%d", cutoff.In(util.WIB).Format("20060102"), merchantID, transactions[0].ID),
+		MerchantID: merchantID,
+		Category:   category,
+		Cutoff:     cutoff,
+		Currency:   "IDR",
+	}
+
+	transactionIDs := make([]uint, 0, len(transactions))
+	for _, trx := range transactions {
+		fee := calculateFee(rule, trx.Amount)
+		line := model.SettlementLine{
+			TransactionID: trx.ID,
+			ReferenceNo:   trx.ReferenceNo,
+			TerminalID:    trx.TerminalID,
+			PaidDate:      trx.PaidDate,
+			GrossAmount:   trx.Amount,
+			MDRPercent:    rule.MDRPercent,
+			FeeAmount:     fee,
+			NetAmount:     roundAmount(trx.Amount - fee),
+		}
+
+		if trx.PaidDate != nil && (batch.PeriodStart.IsZero() || trx.PaidDate.Before(batch.PeriodStart)) {
+			batch.PeriodStart = *trx.PaidDate
+		}
+
+		batch.Lines = append(batch.Lines, line)
+		batch.GrossAmount += line.GrossAmount
+		batch.FeeAmount += line.FeeAmount
+		transactionIDs = append(transactionIDs, trx.ID)
+	}
+
+	batch.TransactionCount = len(batch.Lines)
+	batch.GrossAmount = roundAmount(batch.GrossAmount)
+	batch.FeeAmount = roundAmount(batch.FeeAmount)
+	batch.NetAmount = roundAmount(batch.GrossAmount - batch.FeeAmount)
+	return batch, transactionIDs
+}
+
+// calculateFee menghitung MDR satu transaksi, tidak pernah melebihi amount
+func calculateFee(rule model.FeeRule, amount float64) float64 {
+	if rule.ExemptUpTo > 0 && amount <= rule.ExemptUpTo {
+		return 0
+	}
+	fee := roundAmount(amount*rule.MDRPercent/100 + rule.FixedFee)
+	return math.Min(fee, amount)
+}
+
+// roundAmount membulatkan ke 2 desimal (half up). Dibulatkan dulu ke 6 desimal agar hasil float
+// seperti 335 * 0.3 / 100 = 1.00499999... tetap dibulatkan sebagai 1.005 menjadi 1.01
+func roundAmount(amount float64) float64 {
+	return math.Round(math.Round(amount*1e6)/1e4) / 100
+}
+
+// resolveCutoff mem-parse cut-off RFC3339, atau mengambil jam cut-off terakhir yang sudah lewat
+func resolveCutoff(value string, now time.Time) (time.Time, error) {
+	if value != "" {
+		cutoff, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return time.Time{}, apperror.New(apperror.InvalidFieldFormat, "invalid cutoff format, use RFC3339")
+		}
+		return cutoff, nil
+	}
+
+	cutoff := util.StartOfDay(now).Add(defaultCutoffHour * time.Hour)
+	if now.Before(cutoff) {
+		cutoff = cutoff.AddDate(0, 0, -1)
+	}
+	return cutoff, nil
+}
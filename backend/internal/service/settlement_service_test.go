@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"qr-service/internal/model"
+	"qr-service/internal/repository"
+	"qr-service/pkg/apperror"
+	"qr-service/pkg/util"
+	"slices"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newSettlementService SettlementService dengan ledger di atas SQLite, berisi transaksi PAID
+// dua hari lalu (sebelum cut-off terakhir) untuk setiap amount
+func newSettlementService(t *testing.T, merchantID string, amounts ...float64) (*SettlementService, *gorm.DB) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "settlement.db")), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	err = db.AutoMigrate(&model.Transaction{}, &model.Merchant{}, &model.FeeRule{}, &model.SettlementBatch{}, &model.SettlementLine{},
+		&model.LedgerAccount{}, &model.JournalEntry{}, &model.Posting{})
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	store := repository.NewTransactionRepository(db)
+	paidDate := time.Now().Add(-48 * time.Hour)
+	for i, amount := range amounts {
+		ref := string(rune('A'+i)) + "001"
+		_, err := store.Save(model.Transaction{
+			MerchantID: merchantID, Amount: amount, TrxID: "TRX-" + ref, PartnerReferenceNo: "P" + ref, ReferenceNo: ref,
+			Status: "PAID", PaidDate: &paidDate, TransactionDate: paidDate,
+		})
+		if err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+
+	repo := repository.NewSettlementRepository(db)
+	return NewSettlementService(repo, NewLedgerService(repository.NewLedgerRepository(db))), db
+}
+
+func TestRunSettlementRollsBackBatchWhenLedgerPostFails(t *testing.T) {
+	s, db := newSettlementService(t, "M001", 100000, 50000)
+	if _, err := s.Repo.SaveFeeRule(model.FeeRule{Category: "UMI", MDRPercent: 0.7}); err != nil {
+		t.Fatalf("SaveFeeRule: %v", err)
+	}
+	// Entry FEE berhasil, entry SETTLEMENT gagal: keduanya dan batch-nya harus rollback
+	if err := db.Exec(`CREATE TRIGGER fail_settlement BEFORE INSERT ON journal_entries WHEN NEW.entry_type = 'SETTLEMENT'
+		BEGIN SELECT RAISE(ABORT, 'ledger unavailable'); END`).Error; err != nil {
+		t.Fatalf("create trigger: %v", err)
+	}
+
+	if _, err := s.RunSettlement(context.Background(), model.RunSettlementRequest{}); err == nil {
+		t.Fatal("RunSettlement succeeded although the ledger post failed")
+	}
+	var batches, entries, settled int64
+	db.Model(&model.SettlementBatch{}).Count(&batches)
+	db.Model(&model.JournalEntry{}).Count(&entries)
+	db.Model(&model.Transaction{}).Where("settlement_batch_id IS NOT NULL").Count(&settled)
+	if batches != 0 || entries != 0 || settled != 0 {
+		t.Fatalf("after failure: %d batches, %d entries, %d settled transactions; want none", batches, entries, settled)
+	}
+
+	if err := db.Exec("DROP TRIGGER fail_settlement").Error; err != nil {
+		t.Fatalf("drop trigger: %v", err)
+	}
+	resp, err := s.RunSettlement(context.Background(), model.RunSettlementRequest{})
+	if err != nil {
+		t.Fatalf("RunSettlement returned error: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].FeeAmount != 1050 || resp.Data[0].NetAmount != 148950 {
+		t.Fatalf("batches = %+v, want one batch with fee 1050 and net 148950", resp.Data)
+	}
+	for _, reference := range []string{"FEE:" + resp.Data[0].BatchNo, "SETTLEMENT:" + resp.Data[0].BatchNo} {
+		if entry, err := s.Ledger.Repo.FindEntryByReference(reference); err != nil || entry == nil {
+			t.Fatalf("entry %s = %v, %v; want posted", reference, entry, err)
+		}
+	}
+}
+
+func TestCalculateFee(t *testing.T) {
+	cases := []struct {
+		name   string
+		rule   model.FeeRule
+		amount float64
+		want   float64
+	}{
+		{"percentage", model.FeeRule{MDRPercent: 0.7}, 100000, 700},
+		{"rounds half up to cents", model.FeeRule{MDRPercent: 0.3}, 335, 1.01},
+		{"rounds half up below one rupiah", model.FeeRule{MDRPercent: 0.3}, 95, 0.29},
+		{"rounds down below half cent", model.FeeRule{MDRPercent: 0.7}, 10006, 70.04},
+		{"percentage plus fixed fee", model.FeeRule{MDRPercent: 0.7, FixedFee: 500}, 100000, 1200},
+		{"exempt at limit", model.FeeRule{MDRPercent: 0.3, ExemptUpTo: 100000}, 100000, 0},
+		{"charged above exempt limit", model.FeeRule{MDRPercent: 0.3, ExemptUpTo: 100000}, 100001, 300},
+		{"fee capped at amount", model.FeeRule{MDRPercent: 0.7, FixedFee: 1000}, 500, 500},
+		{"no fee", model.FeeRule{}, 100000, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := calculateFee(tc.rule, tc.amount); got != tc.want {
+				t.Fatalf("calculateFee(%+v, %v) = %v, want %v", tc.rule, tc.amount, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBuildBatch(t *testing.T) {
+	cutoff := time.Date(2025, 9, 21, 23, 0, 0, 0, util.WIB)
+	early := time.Date(2025, 9, 21, 8, 0, 0, 0, util.WIB)
+	late := early.Add(6 * time.Hour)
+	transactions := []model.Transaction{
+		{Model: gorm.Model{ID: 7}, ReferenceNo: "A007", TerminalID: "T01", Amount: 335, PaidDate: &late},
+		{Model: gorm.Model{ID: 9}, ReferenceNo: "A009", Amount: 100000, PaidDate: &early},
+	}
+
+	batch, ids := buildBatch("M001", "UMI", model.FeeRule{MDRPercent: 0.3}, cutoff, transactions)
+	if batch.BatchNo != "STL-20250921-M001-7" || batch.MerchantID != "M001" || batch.Category != "UMI" || !batch.Cutoff.Equal(cutoff) {
+		t.Fatalf("batch header = %+v", batch)
+	}
+	if !slices.Equal(ids, []uint{7, 9}) {
+		t.Fatalf("transaction IDs = %v, want [7 9]", ids)
+	}
+	if !batch.PeriodStart.Equal(early) {
+		t.Fatalf("period start = %s, want earliest paid date %s", batch.PeriodStart, early)
+	}
+	if batch.TransactionCount != 2 || batch.GrossAmount != 100335 || batch.FeeAmount != 301.01 || batch.NetAmount != 100033.99 {
+		t.Fatalf("totals = count %d gross %v fee %v net %v", batch.TransactionCount, batch.GrossAmount, batch.FeeAmount, batch.NetAmount)
+	}
+	line := batch.Lines[0]
+	if line.TransactionID != 7 || line.TerminalID != "T01" || line.MDRPercent != 0.3 || line.FeeAmount != 1.01 || line.NetAmount != 333.99 {
+		t.Fatalf("line = %+v", line)
+	}
+}
+
+func TestResolveCutoff(t *testing.T) {
+	today := time.Date(2025, 9, 21, 0, 0, 0, 0, util.WIB)
+	cases := []struct {
+		name  string
+		value string
+		now   time.Time
+		want  time.Time
+	}{
+		{"explicit cutoff", "2025-09-20T18:00:00+07:00", today, time.Date(2025, 9, 20, 18, 0, 0, 0, util.WIB)},
+		{"before cutoff hour uses yesterday", "", today.Add(22*time.Hour + 59*time.Minute), today.Add(-time.Hour)},
+		{"at cutoff hour uses today", "", today.Add(23 * time.Hour), today.Add(23 * time.Hour)},
+		{"after cutoff hour uses today", "", today.Add(23*time.Hour + 30*time.Minute), today.Add(23 * time.Hour)},
+		{"now in UTC", "", time.Date(2025, 9, 21, 16, 30, 0, 0, time.UTC), today.Add(23 * time.Hour)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := resolveCutoff(tc.value, tc.now)
+			if err != nil || !got.Equal(tc.want) {
+				t.Fatalf("resolveCutoff(%q, %s) = %s, %v; want %s", tc.value, tc.now, got, err, tc.want)
+			}
+		})
+	}
+
+	if _, err := resolveCutoff("2025-09-21 23:00", today); apperror.CodeOf(err) != apperror.InvalidFieldFormat {
+		t.Fatalf("invalid cutoff error = %v, want InvalidFieldFormat", err)
+	}
+}
+
+func TestRunSettlementSkipsMerchantWithoutFeeRule(t *testing.T) {
+	// M001 tanpa profil memakai kategori UMI yang tidak punya fee rule; M002 kategori UMK punya
+	s, db := newSettlementService(t, "M001", 100000)
+	if err := db.Create(&model.Merchant{MerchantID: "M002", Category: "UMK"}).Error; err != nil {
+		t.Fatalf("create merchant: %v", err)
+	}
+	paidDate := time.Now().Add(-48 * time.Hour)
+	_, err := repository.NewTransactionRepository(db).Save(model.Transaction{
+		MerchantID: "M002", Amount: 50000, TrxID: "TRX-M002", PartnerReferenceNo: "PM002", ReferenceNo: "M002-1",
+		Status: "PAID", PaidDate: &paidDate, TransactionDate: paidDate,
+	})
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := s.Repo.SaveFeeRule(model.FeeRule{Category: "UMK", MDRPercent: 0.3}); err != nil {
+		t.Fatalf("SaveFeeRule: %v", err)
+	}
+
+	resp, err := s.RunSettlement(context.Background(), model.RunSettlementRequest{})
+	if err != nil {
+		t.Fatalf("RunSettlement returned error: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].MerchantID != "M002" || resp.Data[0].FeeAmount != 150 {
+		t.Fatalf("batches = %+v, want only M002 with fee 150", resp.Data)
+	}
+	var unsettled int64
+	db.Model(&model.Transaction{}).Where("merchant_id = ? AND settlement_batch_id IS NULL", "M001").Count(&unsettled)
+	if unsettled != 1 {
+		t.Fatalf("M001 unsettled transactions = %d, want 1 left for a later run", unsettled)
+	}
+}
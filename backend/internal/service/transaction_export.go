@@ -0,0 +1,136 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"qr-service/internal/model"
+	"qr-service/internal/repository"
+	"qr-service/pkg/apperror"
+	"qr-service/pkg/export"
+	"strings"
+	"time"
+)
+
+// exportDateLayout format tanggal di file export (gaya Indonesia: dd/mm/yyyy)
+const exportDateLayout = "02/01/2006 15:04:05"
+
+// exportFooterRows jumlah baris footer total (termasuk baris kosong pemisah)
+const exportFooterRows = 5
+
+// TransactionExport file export yang sudah tervalidasi dan siap ditulis ke response
+type TransactionExport struct {
+	Format      string
+	Filename    string
+	ContentType string
+
+	repo   repository.TransactionStore
+	filter model.TransactionFilter
+	loc    *time.Location
+}
+
+// Implementasi Endpoint GET /api/v1/transactions/export.
+// Filter divalidasi di sini agar error bisa dikembalikan sebelum response mulai di-stream.
+func (s *TransactionService) ExportTransactions(req model.GetTransactionsRequest, format string) (*TransactionExport, error) {
+	format = strings.ToLower(format)
+	if format == "" {
+		format = export.FormatCSV
+	}
+	if format != export.FormatCSV && format != export.FormatXLSX {
+		return nil, apperror.Newf(apperror.InvalidFieldFormat, "invalid format: %s, use csv or xlsx", format)
+	}
+
+	// Export selalu mengambil seluruh hasil filter, page dan cursor tidak berlaku
+	req.Page, req.Limit = 1, 0
+	req.After, req.Before, req.IncludeTotal = "", "", nil
+	filter, err := s.buildTransactionFilter(req)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := loadLocation(req.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	// Satu sheet Excel dibatasi ~1 juta baris, cek dulu sebelum mulai menulis
+	if format == export.FormatXLSX {
+		maxRows := export.MaxXLSXRows - 1 - exportFooterRows
+		countFilter := filter
+		countFilter.Limit = 1
+		_, total, err := s.Repo.GetTransactions(countFilter)
+		if err != nil {
+			return nil, err
+		}
+		if total > int64(maxRows) {
+			return nil, apperror.Newf(apperror.InvalidFieldFormat, "invalid export: %d rows exceeds the xlsx limit of %d, use format=csv or narrow the filter", total, maxRows)
+		}
+	}
+
+	return &TransactionExport{
+		Format:      format,
+		Filename:    fmt.Sprintf("transactions_%s.%s", time.Now().In(loc).Format("20060102_150405"), format),
+		ContentType: export.ContentType(format),
+		repo:        s.Repo,
+		filter:      filter,
+		loc:         loc,
+	}, nil
+}
+
+// Write menulis header, baris transaksi satu per satu dari store, lalu footer total
+func (e *TransactionExport) Write(w io.Writer) error {
+	writer, err := export.New(e.Format, w)
+	if err != nil {
+		return err
+	}
+
+	zone := time.Now().In(e.loc).Format("MST")
+	if err := writer.WriteHeader(
+		"Reference No", "Partner Reference No", "Trx ID", "Merchant ID", "Outlet ID", "Terminal ID",
+		"Status", "Amount", "Currency",
+		"Transaction Date ("+zone+")", "Paid Date ("+zone+")", "Created At ("+zone+")",
+		"Issuer", "Customer PAN", "Customer Name", "RRN", "Approval Code",
+	); err != nil {
+		return err
+	}
+
+	var totalCount, paidCount int64
+	var totalAmount, paidAmount float64
+	err = e.repo.StreamTransactions(e.filter, func(trx model.Transaction) error {
+		totalCount++
+		totalAmount += trx.Amount
+		if trx.Status == "PAID" {
+			paidCount++
+			paidAmount += trx.Amount
+		}
+		return writer.WriteRow(
+			trx.ReferenceNo, trx.PartnerReferenceNo, trx.TrxID, trx.MerchantID, trx.OutletID, trx.TerminalID,
+			trx.Status, trx.Amount, trx.Currency,
+			e.formatDate(&trx.TransactionDate), e.formatDate(trx.PaidDate), e.formatDate(&trx.CreatedAt),
+			trx.IssuerName, trx.CustomerPAN, trx.CustomerName, trx.RRN, trx.ApprovalCode,
+		)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Footer total
+	for _, row := range [][]any{
+		{},
+		{"Total Transactions", totalCount},
+		{"Total Amount", totalAmount},
+		{"Total Paid Transactions", paidCount},
+		{"Total Paid Amount", paidAmount},
+	} {
+		if err := writer.WriteRow(row...); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+// formatDate menampilkan waktu di zona export, kosong jika belum ada
+func (e *TransactionExport) formatDate(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.In(e.loc).Format(exportDateLayout)
+}
@@ -1,79 +1,135 @@
 package service
 
 import (
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"maps"
 	"qr-service/internal/model"
 	"qr-service/internal/repository"
+	"qr-service/pkg/apperror"
+	"qr-service/pkg/logging"
+	"qr-service/pkg/metrics"
+	"qr-service/pkg/provider"
+	"qr-service/pkg/snap"
+	"qr-service/pkg/tracing"
 	"qr-service/pkg/util"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	ws "qr-service/pkg/websocket"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type TransactionService struct {
-	Repo         *repository.TransactionRepository
+	Repo         repository.TransactionStore
+	Merchants    *MerchantService
+	Risk         *RiskService
+	Ledger       *LedgerService
 	RefGenerator util.ReferenceGenerator
-	QRGenerator  util.QRGenerator
+	Providers    *provider.Registry
 	StatusMapper util.StatusMapper
 	WSHub        *ws.Hub
+
+	statsUpdates statsDebouncer
+}
+
+func NewTransactionService(repo repository.TransactionStore, merchants *MerchantService, risk *RiskService, ledger *LedgerService, wsHub *ws.Hub) *TransactionService {
+	return &TransactionService{Repo: repo, Merchants: merchants, Risk: risk, Ledger: ledger, RefGenerator: util.NewReferenceGenerator(util.ReferenceOptions{Prefix: "A"}), Providers: provider.NewRegistry(provider.NewSNAP(provider.SNAPOptions{Name: provider.DefaultName})), StatusMapper: util.NewStatusMapper(nil), WSHub: wsHub}
 }
 
-func NewTransactionService(repo *repository.TransactionRepository, wsHub *ws.Hub) *TransactionService {
-	return &TransactionService{Repo: repo, RefGenerator: util.NewReferenceGenerator("A"), QRGenerator: util.NewQRGenerator(), StatusMapper: util.NewStatusMapper(), WSHub: wsHub}
+// Implementasi Endpoint GET /api/v1/status-mappings
+func (s *TransactionService) GetStatusMappings() *model.StatusMappingsResponse {
+	mappings := s.StatusMapper.Mappings()
+	data := make([]model.GatewayStatusMapping, 0, len(mappings))
+	for _, gateway := range slices.Sorted(maps.Keys(mappings)) {
+		data = append(data, model.GatewayStatusMapping{Gateway: gateway, Mapping: mappings[gateway]})
+	}
+
+	return &model.StatusMappingsResponse{
+		ResponseCode:    snap.Internal.SuccessCode(),
+		ResponseMessage: "Success",
+		Data:            data,
+	}
 }
 
 // Implementasi Endpoint POST /api/v1/qr/generate
-func (s *TransactionService) GenerateQR(req model.GenerateQRRequest) (model.GenerateQRResponse, error) {
+func (s *TransactionService) GenerateQR(ctx context.Context, req model.GenerateQRRequest) (resp model.GenerateQRResponse, err error) {
+	ctx, span := tracing.Start(ctx, "TransactionService.GenerateQR", attribute.String("merchant.id", req.MerchantID))
+	// merchantId dari client belum tervalidasi, label baru diisi dari transaksi yang tersimpan
+	merchantID := metrics.UnknownMerchant
+	defer func() {
+		metrics.QRGenerateTotal.WithLabelValues(merchantID, metrics.Outcome(err)).Inc()
+		tracing.End(span, err)
+	}()
+	repo := s.Repo.WithContext(ctx)
+
 	// 1. Parse amount dari string ke float64
 	amount, err := strconv.ParseFloat(req.Amount.Value, 64)
 	if err != nil {
-		return model.GenerateQRResponse{}, errors.New("invalid amount format")
+		return model.GenerateQRResponse{}, apperror.New(apperror.InvalidFieldFormat, "invalid amount format")
 	}
 
 	// 2. Validasi Amount
 	if amount <= 0 {
-		return model.GenerateQRResponse{}, errors.New("amount must be greater than 0")
+		return model.GenerateQRResponse{}, apperror.New(apperror.InvalidAmount, "amount must be greater than 0")
 	}
 
 	// 3. Validasi Currency
 	if req.Amount.Currency != "IDR" {
-		return model.GenerateQRResponse{}, errors.New("only IDR currency is supported")
+		return model.GenerateQRResponse{}, apperror.New(apperror.InvalidFieldFormat, "only IDR currency is supported")
 	}
 
 	// 4. Cek apakah partner_reference_no sudah ada
-	existing, err := s.Repo.FindByPartnerReference(req.PartnerReferenceNo)
+	existing, err := repo.FindByPartnerReference(req.PartnerReferenceNo)
 	if err != nil {
-		return model.GenerateQRResponse{}, fmt.Errorf("failed to check existing transaction: %w", err)
+		return model.GenerateQRResponse{}, apperror.Wrap(err, apperror.Internal, "failed to check existing transaction")
 	}
 
 	// 5. Jika sudah ada, return data existing
 	if existing != nil {
+		merchantID = existing.MerchantID
+
 		// Broadcast transaksi existing
-		s.broadcastTransactionUpdate(existing)
+		s.broadcastTransactionUpdate(ctx, existing)
 
 		return model.GenerateQRResponse{
-			ResponseCode:       "2004700",
+			ResponseCode:       snap.GenerateQR.SuccessCode(),
 			ResponseMessage:    "Successful",
 			ReferenceNo:        existing.ReferenceNo,
 			PartnerReferenceNo: existing.PartnerReferenceNo,
-			QRContent:          s.QRGenerator.GenerateQRContent(existing.MerchantID, existing.ReferenceNo),
+			QRContent:          s.buildQR(existing),
 		}, nil
 	}
 
-	// 6. Generate ReferenceNo internal (contoh: A0000000577)
-	referenceNo := s.RefGenerator.GenerateReferenceNo()
+	// 6. Validasi hierarki outlet/terminal milik merchant
+	outletID := req.OutletID
+	if s.Merchants != nil {
+		outletID, err = s.Merchants.ResolveHierarchy(req.MerchantID, req.OutletID, req.TerminalID)
+		if err != nil {
+			return model.GenerateQRResponse{}, err
+		}
+	}
+
+	// 7. Generate ReferenceNo internal (contoh: A0000581937281024001), prefix per merchant/channel
+	referenceNo := s.RefGenerator.GenerateReferenceNo(util.ReferenceScope{MerchantID: req.MerchantID, ChannelID: req.ChannelID})
 
-	// 7. Generate TrxID dari partnerReferenceNo (untuk uniqueness)
+	// 8. Generate TrxID dari partnerReferenceNo (untuk uniqueness)
 	trxID := "TRX-" + req.PartnerReferenceNo
 
-	// 8. Simpan transaksi baru
+	// 9. Transaksi baru beserta provider yang membuat QR, callback harus dari provider yang sama
+	qrProvider := s.Providers.ForMerchant(req.MerchantID)
 	transaction := model.Transaction{
+		Provider:           qrProvider.Name(),
 		MerchantID:         req.MerchantID,
+		OutletID:           outletID,
+		TerminalID:         req.TerminalID,
 		Amount:             amount,
 		TrxID:              trxID,
 		PartnerReferenceNo: req.PartnerReferenceNo,
@@ -82,100 +138,253 @@ func (s *TransactionService) GenerateQR(req model.GenerateQRRequest) (model.Gene
 		TransactionDate:    time.Now(),
 	}
 
-	savedTransaction, err := s.Repo.Save(transaction)
+	// 10. Simpan transaksi; dengan rule risiko (limit amount, volume, velocity) evaluasi dan insert
+	// berjalan atomik di bawah lock merchant
+	var savedTransaction model.Transaction
+	save := func(store repository.TransactionStore) (err error) {
+		savedTransaction, err = store.Save(transaction)
+		return err
+	}
+	if s.Risk != nil {
+		err = s.Risk.Enforce(ctx, RiskInput{
+			MerchantID:         req.MerchantID,
+			OutletID:           outletID,
+			TerminalID:         req.TerminalID,
+			PartnerReferenceNo: req.PartnerReferenceNo,
+			Amount:             amount,
+			At:                 transaction.TransactionDate,
+		}, repo, save)
+	} else {
+		err = save(repo)
+	}
 	if err != nil {
 		// Tangani error duplicate secara spesifik
-		if strings.Contains(err.Error(), "23505") ||
-			strings.Contains(err.Error(), "unique constraint") ||
-			strings.Contains(err.Error(), "duplicate key") {
-			return model.GenerateQRResponse{}, fmt.Errorf("duplicate key: transaction with reference %s already exists", req.PartnerReferenceNo)
+		if errors.Is(err, repository.ErrDuplicateTransaction) {
+			return model.GenerateQRResponse{}, apperror.Wrap(err, apperror.DuplicateReference, fmt.Sprintf("transaction with reference %s already exists", req.PartnerReferenceNo))
+		}
+		// Violation rule risiko dan error internal rule sudah berupa apperror
+		var appErr *apperror.Error
+		if errors.As(err, &appErr) {
+			return model.GenerateQRResponse{}, err
 		}
-		return model.GenerateQRResponse{}, fmt.Errorf("failed to save transaction: %w", err)
+		return model.GenerateQRResponse{}, apperror.Wrap(err, apperror.Internal, "failed to save transaction")
 	}
 
-	// 9. Broadcast transaksi baru yang berhasil dibuat
-	s.broadcastTransactionUpdate(&savedTransaction)
+	merchantID = savedTransaction.MerchantID
+
+	// 11. Broadcast transaksi baru yang berhasil dibuat
+	s.broadcastTransactionUpdate(ctx, &savedTransaction)
 
-	// 10. Return response sukses
+	// 12. Return response sukses
 	return model.GenerateQRResponse{
-		ResponseCode:       "2004700",
+		ResponseCode:       snap.GenerateQR.SuccessCode(),
 		ResponseMessage:    "Successful",
 		ReferenceNo:        referenceNo,
 		PartnerReferenceNo: req.PartnerReferenceNo,
-		QRContent:          s.QRGenerator.GenerateQRContent(req.MerchantID, referenceNo),
+		QRContent:          qrProvider.BuildQR(provider.QRRequest{MerchantID: req.MerchantID, ReferenceNo: referenceNo, TerminalID: req.TerminalID}),
+	}, nil
+}
+
+// buildQR membangun ulang QR transaksi dengan provider yang membuatnya
+func (s *TransactionService) buildQR(trx *model.Transaction) string {
+	p, ok := s.Providers.Get(trx.Provider)
+	if !ok {
+		p = s.Providers.Default()
+	}
+	return p.BuildQR(provider.QRRequest{MerchantID: trx.MerchantID, ReferenceNo: trx.ReferenceNo, TerminalID: trx.TerminalID})
+}
+
+// Implementasi Endpoint POST /api/v1/qr/payment: callback SNAP dari provider default
+func (s *TransactionService) ProcessPaymentCallback(ctx context.Context, req model.PaymentCallbackRequest) (model.PaymentCallbackResponse, error) {
+	event := provider.PaymentEvent{
+		Provider:           s.Providers.Default().Name(),
+		ReferenceNo:        req.OriginalReferenceNo,
+		PartnerReferenceNo: req.OriginalPartnerReferenceNo,
+		Status:             req.TransactionStatusDesc,
+		StatusDesc:         req.TransactionStatusDesc,
+		Amount:             req.Amount.Value,
+		Currency:           req.Amount.Currency,
+		PaidTime:           req.PaidTime,
+	}
+	// Kode latestTransactionStatus SNAP didahulukan dari deskripsi
+	if req.LatestTransactionStatus != "" {
+		event.Status = req.LatestTransactionStatus
+	}
+	if info := req.AdditionalInfo; info != nil {
+		event.Payer = provider.Payer(*info)
+	}
+	return s.ProcessPaymentEvent(ctx, event)
+}
+
+// Implementasi Endpoint POST /api/v1/callbacks/:provider, dan POST /api/v1/qr/payment lewat
+// ProcessPaymentCallback. Event sudah diautentikasi dan di-parse oleh adapter provider.
+func (s *TransactionService) ProcessPaymentEvent(ctx context.Context, event provider.PaymentEvent) (resp model.PaymentCallbackResponse, err error) {
+	ctx, span := tracing.Start(ctx, "TransactionService.ProcessPaymentEvent",
+		attribute.String("transaction.reference_no", event.ReferenceNo),
+		attribute.String("payment.provider", event.Provider),
+	)
+	merchantID := metrics.UnknownMerchant
+	defer func() {
+		metrics.PaymentCallbackTotal.WithLabelValues(merchantID, metrics.Outcome(err)).Inc()
+		tracing.End(span, err)
+	}()
+
+	trx, err := s.applyPaymentEvent(ctx, event)
+	if trx.MerchantID != "" {
+		merchantID = trx.MerchantID
+		span.SetAttributes(attribute.String("merchant.id", trx.MerchantID))
+	}
+	if err != nil {
+		return model.PaymentCallbackResponse{}, err
+	}
+
+	return model.PaymentCallbackResponse{
+		ResponseCode:          snap.NotifyPayment.SuccessCode(),
+		ResponseMessage:       "Successful",
+		TransactionStatusDesc: event.StatusDesc,
+	}, nil
+}
+
+// Implementasi Endpoint POST /api/v1/transactions/:referenceNo/inquiry: menanyakan status ke
+// provider transaksi lalu menerapkannya seperti callback. merchantID tidak kosong membatasi ke
+// transaksi merchant tersebut; transaksi merchant lain dilaporkan tidak ditemukan.
+func (s *TransactionService) InquireStatus(ctx context.Context, referenceNo string, merchantID string) (resp *model.StatusInquiryResponse, err error) {
+	ctx, span := tracing.Start(ctx, "TransactionService.InquireStatus", attribute.String("transaction.reference_no", referenceNo))
+	defer func() { tracing.End(span, err) }()
+
+	trx, err := s.Repo.WithContext(ctx).FindByReferenceNo(referenceNo)
+	if errors.Is(err, repository.ErrTransactionNotFound) {
+		return nil, apperror.New(apperror.TransactionNotFound, "transaction not found")
+	}
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.Internal, "failed to find transaction")
+	}
+	if merchantID != "" && trx.MerchantID != merchantID {
+		return nil, apperror.New(apperror.TransactionNotFound, "transaction not found")
+	}
+
+	p, ok := s.Providers.Get(trx.Provider)
+	if !ok {
+		return nil, apperror.Newf(apperror.InvalidRouting, "payment provider %s is not configured", trx.Provider)
+	}
+	span.SetAttributes(attribute.String("payment.provider", p.Name()))
+
+	event, err := p.InquireStatus(ctx, provider.InquiryRequest{ReferenceNo: trx.ReferenceNo, PartnerReferenceNo: trx.PartnerReferenceNo})
+	if errors.Is(err, provider.ErrInquiryNotSupported) {
+		return nil, apperror.Newf(apperror.FunctionNotSupported, "payment provider %s does not support status inquiry", p.Name())
+	}
+	if err != nil {
+		return nil, apperror.Wrap(err, apperror.ExternalServerError, "status inquiry to payment provider failed")
+	}
+	event.Provider = p.Name()
+
+	if trx, err = s.applyPaymentEvent(ctx, event); err != nil {
+		return nil, err
+	}
+
+	return &model.StatusInquiryResponse{
+		ResponseCode:          snap.Internal.SuccessCode(),
+		ResponseMessage:       "Success",
+		ReferenceNo:           trx.ReferenceNo,
+		Provider:              p.Name(),
+		TransactionStatusDesc: event.StatusDesc,
+		Status:                trx.Status,
 	}, nil
 }
 
-// Implementasi Endpoint POST /api/v1/qr/payment
-func (s *TransactionService) ProcessPaymentCallback(req model.PaymentCallbackRequest) (model.PaymentCallbackResponse, error) {
+// applyPaymentEvent memvalidasi event terhadap transaksi lalu menyimpan data pembayar dan status.
+// Transaksi dikembalikan dengan status terbaru, juga jika validasi gagal setelah transaksi ditemukan.
+func (s *TransactionService) applyPaymentEvent(ctx context.Context, event provider.PaymentEvent) (model.Transaction, error) {
+	repo := s.Repo.WithContext(ctx)
+
 	// 1. Parse amount dari string ke float64
-	amount, err := strconv.ParseFloat(req.Amount.Value, 64)
+	amount, err := strconv.ParseFloat(event.Amount, 64)
 	if err != nil {
-		return model.PaymentCallbackResponse{}, errors.New("invalid amount format")
+		return model.Transaction{}, apperror.New(apperror.InvalidFieldFormat, "invalid amount format")
 	}
 
 	// 2. Validasi Currency
-	if req.Amount.Currency != "IDR" {
-		return model.PaymentCallbackResponse{}, errors.New("only IDR currency is supported")
+	if event.Currency != "IDR" {
+		return model.Transaction{}, apperror.New(apperror.InvalidFieldFormat, "only IDR currency is supported")
 	}
 
 	// 3. Validasi reference_number (Cek keberadaan di database)
-	trx, err := s.Repo.FindByReferenceNo(req.OriginalReferenceNo)
+	trx, err := repo.FindByReferenceNo(event.ReferenceNo)
+	if errors.Is(err, repository.ErrTransactionNotFound) {
+		return model.Transaction{}, apperror.New(apperror.TransactionNotFound, "transaction not found")
+	}
 	if err != nil {
-		return model.PaymentCallbackResponse{}, errors.New("transaction not found")
+		return model.Transaction{}, apperror.Wrap(err, apperror.Internal, "failed to find transaction")
+	}
+
+	// 4. Event harus dari provider yang membuat QR transaksi ini
+	if p, ok := s.Providers.Get(trx.Provider); !ok || p.Name() != event.Provider {
+		return trx, apperror.New(apperror.InconsistentRequest, "payment provider mismatch")
 	}
 
-	// 4. Validasi partner reference number
-	if trx.PartnerReferenceNo != req.OriginalPartnerReferenceNo {
-		return model.PaymentCallbackResponse{}, errors.New("partner reference number mismatch")
+	// 5. Validasi partner reference number
+	if trx.PartnerReferenceNo != event.PartnerReferenceNo {
+		return trx, apperror.New(apperror.InconsistentRequest, "partner reference number mismatch")
 	}
 
-	// 5. Validasi amount
+	// 6. Validasi amount
 	if trx.Amount != amount {
-		return model.PaymentCallbackResponse{}, errors.New("amount mismatch")
+		return trx, apperror.New(apperror.InvalidAmount, "amount mismatch")
 	}
 
-	// 6. Parse paidTime
-	paidTime, err := time.Parse(time.RFC3339, req.PaidTime)
-	if err != nil {
-		return model.PaymentCallbackResponse{}, errors.New("invalid paidTime format")
+	// 7. Map status provider ke status internal; status di luar tabel ditolak
+	status, ok := s.StatusMapper.Map(event.Provider, event.Status)
+	if !ok {
+		slog.WarnContext(ctx, "Unknown provider transaction status rejected, review status_mapping", "provider", event.Provider, "status", event.Status, "reference_no", trx.ReferenceNo)
+		return trx, apperror.Newf(apperror.InvalidTransactionStatus, "unknown transaction status: %s", event.Status)
 	}
 
-	// 7. Map transactionStatusDesc ke status internal
-	status := s.StatusMapper.MapTransactionStatus(req.TransactionStatusDesc)
+	// 8. Parse paidTime; inquiry untuk transaksi yang belum dibayar boleh tanpa paidTime
+	var paidTime time.Time
+	if event.PaidTime != "" || status == util.StatusPaid {
+		if paidTime, err = time.Parse(time.RFC3339, event.PaidTime); err != nil {
+			return trx, apperror.New(apperror.InvalidFieldFormat, "invalid paidTime format")
+		}
+	}
 
-	// 8. Update Status Transaksi jika status berubah
-	if trx.Status != status {
-		err = s.Repo.UpdateStatus(req.OriginalReferenceNo, status, paidTime)
-		if err != nil {
-			return model.PaymentCallbackResponse{}, errors.New("failed to update status: " + err.Error())
+	// 9. Simpan data pembayar (issuer, PAN masked, RRN) jika dikirim
+	if payer := payerInfo(event.Payer); !payer.IsZero() {
+		if err := repo.UpdatePayer(trx.ReferenceNo, payer); err != nil {
+			return trx, apperror.Wrap(err, apperror.Internal, "failed to save payer info")
+		}
+		if trx.Status == status {
+			// Status tidak berubah sehingga tidak ada broadcast dari UpdateStatus
+			if updatedTrx, err := repo.FindByReferenceNo(trx.ReferenceNo); err == nil {
+				s.broadcastTransactionUpdate(ctx, &updatedTrx)
+			}
 		}
+	}
 
-		updatedTrx, err := s.Repo.FindByReferenceNo(req.OriginalReferenceNo)
-		if err == nil {
-			s.broadcastTransactionUpdate(&updatedTrx)
+	// 10. Update Status Transaksi jika status berubah
+	if trx.Status != status {
+		if err := s.UpdateStatus(ctx, trx, status, paidTime); err != nil {
+			return trx, err
 		}
+		trx.Status = status
 	}
+	return trx, nil
+}
 
-	// 9. Return response sesuai format yang diminta
-	return model.PaymentCallbackResponse{
-		ResponseCode:          "2005100",
-		ResponseMessage:       "Successful",
-		TransactionStatusDesc: req.TransactionStatusDesc,
-	}, nil
+// payerInfo mengubah data pembayar dari provider menjadi data yang disimpan.
+// PAN yang belum di-mask oleh issuer di-mask di sini sebelum menyentuh database.
+func payerInfo(payer provider.Payer) model.PayerInfo {
+	return model.PayerInfo{
+		IssuerID:     strings.TrimSpace(payer.IssuerID),
+		IssuerName:   strings.TrimSpace(payer.IssuerName),
+		CustomerPAN:  util.MaskPAN(payer.CustomerPAN),
+		CustomerName: strings.TrimSpace(payer.CustomerName),
+		RRN:          strings.TrimSpace(payer.RRN),
+		ApprovalCode: strings.TrimSpace(payer.ApprovalCode),
+	}
 }
 
 // Implementasi Endpoint GET /api/v1/transactions
 func (s *TransactionService) GetTransactions(req model.GetTransactionsRequest) (*model.GetTransactionsResponse, error) {
-	// Validasi dan mapping status jika ada
-	if req.Status != "" {
-		if !s.StatusMapper.IsValidStatus(req.Status) {
-			return nil, fmt.Errorf("invalid status: %s", req.Status)
-		}
-		// Map external status ke internal status jika perlu
-		req.Status = s.StatusMapper.GetInternalStatus(req.Status)
-	}
-
 	// Set default values untuk pagination
 	if req.Page <= 0 {
 		req.Page = 1
@@ -187,40 +396,27 @@ func (s *TransactionService) GetTransactions(req model.GetTransactionsRequest) (
 		req.Limit = 100
 	}
 
-	// Parse dates jika ada
-	var startTime, endTime time.Time
-	var err error
-
-	if req.StartDate != "" {
-		startTime, err = time.Parse("2006-01-02", req.StartDate)
-		if err != nil {
-			return nil, fmt.Errorf("invalid start date format, use YYYY-MM-DD")
-		}
+	// Validasi dan ubah query parameter menjadi filter repository
+	filter, err := s.buildTransactionFilter(req)
+	if err != nil {
+		return nil, err
 	}
 
-	if req.EndDate != "" {
-		endTime, err = time.Parse("2006-01-02", req.EndDate)
-		if err != nil {
-			return nil, fmt.Errorf("invalid end date format, use YYYY-MM-DD")
-		}
-		// Set end time to end of day
-		endTime = endTime.Add(23*time.Hour + 59*time.Minute + 59*time.Second)
-	}
-
-	// Panggil repository
-	transactions, total, err := s.Repo.GetTransactions(
-		req.ReferenceNumber,
-		req.CustomerID,
-		req.Status,
-		startTime,
-		endTime,
-		req.Search,
-		req.Page,
-		req.Limit,
-	)
+	// Panggil repository, ambil satu baris lebih untuk mengetahui apakah masih ada halaman lanjutan
+	filter.Limit = req.Limit + 1
+	transactions, total, err := s.Repo.GetTransactions(filter)
 	if err != nil {
 		return nil, err
 	}
+	hasMore := len(transactions) > req.Limit
+	if hasMore {
+		if filter.Before != nil {
+			// Mode before: baris tambahan adalah yang paling jauh dari cursor (paling awal)
+			transactions = transactions[1:]
+		} else {
+			transactions = transactions[:req.Limit]
+		}
+	}
 
 	// Map ke response
 	var transactionResponses []model.TransactionResponse
@@ -228,6 +424,8 @@ func (s *TransactionService) GetTransactions(req model.GetTransactionsRequest) (
 		transactionResponses = append(transactionResponses, model.TransactionResponse{
 			TrxID:           transaction.TrxID,
 			MerchantID:      transaction.MerchantID,
+			OutletID:        transaction.OutletID,
+			TerminalID:      transaction.TerminalID,
 			ReferenceNo:     transaction.ReferenceNo,
 			Amount:          transaction.Amount,
 			Status:          transaction.Status,
@@ -236,35 +434,310 @@ func (s *TransactionService) GetTransactions(req model.GetTransactionsRequest) (
 			Currency:        transaction.Currency,
 			CreatedAt:       transaction.CreatedAt,
 			UpdatedAt:       transaction.UpdatedAt,
+			PayerInfo:       transaction.PayerInfo,
 		})
 	}
 
-	// Hitung pagination
-	totalPage := int((total + int64(req.Limit) - 1) / int64(req.Limit))
-
 	response := &model.GetTransactionsResponse{
-		ResponseCode:    "200",
+		ResponseCode:    snap.Internal.SuccessCode(),
 		ResponseMessage: "Success",
 		Data:            transactionResponses,
-		Pagination: &model.PaginationInfo{
-			Page:      req.Page,
+		Cursor:          transactionCursorInfo(filter, req.Page, transactions, hasMore),
+	}
+
+	// Hitung pagination (hanya jika total dihitung)
+	if !filter.SkipTotal {
+		page := req.Page
+		if filter.After != nil || filter.Before != nil {
+			page = 0 // nomor halaman tidak berlaku di mode cursor
+		}
+		response.Pagination = &model.PaginationInfo{
+			Page:      page,
 			Limit:     req.Limit,
 			Total:     int(total),
-			TotalPage: totalPage,
-		},
+			TotalPage: int((total + int64(req.Limit) - 1) / int64(req.Limit)),
+		}
 	}
 
 	return response, nil
 }
 
-// broadcastTransactionUpdate mengirim update transaksi via WebSocket
-func (s *TransactionService) broadcastTransactionUpdate(transaction *model.Transaction) {
+// transactionCursorInfo menentukan ada tidaknya halaman sebelum/sesudah beserta token cursor-nya.
+// Token hanya dibuat untuk sort created_at karena keyset memakai (created_at, id).
+func transactionCursorInfo(filter model.TransactionFilter, page int, transactions []model.Transaction, hasMore bool) *model.CursorInfo {
+	info := &model.CursorInfo{}
+	switch {
+	case filter.Before != nil:
+		info.HasPrev, info.HasNext = hasMore, true
+	case filter.After != nil:
+		info.HasPrev, info.HasNext = true, hasMore
+	default:
+		info.HasPrev, info.HasNext = page > 1, hasMore
+	}
+
+	if filter.SortBy == model.SortCreatedAt && len(transactions) > 0 {
+		if info.HasNext {
+			info.Next = encodeCursor(transactions[len(transactions)-1])
+		}
+		if info.HasPrev {
+			info.Prev = encodeCursor(transactions[0])
+		}
+	}
+	return info
+}
+
+// encodeCursor membuat token opaque dari posisi (created_at, id) transaksi
+func encodeCursor(trx model.Transaction) string {
+	raw := fmt.Sprintf("%d:%d", trx.CreatedAt.UnixNano(), trx.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeCursor kebalikan dari encodeCursor
+func decodeCursor(token string) (*model.TransactionCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, apperror.New(apperror.InvalidFieldFormat, "invalid cursor")
+	}
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, apperror.New(apperror.InvalidFieldFormat, "invalid cursor")
+	}
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, apperror.New(apperror.InvalidFieldFormat, "invalid cursor")
+	}
+	trxID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, apperror.New(apperror.InvalidFieldFormat, "invalid cursor")
+	}
+	return &model.TransactionCursor{CreatedAt: time.Unix(0, unixNano).UTC(), ID: uint(trxID)}, nil
+}
+
+// buildTransactionFilter memvalidasi query parameter GET /api/v1/transactions.
+// Semua error validasi bertipe apperror.InvalidFieldFormat (400) dan diawali "invalid ".
+func (s *TransactionService) buildTransactionFilter(req model.GetTransactionsRequest) (model.TransactionFilter, error) {
+	filter := model.TransactionFilter{
+		ReferenceNo: strings.TrimSpace(req.ReferenceNumber),
+		MerchantID:  req.MerchantID,
+		OutletID:    req.OutletID,
+		TerminalID:  req.TerminalID,
+		RRN:         strings.TrimSpace(req.RRN),
+		IssuerID:    strings.TrimSpace(req.IssuerID),
+		Currency:    strings.ToUpper(req.Currency),
+		Search:      req.Search,
+		Offset:      (req.Page - 1) * req.Limit,
+		Limit:       req.Limit,
+	}
+
+	// Reference: exact (default) atau prefix
+	switch strings.ToLower(req.ReferenceMatch) {
+	case "", "exact":
+	case "prefix":
+		filter.ReferencePrefix = true
+	default:
+		return filter, apperror.New(apperror.InvalidFieldFormat, "invalid referenceMatch, use exact or prefix")
+	}
+
+	// customerId = PAN pembayar; PAN lengkap di-mask dulu agar cocok dengan yang tersimpan
+	filter.CustomerPAN = util.MaskPAN(req.CustomerID)
+
+	// Status bisa lebih dari satu (PAID,PENDING), dipetakan ke status internal
+	for _, status := range strings.Split(req.Status, ",") {
+		status = strings.TrimSpace(status)
+		if status == "" {
+			continue
+		}
+		internal, ok := s.StatusMapper.Map(util.DefaultGateway, status)
+		if !ok {
+			return filter, apperror.Newf(apperror.InvalidFieldFormat, "invalid status: %s", status)
+		}
+		if !slices.Contains(filter.Statuses, internal) {
+			filter.Statuses = append(filter.Statuses, internal)
+		}
+	}
+
+	// Rentang amount
+	var err error
+	if filter.MinAmount, err = parseAmountFilter("minAmount", req.MinAmount); err != nil {
+		return filter, err
+	}
+	if filter.MaxAmount, err = parseAmountFilter("maxAmount", req.MaxAmount); err != nil {
+		return filter, err
+	}
+	if filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount {
+		return filter, apperror.New(apperror.InvalidFieldFormat, "invalid amount range: minAmount is greater than maxAmount")
+	}
+
+	// Tanggal YYYY-MM-DD dibaca di zona waktu request (default WIB)
+	loc, err := loadLocation(req.Timezone)
+	if err != nil {
+		return filter, err
+	}
+	if filter.CreatedFrom, err = parseDateFilter("startDate", req.StartDate, loc, false); err != nil {
+		return filter, err
+	}
+	if filter.CreatedTo, err = parseDateFilter("endDate", req.EndDate, loc, true); err != nil {
+		return filter, err
+	}
+	if filter.PaidFrom, err = parseDateFilter("paidStartDate", req.PaidStartDate, loc, false); err != nil {
+		return filter, err
+	}
+	if filter.PaidTo, err = parseDateFilter("paidEndDate", req.PaidEndDate, loc, true); err != nil {
+		return filter, err
+	}
+
+	// Sort hanya untuk kolom yang di-whitelist
+	switch req.Sort {
+	case "", model.SortCreatedAt:
+		filter.SortBy = model.SortCreatedAt
+	case model.SortAmount, model.SortPaidDate, model.SortStatus:
+		filter.SortBy = req.Sort
+	default:
+		return filter, apperror.Newf(apperror.InvalidFieldFormat, "invalid sort: %s, use amount, paid_date, status or created_at", req.Sort)
+	}
+	switch strings.ToLower(req.Order) {
+	case "", "desc":
+	case "asc":
+		filter.SortAsc = true
+	default:
+		return filter, apperror.Newf(apperror.InvalidFieldFormat, "invalid order: %s, use asc or desc", req.Order)
+	}
+
+	// Cursor pagination (after/before) menggantikan page/offset
+	if req.After != "" && req.Before != "" {
+		return filter, apperror.New(apperror.InvalidFieldFormat, "invalid cursor: use either after or before, not both")
+	}
+	if req.After != "" {
+		if filter.After, err = decodeCursor(req.After); err != nil {
+			return filter, err
+		}
+	}
+	if req.Before != "" {
+		if filter.Before, err = decodeCursor(req.Before); err != nil {
+			return filter, err
+		}
+	}
+	cursorMode := filter.After != nil || filter.Before != nil
+	if cursorMode {
+		if filter.SortBy != model.SortCreatedAt {
+			return filter, apperror.New(apperror.InvalidFieldFormat, "invalid sort: cursor pagination requires sort=created_at")
+		}
+		filter.Offset = 0
+	}
+
+	// COUNT(*) opsional: default dihitung untuk page/limit, tidak untuk cursor
+	filter.SkipTotal = cursorMode
+	if req.IncludeTotal != nil {
+		filter.SkipTotal = !*req.IncludeTotal
+	}
+
+	return filter, nil
+}
+
+// parseAmountFilter mem-parse batas amount opsional
+func parseAmountFilter(name string, value string) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil || amount < 0 {
+		return nil, apperror.Newf(apperror.InvalidFieldFormat, "invalid %s: %s", name, value)
+	}
+	return &amount, nil
+}
+
+// parseDateFilter mem-parse batas tanggal YYYY-MM-DD (di zona loc) atau RFC3339.
+// Batas akhir bersifat inklusif: tanggal menjadi awal hari berikutnya, timestamp ditambah 1ns,
+// sehingga repository cukup memakai kondisi "< to".
+func parseDateFilter(name string, value string, loc *time.Location, end bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		if end {
+			t = t.Add(time.Nanosecond)
+		}
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, loc)
+	if err != nil {
+		return time.Time{}, apperror.Newf(apperror.InvalidFieldFormat, "invalid %s format, use YYYY-MM-DD or RFC3339", name)
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// UpdateStatus mengubah status transaksi, memposting perubahan dana ke ledger,
+// lalu mem-broadcast hasilnya via WebSocket. trx adalah kondisi sebelum update.
+// Dipakai oleh callback payment dan koreksi hasil rekonsiliasi.
+func (s *TransactionService) UpdateStatus(ctx context.Context, trx model.Transaction, status string, paidTime time.Time) (err error) {
+	ctx, span := tracing.Start(ctx, "TransactionService.UpdateStatus",
+		attribute.String("transaction.reference_no", trx.ReferenceNo),
+		attribute.String("transaction.status.from", trx.Status),
+		attribute.String("transaction.status.to", status),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	// Status dan journal entry disimpan dalam satu database transaction: jika posting gagal status
+	// ikut rollback dan error dikembalikan, sehingga callback ditolak dan dikirim ulang provider
+	var updatedTrx model.Transaction
+	apply := func(ledger *LedgerService, repo repository.TransactionStore) error {
+		if err := repo.UpdateStatus(trx.ReferenceNo, status, paidTime); err != nil {
+			return apperror.Wrap(err, apperror.Internal, "failed to update status")
+		}
+		var err error
+		updatedTrx, err = repo.FindByReferenceNo(trx.ReferenceNo)
+		if err != nil {
+			return apperror.Wrap(err, apperror.Internal, "failed to load updated transaction")
+		}
+
+		if ledger == nil {
+			return nil
+		}
+		if status == "PAID" && trx.Status != "PAID" {
+			return ledger.PostPayment(updatedTrx)
+		}
+		if trx.Status == "PAID" && status != "PAID" {
+			return ledger.PostRefund(trx)
+		}
+		return nil
+	}
+	if s.Ledger != nil {
+		err = s.Ledger.Transaction(ctx, s.Repo, apply)
+	} else {
+		err = apply(nil, s.Repo.WithContext(ctx))
+	}
+	if err != nil {
+		return err
+	}
+
+	metrics.StatusTransitionsTotal.WithLabelValues(trx.Status, status).Inc()
+	if status == "PAID" && trx.Status != "PAID" && !trx.TransactionDate.IsZero() {
+		if elapsed := paidTime.Sub(trx.TransactionDate); elapsed >= 0 {
+			metrics.TimeToPayment.Observe(elapsed.Seconds())
+		}
+	}
+
+	s.broadcastTransactionUpdate(ctx, &updatedTrx)
+	return nil
+}
+
+// broadcastTransactionUpdate mengirim update transaksi via WebSocket. Message membawa
+// traceparent dan request_id agar dashboard bisa dikaitkan dengan trace dan log callback-nya.
+func (s *TransactionService) broadcastTransactionUpdate(ctx context.Context, transaction *model.Transaction) {
 	if s.WSHub != nil {
+		ctx, span := tracing.Start(ctx, "Hub.BroadcastScoped", attribute.String("transaction.reference_no", transaction.ReferenceNo))
+		defer span.End()
+
 		// Prepare data untuk broadcast dengan snake_case
 		updateData := map[string]interface{}{
 			"type":                 "TRANSACTION_UPDATE",
 			"id":                   transaction.ID,
 			"merchant_id":          transaction.MerchantID, // snake_case
+			"outlet_id":            transaction.OutletID,
+			"terminal_id":          transaction.TerminalID,
 			"amount":               transaction.Amount,
 			"paid_date":            transaction.PaidDate,
 			"partner_reference_no": transaction.PartnerReferenceNo, // snake_case
@@ -275,13 +748,26 @@ func (s *TransactionService) broadcastTransactionUpdate(transaction *model.Trans
 			"updated_at":           transaction.UpdatedAt, // snake_case
 			"currency":             transaction.Currency,
 			"trx_id":               transaction.TrxID, // snake_case
+			"issuer_name":          transaction.IssuerName,
+			"customer_pan":         transaction.CustomerPAN, // selalu masked
+			"customer_name":        transaction.CustomerName,
+			"rrn":                  transaction.RRN,
+			"traceparent":          tracing.Traceparent(ctx),
+			"request_id":           logging.RequestID(ctx),
 		}
 
-		// Convert ke JSON dan broadcast
+		// Convert ke JSON dan broadcast hanya ke client yang subscribe merchant/outlet/terminal ini
 		messageBytes, err := json.Marshal(updateData)
 		if err == nil {
-			s.WSHub.Broadcast(messageBytes)
-			log.Printf("📢 Broadcast transaction update: %s - %s", transaction.ReferenceNo, transaction.Status)
+			s.WSHub.BroadcastScoped(messageBytes, ws.Subscription{
+				MerchantID: transaction.MerchantID,
+				OutletID:   transaction.OutletID,
+				TerminalID: transaction.TerminalID,
+			})
+			slog.InfoContext(ctx, "Broadcast transaction update", "reference_no", transaction.ReferenceNo, "status", transaction.Status)
 		}
+
+		// Counter live dashboard ikut diperbarui setiap ada transaksi berubah
+		s.broadcastStatsUpdate(ctx, transaction.MerchantID)
 	}
 }
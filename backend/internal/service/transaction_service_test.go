@@ -0,0 +1,571 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"qr-service/internal/model"
+	"qr-service/internal/repository"
+	"qr-service/pkg/apperror"
+	"qr-service/pkg/metrics"
+	"qr-service/pkg/provider"
+	"qr-service/pkg/util"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestBuildTransactionFilter(t *testing.T) {
+	s := NewTransactionService(repository.NewMemoryTransactionStore(), nil, nil, nil, nil)
+
+	filter, err := s.buildTransactionFilter(model.GetTransactionsRequest{
+		ReferenceNumber: "A00",
+		ReferenceMatch:  "prefix",
+		Status:          "Success, PENDING,PAID",
+		MinAmount:       "1000",
+		MaxAmount:       "50000.50",
+		Currency:        "idr",
+		StartDate:       "2025-09-21",
+		EndDate:         "2025-09-21",
+		PaidStartDate:   "2025-09-21T09:00:00+07:00",
+		PaidEndDate:     "2025-09-21T10:00:00+07:00",
+		Sort:            "amount",
+		Order:           "asc",
+	})
+	if err != nil {
+		t.Fatalf("buildTransactionFilter returned error: %v", err)
+	}
+
+	if !filter.ReferencePrefix || filter.Currency != "IDR" || filter.SortBy != model.SortAmount || !filter.SortAsc {
+		t.Fatalf("unexpected filter: %+v", filter)
+	}
+	if strings.Join(filter.Statuses, ",") != "PAID,PENDING" {
+		t.Fatalf("statuses = %v, want [PAID PENDING]", filter.Statuses)
+	}
+	if *filter.MinAmount != 1000 || *filter.MaxAmount != 50000.50 {
+		t.Fatalf("amount range = %v..%v", *filter.MinAmount, *filter.MaxAmount)
+	}
+
+	// Tanggal dibaca dalam WIB dan endDate inklusif sampai akhir hari
+	wantFrom := time.Date(2025, 9, 20, 17, 0, 0, 0, time.UTC)
+	if !filter.CreatedFrom.Equal(wantFrom) || !filter.CreatedTo.Equal(wantFrom.Add(24*time.Hour)) {
+		t.Fatalf("created range = %v..%v", filter.CreatedFrom, filter.CreatedTo)
+	}
+	if !filter.PaidFrom.Equal(time.Date(2025, 9, 21, 2, 0, 0, 0, time.UTC)) ||
+		!filter.PaidTo.Equal(time.Date(2025, 9, 21, 3, 0, 0, 1, time.UTC)) {
+		t.Fatalf("paid range = %v..%v", filter.PaidFrom, filter.PaidTo)
+	}
+
+	// Zona waktu lain
+	filter, err = s.buildTransactionFilter(model.GetTransactionsRequest{StartDate: "2025-09-21", Timezone: "UTC"})
+	if err != nil || !filter.CreatedFrom.Equal(time.Date(2025, 9, 21, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("UTC start date = %v, %v", filter.CreatedFrom, err)
+	}
+	if filter.SortBy != model.SortCreatedAt || filter.SortAsc {
+		t.Fatalf("default sort = %s asc=%v, want created_at desc", filter.SortBy, filter.SortAsc)
+	}
+}
+
+func TestBuildTransactionFilterRejectsInvalidInput(t *testing.T) {
+	s := NewTransactionService(repository.NewMemoryTransactionStore(), nil, nil, nil, nil)
+
+	cases := map[string]model.GetTransactionsRequest{
+		"status":          {Status: "PAID,UNKNOWN"},
+		"reference match": {ReferenceMatch: "contains"},
+		"min amount":      {MinAmount: "abc"},
+		"negative amount": {MaxAmount: "-1"},
+		"amount range":    {MinAmount: "100", MaxAmount: "10"},
+		"timezone":        {Timezone: "Mars/Base", StartDate: "2025-09-21"},
+		"start date":      {StartDate: "21-09-2025"},
+		"paid end date":   {PaidEndDate: "yesterday"},
+		"sort column":     {Sort: "merchant_id; DROP TABLE transactions"},
+		"order":           {Order: "up"},
+	}
+	for name, req := range cases {
+		if _, err := s.buildTransactionFilter(req); err == nil || !strings.HasPrefix(err.Error(), "invalid ") {
+			t.Errorf("%s: got error %v, want validation error", name, err)
+		}
+	}
+}
+
+func TestGetTransactionsCursorPagination(t *testing.T) {
+	store := repository.NewMemoryTransactionStore()
+	s := NewTransactionService(store, nil, nil, nil, nil)
+	for i := 1; i <= 7; i++ {
+		ref := fmt.Sprintf("A%03d", i)
+		if _, err := store.Save(model.Transaction{
+			MerchantID: "M001", Amount: 10000, TrxID: "TRX-" + ref, PartnerReferenceNo: "P" + ref, ReferenceNo: ref,
+		}); err != nil {
+			t.Fatalf("Save returned error: %v", err)
+		}
+	}
+
+	// Halaman pertama pakai page/limit, lalu lanjut dengan cursor next sampai habis
+	resp, err := s.GetTransactions(model.GetTransactionsRequest{Limit: 3})
+	if err != nil {
+		t.Fatalf("GetTransactions returned error: %v", err)
+	}
+	if resp.Pagination == nil || resp.Pagination.Total != 7 || resp.Cursor.HasPrev || !resp.Cursor.HasNext {
+		t.Fatalf("unexpected first page: %+v %+v", resp.Pagination, resp.Cursor)
+	}
+
+	var seen []string
+	pages := 0
+	for {
+		pages++
+		for _, trx := range resp.Data {
+			seen = append(seen, trx.ReferenceNo)
+		}
+		if !resp.Cursor.HasNext {
+			break
+		}
+		resp, err = s.GetTransactions(model.GetTransactionsRequest{Limit: 3, After: resp.Cursor.Next})
+		if err != nil {
+			t.Fatalf("GetTransactions(after) returned error: %v", err)
+		}
+		if resp.Pagination != nil {
+			t.Fatalf("cursor mode should skip total by default")
+		}
+	}
+	if pages != 3 || strings.Join(seen, ",") != "A007,A006,A005,A004,A003,A002,A001" {
+		t.Fatalf("pages = %d, seen = %v", pages, seen)
+	}
+
+	// Kembali ke halaman sebelumnya dari halaman terakhir
+	resp, err = s.GetTransactions(model.GetTransactionsRequest{Limit: 3, Before: resp.Cursor.Prev, IncludeTotal: &[]bool{true}[0]})
+	if err != nil {
+		t.Fatalf("GetTransactions(before) returned error: %v", err)
+	}
+	var refs []string
+	for _, trx := range resp.Data {
+		refs = append(refs, trx.ReferenceNo)
+	}
+	if strings.Join(refs, ",") != "A004,A003,A002" || !resp.Cursor.HasPrev || !resp.Cursor.HasNext || resp.Pagination.Total != 7 {
+		t.Fatalf("previous page = %v, cursor %+v", refs, resp.Cursor)
+	}
+
+	// Cursor tidak valid atau dipakai dengan sort lain ditolak
+	for _, req := range []model.GetTransactionsRequest{
+		{After: "not-a-cursor"},
+		{After: resp.Cursor.Next, Before: resp.Cursor.Prev},
+		{After: resp.Cursor.Next, Sort: "amount"},
+	} {
+		if _, err := s.GetTransactions(req); err == nil || !strings.HasPrefix(err.Error(), "invalid ") {
+			t.Errorf("GetTransactions(%+v) error = %v, want validation error", req, err)
+		}
+	}
+}
+
+func TestProcessPaymentCallbackStoresPayerInfo(t *testing.T) {
+	store := repository.NewMemoryTransactionStore()
+	s := NewTransactionService(store, nil, nil, nil, nil)
+	if _, err := store.Save(model.Transaction{
+		MerchantID: "M001", Amount: 10000, TrxID: "TRX-P001", PartnerReferenceNo: "P001", ReferenceNo: "A001",
+	}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	_, err := s.ProcessPaymentCallback(context.Background(), model.PaymentCallbackRequest{
+		OriginalReferenceNo:        "A001",
+		OriginalPartnerReferenceNo: "P001",
+		TransactionStatusDesc:      "Success",
+		PaidTime:                   "2025-09-21T10:00:00+07:00",
+		Amount:                     model.Amount{Value: "10000.00", Currency: "IDR"},
+		AdditionalInfo: &model.PaymentAdditionalInfo{
+			IssuerID:     "93600014",
+			IssuerName:   "BCA",
+			CustomerPAN:  "9360001412345671234",
+			CustomerName: "BUDI SANTOSO",
+			RRN:          "123456789012",
+			ApprovalCode: "A1B2C3",
+		},
+	})
+	if err != nil {
+		t.Fatalf("ProcessPaymentCallback returned error: %v", err)
+	}
+
+	trx, _ := store.FindByReferenceNo("A001")
+	if trx.Status != "PAID" || trx.CustomerPAN != "936000*********1234" || trx.RRN != "123456789012" || trx.IssuerName != "BCA" {
+		t.Fatalf("unexpected transaction after callback: %+v", trx)
+	}
+
+	// customerId menerima PAN lengkap maupun masked, search juga mencakup RRN dan nama pembayar
+	for _, req := range []model.GetTransactionsRequest{
+		{CustomerID: "9360001412345671234"},
+		{CustomerID: "936000*********1234"},
+		{RRN: "123456789012"},
+		{Search: "BUDI"},
+	} {
+		resp, err := s.GetTransactions(req)
+		if err != nil {
+			t.Fatalf("GetTransactions(%+v) returned error: %v", req, err)
+		}
+		if len(resp.Data) != 1 || resp.Data[0].ApprovalCode != "A1B2C3" {
+			t.Fatalf("GetTransactions(%+v) = %+v", req, resp.Data)
+		}
+	}
+}
+
+func TestProcessPaymentEventMapsProviderStatus(t *testing.T) {
+	store := repository.NewMemoryTransactionStore()
+	s := NewTransactionService(store, nil, nil, nil, nil)
+	s.Providers = provider.NewRegistry(provider.NewSNAP(provider.SNAPOptions{Name: "gw-1"}), provider.NewSNAP(provider.SNAPOptions{Name: "gw-2"}))
+	s.StatusMapper = util.NewStatusMapper(map[string]util.StatusMapping{"gw-1": {"99": util.StatusFailed}})
+	for ref, name := range map[string]string{"A001": "gw-2", "A002": "gw-1", "A003": "gw-2", "A004": ""} {
+		if _, err := store.Save(model.Transaction{
+			MerchantID: "M001", Amount: 10000, TrxID: "TRX-P" + ref, PartnerReferenceNo: "P" + ref, ReferenceNo: ref, Provider: name,
+		}); err != nil {
+			t.Fatalf("Save returned error: %v", err)
+		}
+	}
+	apply := func(ref, name, status string) error {
+		_, err := s.ProcessPaymentEvent(context.Background(), provider.PaymentEvent{
+			Provider:           name,
+			ReferenceNo:        ref,
+			PartnerReferenceNo: "P" + ref,
+			Status:             status,
+			Amount:             "10000.00",
+			Currency:           "IDR",
+			PaidTime:           "2025-09-21T10:00:00+07:00",
+		})
+		return err
+	}
+
+	// Kode SNAP tabel default
+	if err := apply("A001", "gw-2", "00"); err != nil {
+		t.Fatalf("status 00 returned error: %v", err)
+	}
+	// Kode khusus provider
+	if err := apply("A002", "gw-1", "99"); err != nil {
+		t.Fatalf("provider status code returned error: %v", err)
+	}
+	// Status tidak dikenal ditolak, bukan diam-diam PENDING; kode provider lain tidak berlaku
+	if err := apply("A003", "gw-2", "99"); apperror.CodeOf(err) != apperror.InvalidTransactionStatus {
+		t.Fatalf("unknown status: error = %v, want INVALID_TRANSACTION_STATUS", err)
+	}
+	// Event dari provider lain ditolak; transaksi lama tanpa provider milik provider default
+	if err := apply("A003", "gw-1", "99"); apperror.CodeOf(err) != apperror.InconsistentRequest {
+		t.Fatalf("provider mismatch: error = %v, want INCONSISTENT_REQUEST", err)
+	}
+	if err := apply("A004", "gw-1", "Success"); err != nil {
+		t.Fatalf("legacy transaction returned error: %v", err)
+	}
+
+	for ref, want := range map[string]string{"A001": util.StatusPaid, "A002": util.StatusFailed, "A003": util.StatusPending, "A004": util.StatusPaid} {
+		if trx, _ := store.FindByReferenceNo(ref); trx.Status != want {
+			t.Errorf("%s status = %s, want %s", ref, trx.Status, want)
+		}
+	}
+}
+
+func TestInquireStatusAppliesProviderResult(t *testing.T) {
+	store := repository.NewMemoryTransactionStore()
+	s := NewTransactionService(store, nil, nil, nil, nil)
+	s.Providers = provider.NewRegistry(&fakeProvider{name: "acme", event: provider.PaymentEvent{
+		ReferenceNo: "A001", PartnerReferenceNo: "PA001", Status: "Success", StatusDesc: "Success",
+		Amount: "10000.00", Currency: "IDR", PaidTime: "2025-09-21T10:00:00+07:00",
+	}}, provider.NewSNAP(provider.SNAPOptions{Name: "manjo"}))
+	for ref, name := range map[string]string{"A001": "acme", "A002": "manjo"} {
+		if _, err := store.Save(model.Transaction{
+			MerchantID: "M001", Amount: 10000, TrxID: "TRX-P" + ref, PartnerReferenceNo: "P" + ref, ReferenceNo: ref, Provider: name, Status: util.StatusPending,
+		}); err != nil {
+			t.Fatalf("Save returned error: %v", err)
+		}
+	}
+
+	if _, err := s.InquireStatus(context.Background(), "A001", "M999"); apperror.CodeOf(err) != apperror.TransactionNotFound {
+		t.Fatalf("InquireStatus for another merchant = %v, want TRANSACTION_NOT_FOUND", err)
+	}
+	resp, err := s.InquireStatus(context.Background(), "A001", "")
+	if err != nil {
+		t.Fatalf("InquireStatus returned error: %v", err)
+	}
+	if resp.Status != util.StatusPaid || resp.Provider != "acme" {
+		t.Fatalf("InquireStatus = %+v", resp)
+	}
+
+	// Provider SNAP tanpa inquiry_url
+	if _, err := s.InquireStatus(context.Background(), "A002", ""); apperror.CodeOf(err) != apperror.FunctionNotSupported {
+		t.Fatalf("InquireStatus without inquiry URL: error = %v, want FUNCTION_NOT_SUPPORTED", err)
+	}
+}
+
+func TestGenerateQRMetricLabelsUnresolvedMerchantUnknown(t *testing.T) {
+	s := NewTransactionService(repository.NewMemoryTransactionStore(), nil, nil, nil, nil)
+	req := model.GenerateQRRequest{PartnerReferenceNo: "P-METRIC", Amount: model.Amount{Value: "abc", Currency: "IDR"}, MerchantID: "M-METRIC"}
+
+	unknown := testutil.ToFloat64(metrics.QRGenerateTotal.WithLabelValues(metrics.UnknownMerchant, string(apperror.InvalidFieldFormat)))
+	if _, err := s.GenerateQR(context.Background(), req); err == nil {
+		t.Fatal("GenerateQR accepted an invalid amount")
+	}
+	if got := testutil.ToFloat64(metrics.QRGenerateTotal.WithLabelValues(metrics.UnknownMerchant, string(apperror.InvalidFieldFormat))); got != unknown+1 {
+		t.Fatalf("unknown merchant counter = %v, want %v", got, unknown+1)
+	}
+	if got := testutil.ToFloat64(metrics.QRGenerateTotal.WithLabelValues("M-METRIC", string(apperror.InvalidFieldFormat))); got != 0 {
+		t.Fatalf("rejected request created series for client merchantId (%v)", got)
+	}
+
+	req.Amount.Value = "10000.00"
+	if _, err := s.GenerateQR(context.Background(), req); err != nil {
+		t.Fatalf("GenerateQR returned error: %v", err)
+	}
+	if got := testutil.ToFloat64(metrics.QRGenerateTotal.WithLabelValues("M-METRIC", "success")); got != 1 {
+		t.Fatalf("saved merchant counter = %v, want 1", got)
+	}
+}
+
+func TestUpdateStatusRollsBackWhenLedgerPostFails(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "ledger.db")), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	// Tabel ledger sengaja belum dibuat sehingga posting gagal
+	if err := db.AutoMigrate(&model.Transaction{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	store := repository.NewTransactionRepository(db)
+	trx, err := store.Save(model.Transaction{
+		MerchantID: "M001", Amount: 10000, TrxID: "TRX-P1", PartnerReferenceNo: "P1", ReferenceNo: "A001", Status: "PENDING", TransactionDate: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	s := NewTransactionService(store, nil, nil, NewLedgerService(repository.NewLedgerRepository(db)), nil)
+
+	if err := s.UpdateStatus(context.Background(), trx, "PAID", time.Now()); err == nil {
+		t.Fatal("UpdateStatus succeeded although the ledger post failed")
+	}
+	if got, _ := store.FindByReferenceNo("A001"); got.Status != "PENDING" {
+		t.Fatalf("status = %s, want PENDING after rollback", got.Status)
+	}
+
+	if err := db.AutoMigrate(&model.LedgerAccount{}, &model.JournalEntry{}, &model.Posting{}); err != nil {
+		t.Fatalf("migrate ledger: %v", err)
+	}
+	if err := s.UpdateStatus(context.Background(), trx, "PAID", time.Now()); err != nil {
+		t.Fatalf("UpdateStatus returned error: %v", err)
+	}
+	paid, _ := store.FindByReferenceNo("A001")
+	if paid.Status != "PAID" {
+		t.Fatalf("status = %s, want PAID", paid.Status)
+	}
+	if entry, err := s.Ledger.Repo.FindEntryByReference("PAYMENT:" + paymentKey(paid)); err != nil || entry == nil {
+		t.Fatalf("payment entry = %v, %v; want posted", entry, err)
+	}
+}
+
+func TestRiskAndLedgerUseInjectedStore(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "store.db")), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	err = db.AutoMigrate(&model.Transaction{}, &model.RiskLimit{}, &model.RiskRuleHit{}, &model.RiskMerchantLock{},
+		&model.LedgerAccount{}, &model.JournalEntry{}, &model.Posting{})
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	// Insert di bawah lock risk dan update status bersama posting ledger harus lewat store milik service
+	store := repository.NewMemoryTransactionStore()
+	s := NewTransactionService(store, nil, NewRiskService(repository.NewRiskRepository(db)), NewLedgerService(repository.NewLedgerRepository(db)), nil)
+
+	_, err = s.GenerateQR(context.Background(), model.GenerateQRRequest{
+		PartnerReferenceNo: "P01", Amount: model.Amount{Value: "10000.00", Currency: "IDR"}, MerchantID: "M001",
+	})
+	if err != nil {
+		t.Fatalf("GenerateQR returned error: %v", err)
+	}
+	trx, err := store.FindByPartnerReference("P01")
+	if err != nil || trx == nil {
+		t.Fatalf("transaction in injected store = %v, %v; want saved", trx, err)
+	}
+	var inDB int64
+	db.Model(&model.Transaction{}).Count(&inDB)
+	if inDB != 0 {
+		t.Fatalf("%d transactions written to the risk database, want 0", inDB)
+	}
+
+	if err := s.UpdateStatus(context.Background(), *trx, "PAID", time.Now()); err != nil {
+		t.Fatalf("UpdateStatus returned error: %v", err)
+	}
+	paid, _ := store.FindByReferenceNo(trx.ReferenceNo)
+	if paid.Status != "PAID" {
+		t.Fatalf("status in injected store = %s, want PAID", paid.Status)
+	}
+	if entry, err := s.Ledger.Repo.FindEntryByReference("PAYMENT:" + paymentKey(paid)); err != nil || entry == nil {
+		t.Fatalf("payment entry = %v, %v; want posted", entry, err)
+	}
+}
+
+func TestGenerateQRRoutesMerchantToProvider(t *testing.T) {
+	store := repository.NewMemoryTransactionStore()
+	s := NewTransactionService(store, nil, nil, nil, nil)
+	s.Providers = provider.NewRegistry(provider.NewSNAP(provider.SNAPOptions{Name: "manjo"}), &fakeProvider{name: "acme"})
+	s.Providers.Route("M002", "acme")
+
+	for merchantID, want := range map[string]string{"M001": "manjo", "M002": "acme"} {
+		resp, err := s.GenerateQR(context.Background(), model.GenerateQRRequest{
+			PartnerReferenceNo: "P-" + merchantID,
+			Amount:             model.Amount{Value: "10000.00", Currency: "IDR"},
+			MerchantID:         merchantID,
+		})
+		if err != nil {
+			t.Fatalf("GenerateQR(%s) returned error: %v", merchantID, err)
+		}
+		trx, _ := store.FindByReferenceNo(resp.ReferenceNo)
+		if trx.Provider != want {
+			t.Errorf("%s provider = %q, want %q", merchantID, trx.Provider, want)
+		}
+		if want == "acme" && resp.QRContent != "acme:"+resp.ReferenceNo {
+			t.Errorf("%s QR = %q, want QR from acme", merchantID, resp.QRContent)
+		}
+	}
+}
+
+// fakeProvider provider dengan hasil inquiry tetap
+type fakeProvider struct {
+	name  string
+	event provider.PaymentEvent
+}
+
+func (p *fakeProvider) Name() string                                           { return p.name }
+func (p *fakeProvider) BuildQR(req provider.QRRequest) string                  { return p.name + ":" + req.ReferenceNo }
+func (p *fakeProvider) AuthenticateCallback(func(string) string, []byte) error { return nil }
+func (p *fakeProvider) ParseCallback([]byte) (provider.PaymentEvent, error)    { return p.event, nil }
+func (p *fakeProvider) InquireStatus(context.Context, provider.InquiryRequest) (provider.PaymentEvent, error) {
+	return p.event, nil
+}
+
+func TestGetStats(t *testing.T) {
+	store := repository.NewMemoryTransactionStore()
+	s := NewTransactionService(store, nil, nil, nil, nil)
+	day := time.Date(2025, 9, 21, 0, 0, 0, 0, util.WIB)
+	for i, amount := range []float64{10000, 30000, 5000, 7000} {
+		ref := fmt.Sprintf("A%03d", i+1)
+		trx := model.Transaction{MerchantID: "M001", Amount: amount, TrxID: "TRX-" + ref, PartnerReferenceNo: "P" + ref, ReferenceNo: ref}
+		trx.CreatedAt = day.AddDate(0, 0, i)
+		if _, err := store.Save(trx); err != nil {
+			t.Fatalf("Save returned error: %v", err)
+		}
+	}
+	for _, ref := range []string{"A001", "A002"} {
+		if err := store.UpdateStatus(ref, "PAID", day); err != nil {
+			t.Fatalf("UpdateStatus returned error: %v", err)
+		}
+	}
+
+	resp, err := s.GetStats(model.GetTransactionStatsRequest{MerchantID: "M001", StartDate: "2025-09-21", EndDate: "2025-09-23"})
+	if err != nil {
+		t.Fatalf("GetStats returned error: %v", err)
+	}
+	stats := resp.Data
+	if stats.Interval != model.IntervalDay || stats.TotalCount != 3 || stats.PaidCount != 2 || stats.PaidAmount != 40000 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if stats.AverageTicket != 20000 || stats.SuccessRate != 2.0/3.0 {
+		t.Fatalf("average ticket = %v, success rate = %v", stats.AverageTicket, stats.SuccessRate)
+	}
+	if len(stats.Series) != 3 || stats.Series[2].Count != 1 || stats.Series[2].PaidCount != 0 {
+		t.Fatalf("series = %+v", stats.Series)
+	}
+
+	// Bucket per jam di hari tanpa transaksi tetap lengkap 24 titik bernilai nol
+	resp, err = s.GetStats(model.GetTransactionStatsRequest{MerchantID: "M001", StartDate: "2025-09-25"})
+	if err != nil {
+		t.Fatalf("GetStats returned error: %v", err)
+	}
+	if resp.Data.Interval != model.IntervalHour || len(resp.Data.Series) != 24 || resp.Data.TotalCount != 0 {
+		t.Fatalf("empty day stats = %+v", resp.Data)
+	}
+
+	for _, req := range []model.GetTransactionStatsRequest{
+		{Interval: "minute"},
+		{StartDate: "2025-09-22", EndDate: "2025-09-21"},
+		{StartDate: "2024-01-01", EndDate: "2025-12-31", Interval: model.IntervalHour},
+	} {
+		if _, err := s.GetStats(req); err == nil || !strings.HasPrefix(err.Error(), "invalid ") {
+			t.Errorf("GetStats(%+v) error = %v, want validation error", req, err)
+		}
+	}
+}
+
+func TestStatsDebouncerCoalescesPerMerchant(t *testing.T) {
+	d := statsDebouncer{delay: 20 * time.Millisecond}
+	var mu sync.Mutex
+	calls := map[string]int{}
+	record := func(merchantID string) func() {
+		return func() {
+			mu.Lock()
+			calls[merchantID]++
+			mu.Unlock()
+		}
+	}
+
+	for range 100 {
+		d.schedule("M001", record("M001"))
+	}
+	d.schedule("M002", record("M002"))
+	time.Sleep(100 * time.Millisecond)
+
+	// Perubahan setelah perhitungan berjalan dijadwalkan lagi
+	d.schedule("M001", record("M001"))
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if calls["M001"] != 2 || calls["M002"] != 1 {
+		t.Fatalf("calls = %v, want M001:2 M002:1", calls)
+	}
+}
+
+func TestExportTransactionsCSV(t *testing.T) {
+	store := repository.NewMemoryTransactionStore()
+	s := NewTransactionService(store, nil, nil, nil, nil)
+	for i, amount := range []float64{10000, 25000} {
+		ref := fmt.Sprintf("A%03d", i+1)
+		trx := model.Transaction{MerchantID: "M001", Amount: amount, TrxID: "TRX-" + ref, PartnerReferenceNo: "P" + ref, ReferenceNo: ref}
+		trx.CreatedAt = time.Date(2025, 9, 21, 2, i, 0, 0, time.UTC)
+		if _, err := store.Save(trx); err != nil {
+			t.Fatalf("Save returned error: %v", err)
+		}
+	}
+	if err := store.UpdateStatus("A002", "PAID", time.Date(2025, 9, 21, 2, 30, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("UpdateStatus returned error: %v", err)
+	}
+
+	exp, err := s.ExportTransactions(model.GetTransactionsRequest{MerchantID: "M001", Sort: "amount", Order: "asc", Page: 5, Limit: 1}, "CSV")
+	if err != nil {
+		t.Fatalf("ExportTransactions returned error: %v", err)
+	}
+	if exp.Format != "csv" || !strings.HasSuffix(exp.Filename, ".csv") {
+		t.Fatalf("unexpected export: %+v", exp)
+	}
+
+	var buf strings.Builder
+	if err := exp.Write(&buf); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 8 {
+		t.Fatalf("got %d lines:\n%s", len(lines), buf.String())
+	}
+	// Page/limit diabaikan, tanggal dalam WIB, footer berisi total
+	if !strings.Contains(lines[0], "Created At (WIB)") ||
+		!strings.HasPrefix(lines[1], "A001,") ||
+		!strings.HasPrefix(lines[2], "A002,") || !strings.Contains(lines[2], ",PAID,25000.00,IDR,") ||
+		!strings.Contains(lines[2], "21/09/2025 09:30:00") {
+		t.Fatalf("unexpected rows:\n%s", buf.String())
+	}
+	if lines[4] != "Total Transactions,2" || lines[5] != "Total Amount,35000.00" || lines[7] != "Total Paid Amount,25000.00" {
+		t.Fatalf("unexpected footer:\n%s", strings.Join(lines[3:], "\n"))
+	}
+
+	if _, err := s.ExportTransactions(model.GetTransactionsRequest{}, "pdf"); err == nil || !strings.HasPrefix(err.Error(), "invalid ") {
+		t.Fatalf("ExportTransactions(pdf) error = %v, want validation error", err)
+	}
+}
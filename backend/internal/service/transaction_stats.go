@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"qr-service/internal/model"
+	"qr-service/pkg/apperror"
+	"qr-service/pkg/snap"
+	"qr-service/pkg/util"
+	"sync"
+	"time"
+
+	ws "qr-service/pkg/websocket"
+)
+
+// maxStatsBuckets batas jumlah titik time series agar response tetap kecil
+const maxStatsBuckets = 1000
+
+// Implementasi Endpoint GET /api/v1/transactions/stats
+func (s *TransactionService) GetStats(req model.GetTransactionStatsRequest) (*model.TransactionStatsResponse, error) {
+	loc, err := loadLocation(req.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	// Default rentang: hari ini di zona waktu request
+	from, err := parseDateFilter("startDate", req.StartDate, loc, false)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseDateFilter("endDate", req.EndDate, loc, true)
+	if err != nil {
+		return nil, err
+	}
+	if from.IsZero() {
+		now := time.Now().In(loc)
+		from = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+		if !to.IsZero() && !to.After(from) {
+			from = to.AddDate(0, 0, -1)
+		}
+	}
+	if to.IsZero() {
+		to = from.AddDate(0, 0, 1)
+	}
+	if !to.After(from) {
+		return nil, apperror.New(apperror.InvalidFieldFormat, "invalid date range: endDate is before startDate")
+	}
+
+	// Interval default: per jam untuk rentang <= 2 hari, selain itu per hari
+	interval := req.Interval
+	if interval == "" {
+		interval = model.IntervalDay
+		if to.Sub(from) <= 48*time.Hour {
+			interval = model.IntervalHour
+		}
+	}
+	origin, step, err := statsBuckets(from.In(loc), interval)
+	if err != nil {
+		return nil, err
+	}
+	if to.Sub(origin)/step > maxStatsBuckets {
+		return nil, apperror.Newf(apperror.InvalidFieldFormat, "invalid interval: %s produces more than %d buckets for this date range", interval, maxStatsBuckets)
+	}
+
+	aggregates, err := s.Repo.GetStats(model.TransactionStatsQuery{
+		Filter: model.TransactionFilter{
+			MerchantID:  req.MerchantID,
+			OutletID:    req.OutletID,
+			TerminalID:  req.TerminalID,
+			CreatedFrom: from,
+			CreatedTo:   to,
+		},
+		Origin:   origin,
+		Interval: step,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := summarizeStats(aggregates.ByStatus)
+	stats.MerchantID, stats.OutletID, stats.TerminalID = req.MerchantID, req.OutletID, req.TerminalID
+	stats.From, stats.To = from.In(loc), to.In(loc)
+	stats.Interval = interval
+	stats.Series = fillStatsSeries(aggregates.Series, origin, to, step, loc)
+
+	return &model.TransactionStatsResponse{
+		ResponseCode:    snap.Internal.SuccessCode(),
+		ResponseMessage: "Success",
+		Data:            stats,
+	}, nil
+}
+
+// loadLocation zona waktu IANA dari request, default WIB
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return util.WIB, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, apperror.Newf(apperror.InvalidFieldFormat, "invalid timezone: %s", name)
+	}
+	return loc, nil
+}
+
+// statsBuckets menentukan awal bucket pertama (dibulatkan ke bawah di zona waktu from) dan panjang bucket.
+// Bucket memakai durasi tetap, sehingga di zona dengan DST batas hari bisa bergeser satu jam.
+func statsBuckets(from time.Time, interval string) (time.Time, time.Duration, error) {
+	loc := from.Location()
+	switch interval {
+	case model.IntervalHour:
+		return time.Date(from.Year(), from.Month(), from.Day(), from.Hour(), 0, 0, 0, loc), time.Hour, nil
+	case model.IntervalDay:
+		return time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc), 24 * time.Hour, nil
+	case model.IntervalWeek:
+		// Minggu dimulai hari Senin
+		offset := (int(from.Weekday()) + 6) % 7
+		return time.Date(from.Year(), from.Month(), from.Day()-offset, 0, 0, 0, 0, loc), 7 * 24 * time.Hour, nil
+	default:
+		return time.Time{}, 0, apperror.Newf(apperror.InvalidFieldFormat, "invalid interval: %s, use hour, day or week", interval)
+	}
+}
+
+// summarizeStats menghitung total, success rate dan rata-rata ticket dari agregat per status
+func summarizeStats(byStatus []model.StatusStats) model.TransactionStats {
+	stats := model.TransactionStats{ByStatus: byStatus}
+	for _, stat := range byStatus {
+		stats.TotalCount += stat.Count
+		stats.TotalAmount += stat.Amount
+		if stat.Status == "PAID" {
+			stats.PaidCount, stats.PaidAmount = stat.Count, stat.Amount
+		}
+	}
+	if stats.TotalCount > 0 {
+		stats.SuccessRate = float64(stats.PaidCount) / float64(stats.TotalCount)
+	}
+	if stats.PaidCount > 0 {
+		stats.AverageTicket = stats.PaidAmount / float64(stats.PaidCount)
+	}
+	return stats
+}
+
+// fillStatsSeries melengkapi bucket kosong dengan nol agar grafik dashboard kontinu
+func fillStatsSeries(series []model.StatsBucket, origin time.Time, to time.Time, step time.Duration, loc *time.Location) []model.StatsBucket {
+	filled := []model.StatsBucket{}
+	i := 0
+	for start := origin; start.Before(to); start = start.Add(step) {
+		bucket := model.StatsBucket{Start: start.In(loc)}
+		if i < len(series) && series[i].Start.Equal(start) {
+			bucket = series[i]
+			bucket.Start = start.In(loc)
+			i++
+		}
+		filled = append(filled, bucket)
+	}
+	return filled
+}
+
+// statsDebounce jeda sebelum counter live merchant dihitung ulang. Perubahan transaksi merchant
+// yang sama selama jeda ini digabung menjadi satu query agregasi.
+const statsDebounce = time.Second
+
+// statsDebouncer menjadwalkan paling banyak satu perhitungan tertunda per merchant
+type statsDebouncer struct {
+	delay time.Duration // 0 berarti statsDebounce
+
+	mu      sync.Mutex
+	pending map[string]bool
+}
+
+// schedule menjalankan fn setelah jeda di goroutine terpisah, kecuali merchant sudah
+// punya jadwal yang belum berjalan. Jadwal dihapus sebelum fn dipanggil, jadi perubahan yang
+// datang selama query berjalan memicu perhitungan berikutnya.
+func (d *statsDebouncer) schedule(merchantID string, fn func()) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.pending[merchantID] {
+		return
+	}
+	if d.pending == nil {
+		d.pending = make(map[string]bool)
+	}
+	d.pending[merchantID] = true
+
+	delay := d.delay
+	if delay == 0 {
+		delay = statsDebounce
+	}
+	time.AfterFunc(delay, func() {
+		d.mu.Lock()
+		delete(d.pending, merchantID)
+		d.mu.Unlock()
+		fn()
+	})
+}
+
+// broadcastStatsUpdate menjadwalkan pengiriman counter hari ini (WIB) milik merchant setelah ada
+// transaksi berubah. Query agregasi berjalan di luar request, jadi callback tidak menunggunya.
+// Hanya dijadwalkan jika ada client yang terhubung.
+func (s *TransactionService) broadcastStatsUpdate(ctx context.Context, merchantID string) {
+	if s.WSHub == nil || s.WSHub.GetClientCount() == 0 {
+		return
+	}
+	// Trace dan request_id tetap terbawa, tetapi tidak ikut dibatalkan saat request selesai
+	ctx = context.WithoutCancel(ctx)
+	s.statsUpdates.schedule(merchantID, func() { s.sendStatsUpdate(ctx, merchantID) })
+}
+
+// sendStatsUpdate menghitung dan mengirim counter hari ini (WIB) milik merchant
+func (s *TransactionService) sendStatsUpdate(ctx context.Context, merchantID string) {
+	if s.WSHub.GetClientCount() == 0 {
+		return
+	}
+
+	from := util.StartOfDay(time.Now())
+	aggregates, err := s.Repo.WithContext(ctx).GetStats(model.TransactionStatsQuery{
+		Filter: model.TransactionFilter{MerchantID: merchantID, CreatedFrom: from, CreatedTo: from.AddDate(0, 0, 1)},
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to compute live stats", "merchant_id", merchantID, "error", err)
+		return
+	}
+	stats := summarizeStats(aggregates.ByStatus)
+
+	messageBytes, err := json.Marshal(map[string]interface{}{
+		"type":           "STATS_UPDATE",
+		"merchant_id":    merchantID,
+		"date":           from.Format("2006-01-02"),
+		"total_count":    stats.TotalCount,
+		"total_amount":   stats.TotalAmount,
+		"paid_count":     stats.PaidCount,
+		"paid_amount":    stats.PaidAmount,
+		"success_rate":   stats.SuccessRate,
+		"average_ticket": stats.AverageTicket,
+		"by_status":      stats.ByStatus,
+		"timestamp":      time.Now().Unix(),
+	})
+	if err == nil {
+		// Counter level merchant, jadi hanya client yang subscribe merchant ini (atau semua) yang menerima
+		s.WSHub.BroadcastScoped(messageBytes, ws.Subscription{MerchantID: merchantID})
+	}
+}
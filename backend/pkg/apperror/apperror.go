@@ -0,0 +1,250 @@
+// Package apperror error domain bertipe. Service mengembalikan *Error dengan Code,
+// handler cukup meneruskan error-nya, dan error handler Fiber memetakan Code ke
+// HTTP status serta response code SNAP (HTTP status + service code + case code).
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Code jenis error domain. Pemetaan ke HTTP status, case code dan pesan standar SNAP
+// BI ada di tabel codes.
+type Code string
+
+// Katalog response code SNAP BI (HTTP status + service code + case code).
+// NotFound, Conflict dan Internal juga dipakai endpoint non-SNAP.
+const (
+	BadRequest            Code = "BAD_REQUEST"             // 400xx00
+	InvalidFieldFormat    Code = "INVALID_FIELD_FORMAT"    // 400xx01
+	InvalidMandatoryField Code = "INVALID_MANDATORY_FIELD" // 400xx02
+
+	Unauthorized          Code = "UNAUTHORIZED"             // 401xx00
+	InvalidToken          Code = "INVALID_TOKEN"            // 401xx01
+	InvalidCustomerToken  Code = "INVALID_CUSTOMER_TOKEN"   // 401xx02
+	TokenNotFound         Code = "TOKEN_NOT_FOUND"          // 401xx03
+	CustomerTokenNotFound Code = "CUSTOMER_TOKEN_NOT_FOUND" // 401xx04
+
+	TransactionExpired      Code = "TRANSACTION_EXPIRED"       // 403xx00
+	FeatureNotAllowed       Code = "FEATURE_NOT_ALLOWED"       // 403xx01
+	AmountLimitExceeded     Code = "AMOUNT_LIMIT_EXCEEDED"     // 403xx02
+	SuspectedFraud          Code = "SUSPECTED_FRAUD"           // 403xx03
+	ActivityLimitExceeded   Code = "ACTIVITY_LIMIT_EXCEEDED"   // 403xx04
+	DoNotHonor              Code = "DO_NOT_HONOR"              // 403xx05
+	FeatureNotAllowedNow    Code = "FEATURE_NOT_ALLOWED_NOW"   // 403xx06
+	CardBlocked             Code = "CARD_BLOCKED"              // 403xx07
+	CardExpired             Code = "CARD_EXPIRED"              // 403xx08
+	DormantAccount          Code = "DORMANT_ACCOUNT"           // 403xx09
+	NeedToSetTokenLimit     Code = "NEED_TO_SET_TOKEN_LIMIT"   // 403xx10
+	OTPBlocked              Code = "OTP_BLOCKED"               // 403xx11
+	OTPLifetimeExpired      Code = "OTP_LIFETIME_EXPIRED"      // 403xx12
+	OTPSentToCardholder     Code = "OTP_SENT_TO_CARDHOLDER"    // 403xx13
+	InsufficientFunds       Code = "INSUFFICIENT_FUNDS"        // 403xx14
+	TransactionNotPermitted Code = "TRANSACTION_NOT_PERMITTED" // 403xx15
+	SuspendTransaction      Code = "SUSPEND_TRANSACTION"       // 403xx16
+	TokenLimitExceeded      Code = "TOKEN_LIMIT_EXCEEDED"      // 403xx17
+	InactiveAccount         Code = "INACTIVE_ACCOUNT"          // 403xx18
+	MerchantBlacklisted     Code = "MERCHANT_BLACKLISTED"      // 403xx19
+	MerchantLimitExceeded   Code = "MERCHANT_LIMIT_EXCEEDED"   // 403xx20
+	SetLimitNotAllowed      Code = "SET_LIMIT_NOT_ALLOWED"     // 403xx21
+	TokenLimitInvalid       Code = "TOKEN_LIMIT_INVALID"       // 403xx22
+	AccountLimitExceeded    Code = "ACCOUNT_LIMIT_EXCEEDED"    // 403xx23
+
+	NotFound                 Code = "NOT_FOUND"                  // 404xx00, resource non-SNAP
+	InvalidTransactionStatus Code = "INVALID_TRANSACTION_STATUS" // 404xx00
+	TransactionNotFound      Code = "TRANSACTION_NOT_FOUND"      // 404xx01
+	InvalidRouting           Code = "INVALID_ROUTING"            // 404xx02
+	BankNotSupported         Code = "BANK_NOT_SUPPORTED"         // 404xx03
+	TransactionCancelled     Code = "TRANSACTION_CANCELLED"      // 404xx04
+	MerchantNotRegistered    Code = "MERCHANT_NOT_REGISTERED"    // 404xx05
+	NeedToRequestOTP         Code = "NEED_TO_REQUEST_OTP"        // 404xx06
+	JourneyNotFound          Code = "JOURNEY_NOT_FOUND"          // 404xx07
+	InvalidMerchant          Code = "INVALID_MERCHANT"           // 404xx08
+	NoIssuer                 Code = "NO_ISSUER"                  // 404xx09
+	InvalidAPITransition     Code = "INVALID_API_TRANSITION"     // 404xx10
+	InvalidAccount           Code = "INVALID_ACCOUNT"            // 404xx11
+	InvalidBill              Code = "INVALID_BILL"               // 404xx12
+	InvalidAmount            Code = "INVALID_AMOUNT"             // 404xx13
+	PaidBill                 Code = "PAID_BILL"                  // 404xx14
+	InvalidOTP               Code = "INVALID_OTP"                // 404xx15
+	PartnerNotFound          Code = "PARTNER_NOT_FOUND"          // 404xx16
+	InvalidTerminal          Code = "INVALID_TERMINAL"           // 404xx17
+	InconsistentRequest      Code = "INCONSISTENT_REQUEST"       // 404xx18
+	BillExpired              Code = "BILL_EXPIRED"               // 404xx19
+
+	FunctionNotSupported Code = "FUNCTION_NOT_SUPPORTED" // 405xx00
+	OperationNotAllowed  Code = "OPERATION_NOT_ALLOWED"  // 405xx01
+
+	Conflict           Code = "CONFLICT"            // 409xx00
+	DuplicateReference Code = "DUPLICATE_REFERENCE" // 409xx01
+
+	TooManyRequests Code = "TOO_MANY_REQUESTS" // 429xx00
+
+	Internal            Code = "INTERNAL"              // 500xx00
+	InternalServerError Code = "INTERNAL_SERVER_ERROR" // 500xx01
+	ExternalServerError Code = "EXTERNAL_SERVER_ERROR" // 500xx02
+
+	Timeout Code = "TIMEOUT" // 504xx00
+)
+
+type mapping struct {
+	status   int
+	caseCode string
+	message  string
+}
+
+// codes tabel pusat Code -> HTTP status, case code dan pesan standar SNAP
+var codes = map[Code]mapping{
+	BadRequest:            {http.StatusBadRequest, "00", "Bad Request"},
+	InvalidFieldFormat:    {http.StatusBadRequest, "01", "Invalid Field Format"},
+	InvalidMandatoryField: {http.StatusBadRequest, "02", "Invalid Mandatory Field"},
+
+	Unauthorized:          {http.StatusUnauthorized, "00", "Unauthorized"},
+	InvalidToken:          {http.StatusUnauthorized, "01", "Invalid Token (B2B)"},
+	InvalidCustomerToken:  {http.StatusUnauthorized, "02", "Invalid Customer Token"},
+	TokenNotFound:         {http.StatusUnauthorized, "03", "Token Not Found (B2B)"},
+	CustomerTokenNotFound: {http.StatusUnauthorized, "04", "Customer Token Not Found"},
+
+	TransactionExpired:      {http.StatusForbidden, "00", "Transaction Expired"},
+	FeatureNotAllowed:       {http.StatusForbidden, "01", "Feature Not Allowed"},
+	AmountLimitExceeded:     {http.StatusForbidden, "02", "Exceeds Transaction Amount Limit"},
+	SuspectedFraud:          {http.StatusForbidden, "03", "Suspected Fraud"},
+	ActivityLimitExceeded:   {http.StatusForbidden, "04", "Activity Count Limit Exceeded"},
+	DoNotHonor:              {http.StatusForbidden, "05", "Do Not Honor"},
+	FeatureNotAllowedNow:    {http.StatusForbidden, "06", "Feature Not Allowed At This Time"},
+	CardBlocked:             {http.StatusForbidden, "07", "Card Blocked"},
+	CardExpired:             {http.StatusForbidden, "08", "Card Expired"},
+	DormantAccount:          {http.StatusForbidden, "09", "Dormant Account"},
+	NeedToSetTokenLimit:     {http.StatusForbidden, "10", "Need To Set Token Limit"},
+	OTPBlocked:              {http.StatusForbidden, "11", "OTP Blocked"},
+	OTPLifetimeExpired:      {http.StatusForbidden, "12", "OTP Lifetime Expired"},
+	OTPSentToCardholder:     {http.StatusForbidden, "13", "OTP Sent To Cardholder"},
+	InsufficientFunds:       {http.StatusForbidden, "14", "Insufficient Funds"},
+	TransactionNotPermitted: {http.StatusForbidden, "15", "Transaction Not Permitted"},
+	SuspendTransaction:      {http.StatusForbidden, "16", "Suspend Transaction"},
+	TokenLimitExceeded:      {http.StatusForbidden, "17", "Token Limit Exceeded"},
+	InactiveAccount:         {http.StatusForbidden, "18", "Inactive Card/Account/Customer"},
+	MerchantBlacklisted:     {http.StatusForbidden, "19", "Merchant Blacklisted"},
+	MerchantLimitExceeded:   {http.StatusForbidden, "20", "Merchant Limit Exceed"},
+	SetLimitNotAllowed:      {http.StatusForbidden, "21", "Set Limit Not Allowed"},
+	TokenLimitInvalid:       {http.StatusForbidden, "22", "Token Limit Invalid"},
+	AccountLimitExceeded:    {http.StatusForbidden, "23", "Account Limit Exceed"},
+
+	NotFound:                 {http.StatusNotFound, "00", "Not Found"},
+	InvalidTransactionStatus: {http.StatusNotFound, "00", "Invalid Transaction Status"},
+	TransactionNotFound:      {http.StatusNotFound, "01", "Transaction Not Found"},
+	InvalidRouting:           {http.StatusNotFound, "02", "Invalid Routing"},
+	BankNotSupported:         {http.StatusNotFound, "03", "Bank Not Supported By Switch"},
+	TransactionCancelled:     {http.StatusNotFound, "04", "Transaction Cancelled"},
+	MerchantNotRegistered:    {http.StatusNotFound, "05", "Merchant Is Not Registered For Card Registration Services"},
+	NeedToRequestOTP:         {http.StatusNotFound, "06", "Need To Request OTP"},
+	JourneyNotFound:          {http.StatusNotFound, "07", "Journey Not Found"},
+	InvalidMerchant:          {http.StatusNotFound, "08", "Invalid Merchant"},
+	NoIssuer:                 {http.StatusNotFound, "09", "No Issuer"},
+	InvalidAPITransition:     {http.StatusNotFound, "10", "Invalid API Transition"},
+	InvalidAccount:           {http.StatusNotFound, "11", "Invalid Card/Account/Customer/Virtual Account"},
+	InvalidBill:              {http.StatusNotFound, "12", "Invalid Bill/Virtual Account"},
+	InvalidAmount:            {http.StatusNotFound, "13", "Invalid Amount"},
+	PaidBill:                 {http.StatusNotFound, "14", "Paid Bill"},
+	InvalidOTP:               {http.StatusNotFound, "15", "Invalid OTP"},
+	PartnerNotFound:          {http.StatusNotFound, "16", "Partner Not Found"},
+	InvalidTerminal:          {http.StatusNotFound, "17", "Invalid Terminal"},
+	InconsistentRequest:      {http.StatusNotFound, "18", "Inconsistent Request"},
+	BillExpired:              {http.StatusNotFound, "19", "Invalid Bill/Virtual Account"},
+
+	FunctionNotSupported: {http.StatusMethodNotAllowed, "00", "Requested Function Is Not Supported"},
+	OperationNotAllowed:  {http.StatusMethodNotAllowed, "01", "Requested Operation Is Not Allowed"},
+
+	Conflict:           {http.StatusConflict, "00", "Conflict"},
+	DuplicateReference: {http.StatusConflict, "01", "Duplicate partnerReferenceNo"},
+
+	TooManyRequests: {http.StatusTooManyRequests, "00", "Too Many Requests"},
+
+	Internal:            {http.StatusInternalServerError, "00", "General Error"},
+	InternalServerError: {http.StatusInternalServerError, "01", "Internal Server Error"},
+	ExternalServerError: {http.StatusInternalServerError, "02", "External Server Error"},
+
+	Timeout: {http.StatusGatewayTimeout, "00", "Timeout"},
+}
+
+// HTTPStatus status HTTP untuk code; code yang tidak dikenal dianggap Internal
+func (c Code) HTTPStatus() int {
+	if m, ok := codes[c]; ok {
+		return m.status
+	}
+	return http.StatusInternalServerError
+}
+
+// CaseCode 2 digit terakhir response code SNAP
+func (c Code) CaseCode() string {
+	if m, ok := codes[c]; ok {
+		return m.caseCode
+	}
+	return "00"
+}
+
+// Description pesan standar SNAP untuk code, contoh "Invalid Mandatory Field"
+func (c Code) Description() string {
+	if m, ok := codes[c]; ok {
+		return m.message
+	}
+	return codes[Internal].message
+}
+
+// ResponseCode response code SNAP 7 digit, contoh NotFound.ResponseCode("47") = "4044700"
+func (c Code) ResponseCode(serviceCode string) string {
+	return fmt.Sprintf("%d%s%s", c.HTTPStatus(), serviceCode, c.CaseCode())
+}
+
+// Error error domain. Message aman ditampilkan ke client; Err (jika ada) penyebab
+// teknis yang hanya ikut di Error() untuk log.
+type Error struct {
+	Code    Code
+	Message string
+	Err     error
+}
+
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+func Newf(code Code, format string, args ...any) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// Field error untuk satu field/header dengan pesan standar SNAP,
+// contoh Field(InvalidMandatoryField, "X-TIMESTAMP") -> "Invalid Mandatory Field X-TIMESTAMP"
+func Field(code Code, field string) *Error {
+	return &Error{Code: code, Message: code.Description() + " " + field}
+}
+
+// Wrap membungkus err dengan code dan pesan untuk client
+func Wrap(err error, code Code, message string) *Error {
+	return &Error{Code: code, Message: message, Err: err}
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// From mencari *Error di rantai err. Error lain dianggap Internal dengan pesan generik.
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return Wrap(err, Internal, "Internal server error")
+}
+
+// CodeOf code dari err, Internal jika err bukan error domain
+func CodeOf(err error) Code {
+	return From(err).Code
+}
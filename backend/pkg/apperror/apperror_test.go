@@ -0,0 +1,47 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestResponseCode(t *testing.T) {
+	cases := []struct {
+		code        Code
+		serviceCode string
+		want        string
+	}{
+		{InvalidMandatoryField, "47", "4004702"},
+		{Unauthorized, "47", "4014700"},
+		{TransactionNotFound, "51", "4045101"},
+		{DuplicateReference, "47", "4094701"},
+		{Code("UNKNOWN"), "00", "5000000"},
+	}
+	for _, tc := range cases {
+		if got := tc.code.ResponseCode(tc.serviceCode); got != tc.want {
+			t.Errorf("%s.ResponseCode(%q) = %q, want %q", tc.code, tc.serviceCode, got, tc.want)
+		}
+	}
+}
+
+func TestFromFindsWrappedError(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := fmt.Errorf("load merchant: %w", Wrap(cause, NotFound, "merchant not found"))
+
+	appErr := From(err)
+	if appErr.Code != NotFound || appErr.Message != "merchant not found" || !errors.Is(err, cause) {
+		t.Fatalf("unexpected error: %+v", appErr)
+	}
+	if appErr.Error() != "merchant not found: connection refused" {
+		t.Fatalf("Error() = %q", appErr.Error())
+	}
+}
+
+func TestFromHidesUntypedError(t *testing.T) {
+	appErr := From(errors.New("pq: relation does not exist"))
+	if appErr.Code != Internal || appErr.Code.HTTPStatus() != http.StatusInternalServerError || appErr.Message != "Internal server error" {
+		t.Fatalf("unexpected error: %+v", appErr)
+	}
+}
@@ -0,0 +1,182 @@
+package export
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Format file export yang didukung
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// MaxXLSXRows batas baris satu sheet Excel (termasuk header dan footer)
+const MaxXLSXRows = excelize.TotalRows
+
+// ErrTooManyRows dikembalikan jika baris melebihi batas format
+var ErrTooManyRows = errors.New("export exceeds maximum rows for format")
+
+// Writer menulis tabel baris per baris ke file export.
+// Nilai yang didukung: string, float64, int, int64 dan nil (sel kosong).
+type Writer interface {
+	WriteHeader(columns ...string) error
+	WriteRow(values ...any) error
+	// Close menyelesaikan file; untuk XLSX isi file baru ditulis ke io.Writer di sini
+	Close() error
+}
+
+// New membuat Writer untuk format csv atau xlsx
+func New(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	case FormatXLSX:
+		return newXLSXWriter(w)
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+// ContentType MIME type untuk format export
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// csvFlushEvery jumlah baris sebelum buffer CSV dikirim ke client
+const csvFlushEvery = 500
+
+type csvWriter struct {
+	w    *csv.Writer
+	rows int
+}
+
+func (c *csvWriter) WriteHeader(columns ...string) error {
+	return c.w.Write(columns)
+}
+
+func (c *csvWriter) WriteRow(values ...any) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = formatCSVValue(value)
+	}
+	if err := c.w.Write(record); err != nil {
+		return err
+	}
+	c.rows++
+	if c.rows%csvFlushEvery == 0 {
+		c.w.Flush()
+		return c.w.Error()
+	}
+	return nil
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func formatCSVValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', 2, 64)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// xlsxWriter memakai StreamWriter excelize: baris disimpan ke file sementara, bukan di memori
+type xlsxWriter struct {
+	out         io.Writer
+	file        *excelize.File
+	stream      *excelize.StreamWriter
+	headerStyle int
+	amountStyle int
+	row         int
+}
+
+const xlsxSheet = "Transactions"
+
+func newXLSXWriter(out io.Writer) (*xlsxWriter, error) {
+	file := excelize.NewFile()
+	if err := file.SetSheetName("Sheet1", xlsxSheet); err != nil {
+		file.Close()
+		return nil, err
+	}
+	stream, err := file.NewStreamWriter(xlsxSheet)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	headerStyle, err := file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	amountFormat := "#,##0.00"
+	amountStyle, err := file.NewStyle(&excelize.Style{CustomNumFmt: &amountFormat})
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &xlsxWriter{out: out, file: file, stream: stream, headerStyle: headerStyle, amountStyle: amountStyle}, nil
+}
+
+func (x *xlsxWriter) WriteHeader(columns ...string) error {
+	values := make([]any, len(columns))
+	for i, column := range columns {
+		values[i] = excelize.Cell{StyleID: x.headerStyle, Value: column}
+	}
+	return x.setRow(values)
+}
+
+func (x *xlsxWriter) WriteRow(values ...any) error {
+	cells := make([]any, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case nil:
+			cells[i] = nil
+		case float64:
+			cells[i] = excelize.Cell{StyleID: x.amountStyle, Value: v}
+		default:
+			cells[i] = v
+		}
+	}
+	return x.setRow(cells)
+}
+
+func (x *xlsxWriter) setRow(values []any) error {
+	if x.row >= MaxXLSXRows {
+		return ErrTooManyRows
+	}
+	x.row++
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+	return x.stream.SetRow(cell, values)
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	return x.file.Write(x.out)
+}
@@ -0,0 +1,70 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := New(FormatCSV, &buf)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	if err := w.WriteHeader("Reference No", "Amount", "Count"); err != nil {
+		t.Fatalf("WriteHeader returned error: %v", err)
+	}
+	if err := w.WriteRow("A001, Toko \"Maju\"", 10000.5, int64(3)); err != nil {
+		t.Fatalf("WriteRow returned error: %v", err)
+	}
+	if err := w.WriteRow(nil, 0.0); err != nil {
+		t.Fatalf("WriteRow returned error: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	want := "Reference No,Amount,Count\n\"A001, Toko \"\"Maju\"\"\",10000.50,3\n,0.00\n"
+	if buf.String() != want {
+		t.Fatalf("csv = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestXLSXWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := New(FormatXLSX, &buf)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	if err := w.WriteHeader("Reference No", "Amount"); err != nil {
+		t.Fatalf("WriteHeader returned error: %v", err)
+	}
+	if err := w.WriteRow("A001", 10000.5); err != nil {
+		t.Fatalf("WriteRow returned error: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	file, err := excelize.OpenReader(&buf)
+	if err != nil {
+		t.Fatalf("OpenReader returned error: %v", err)
+	}
+	defer file.Close()
+	rows, err := file.GetRows(xlsxSheet, excelize.Options{RawCellValue: true})
+	if err != nil {
+		t.Fatalf("GetRows returned error: %v", err)
+	}
+	if len(rows) != 2 || strings.Join(rows[0], ",") != "Reference No,Amount" || strings.Join(rows[1], ",") != "A001,10000.5" {
+		t.Fatalf("rows = %v", rows)
+	}
+}
+
+func TestNewRejectsUnknownFormat(t *testing.T) {
+	if _, err := New("pdf", &bytes.Buffer{}); err == nil {
+		t.Fatal("expected error for unsupported format")
+	}
+}
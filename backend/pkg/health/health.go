@@ -0,0 +1,161 @@
+// Package health pemeriksaan dependency untuk probe readiness: setiap komponen (database,
+// schema, hub WebSocket, background worker) diperiksa paralel dengan batas waktu dan
+// hasilnya dilaporkan per komponen.
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// CheckFunc memeriksa satu komponen; error berarti komponen tidak siap.
+// Implementasi harus berhenti saat ctx habis.
+type CheckFunc func(ctx context.Context) error
+
+// Result hasil pemeriksaan satu komponen
+type Result struct {
+	Status     string  `json:"status"`
+	Error      string  `json:"error,omitempty"`
+	DurationMs float64 `json:"duration_ms"`
+}
+
+// Report hasil semua pemeriksaan. Status "fail" jika ada satu komponen yang gagal.
+type Report struct {
+	Status    string            `json:"status"`
+	Checks    map[string]Result `json:"checks"`
+	CheckedAt time.Time         `json:"checked_at"`
+}
+
+// OK true jika semua komponen sehat
+func (r Report) OK() bool {
+	return r.Status == StatusOK
+}
+
+type namedCheck struct {
+	name  string
+	check CheckFunc
+}
+
+// Checker kumpulan pemeriksaan readiness
+type Checker struct {
+	Timeout time.Duration // batas waktu per pemeriksaan
+
+	mu     sync.RWMutex
+	checks []namedCheck
+}
+
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{Timeout: timeout}
+}
+
+// Add mendaftarkan pemeriksaan komponen dengan nama unik
+func (c *Checker) Add(name string, check CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, existing := range c.checks {
+		if existing.name == name {
+			panic("health: duplicate check " + name)
+		}
+	}
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// Run menjalankan semua pemeriksaan secara paralel. Pemeriksaan yang melewati Timeout
+// dianggap gagal walaupun fungsinya belum kembali.
+func (c *Checker) Run(ctx context.Context) Report {
+	c.mu.RLock()
+	checks := append([]namedCheck(nil), c.checks...)
+	c.mu.RUnlock()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(checks)), CheckedAt: time.Now()}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := c.run(ctx, check.check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[check.name] = result
+			if result.Status != StatusOK {
+				report.Status = StatusFail
+			}
+		}()
+	}
+	wg.Wait()
+	return report
+}
+
+func (c *Checker) run(ctx context.Context, check CheckFunc) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("check panicked: %v", r)
+			}
+		}()
+		done <- check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("timed out after %s", c.Timeout)
+	}
+
+	result := Result{Status: StatusOK, DurationMs: float64(time.Since(start).Microseconds()) / 1000}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Heartbeat dipanggil background worker secara berkala. Worker dianggap macet jika
+// heartbeat terakhir lebih lama dari MaxAge.
+type Heartbeat struct {
+	MaxAge time.Duration
+	last   atomic.Int64 // unix nano
+}
+
+// NewHeartbeat membuat heartbeat yang langsung dianggap hidup sejak sekarang
+func NewHeartbeat(maxAge time.Duration) *Heartbeat {
+	h := &Heartbeat{MaxAge: maxAge}
+	h.Beat()
+	return h
+}
+
+// Beat mencatat bahwa worker masih berjalan. Aman dipanggil pada Heartbeat nil.
+func (h *Heartbeat) Beat() {
+	if h != nil {
+		h.last.Store(time.Now().UnixNano())
+	}
+}
+
+// Last waktu heartbeat terakhir
+func (h *Heartbeat) Last() time.Time {
+	return time.Unix(0, h.last.Load())
+}
+
+// Check CheckFunc yang gagal jika heartbeat sudah terlalu lama
+func (h *Heartbeat) Check(ctx context.Context) error {
+	if age := time.Since(h.Last()); age > h.MaxAge {
+		return fmt.Errorf("last heartbeat %s ago, max %s", age.Round(time.Second), h.MaxAge)
+	}
+	return nil
+}
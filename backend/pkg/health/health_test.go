@@ -0,0 +1,67 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunReportsEachComponent(t *testing.T) {
+	checker := NewChecker(50 * time.Millisecond)
+	checker.Add("database", func(ctx context.Context) error { return nil })
+	checker.Add("migrations", func(ctx context.Context) error { return errors.New("schema behind") })
+	checker.Add("websocket_hub", func(ctx context.Context) error {
+		time.Sleep(time.Second) // hub macet dan tidak menghormati ctx
+		return nil
+	})
+
+	start := time.Now()
+	report := checker.Run(context.Background())
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("Run took %s, stuck check should be cut at timeout", elapsed)
+	}
+
+	if report.OK() || report.Status != StatusFail {
+		t.Fatalf("status = %s, want fail", report.Status)
+	}
+	if got := report.Checks["database"]; got.Status != StatusOK || got.Error != "" {
+		t.Errorf("database = %+v, want ok", got)
+	}
+	if got := report.Checks["migrations"]; got.Status != StatusFail || got.Error != "schema behind" {
+		t.Errorf("migrations = %+v, want fail with error", got)
+	}
+	if got := report.Checks["websocket_hub"]; got.Status != StatusFail || !strings.Contains(got.Error, "timed out") {
+		t.Errorf("websocket_hub = %+v, want timeout", got)
+	}
+}
+
+func TestRunAllHealthy(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.Add("database", func(ctx context.Context) error { return nil })
+
+	if report := checker.Run(context.Background()); !report.OK() || len(report.Checks) != 1 {
+		t.Fatalf("report = %+v, want ok with one check", report)
+	}
+}
+
+func TestHeartbeat(t *testing.T) {
+	heartbeat := NewHeartbeat(time.Minute)
+	if err := heartbeat.Check(context.Background()); err != nil {
+		t.Fatalf("fresh heartbeat = %v", err)
+	}
+
+	heartbeat.last.Store(time.Now().Add(-2 * time.Minute).UnixNano())
+	if err := heartbeat.Check(context.Background()); err == nil {
+		t.Fatal("expected stale heartbeat to fail")
+	}
+
+	heartbeat.Beat()
+	if err := heartbeat.Check(context.Background()); err != nil {
+		t.Fatalf("heartbeat after Beat = %v", err)
+	}
+
+	var disabled *Heartbeat
+	disabled.Beat() // worker tanpa heartbeat
+}
@@ -0,0 +1,27 @@
+package logging
+
+import (
+	"log/slog"
+	"time"
+
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// SlowQueryThreshold query yang lebih lama dari ini dicatat dengan level WARN
+const SlowQueryThreshold = 200 * time.Millisecond
+
+// GormLogger logger GORM yang menulis ke logger (dengan request_id dari Statement.Context).
+// Query ditulis dengan placeholder tanpa nilai parameter, jadi data pembayar tidak ikut ke log.
+// Setiap query hanya dicatat jika level DEBUG; di level lain hanya query lambat dan error.
+func GormLogger(logger *slog.Logger, level slog.Level) gormlogger.Interface {
+	logLevel := gormlogger.Warn
+	if level <= slog.LevelDebug {
+		logLevel = gormlogger.Info
+	}
+	return gormlogger.NewSlogLogger(logger, gormlogger.Config{
+		LogLevel:                  logLevel,
+		SlowThreshold:             SlowQueryThreshold,
+		ParameterizedQueries:      true,
+		IgnoreRecordNotFoundError: true,
+	})
+}
@@ -0,0 +1,143 @@
+// Package logging setup log terstruktur (log/slog) untuk seluruh aplikasi: output JSON atau
+// teks, level yang bisa dikonfigurasi, request ID dan trace ID dari context di setiap record,
+// serta redaksi field sensitif (signature, secret, data pembayar).
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDHeader header request/response yang membawa request ID
+const RequestIDHeader = "X-Request-ID"
+
+// Redacted nilai pengganti field sensitif
+const Redacted = "[REDACTED]"
+
+// Options konfigurasi Setup (diisi dari config.LogConfig)
+type Options struct {
+	Level  slog.Level
+	Format string    // "json" (default) atau "text"
+	Output io.Writer // default os.Stdout
+}
+
+// New membuat logger dengan redaksi dan atribut dari context
+func New(opts Options) *slog.Logger {
+	output := opts.Output
+	if output == nil {
+		output = os.Stdout
+	}
+	handlerOpts := &slog.HandlerOptions{Level: opts.Level, ReplaceAttr: redact}
+
+	var handler slog.Handler
+	if opts.Format == "text" {
+		handler = slog.NewTextHandler(output, handlerOpts)
+	} else {
+		handler = slog.NewJSONHandler(output, handlerOpts)
+	}
+	return slog.New(contextHandler{handler})
+}
+
+// Setup memasang logger sebagai slog default. Package log standar ikut diarahkan ke
+// logger ini, jadi log dari library yang memakai log.Printf tetap keluar dalam format yang sama.
+func Setup(opts Options) *slog.Logger {
+	logger := New(opts)
+	slog.SetDefault(logger)
+	return logger
+}
+
+type requestIDKey struct{}
+
+// WithRequestID menyimpan request ID di ctx; semua log dengan ctx ini membawa request_id
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID request ID dari ctx, kosong jika tidak ada
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// NewRequestID membuat request ID baru untuk request tanpa X-Request-ID
+func NewRequestID() string {
+	return uuid.NewString()
+}
+
+// contextHandler menambahkan request_id, trace_id dan span_id dari ctx ke setiap record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx != nil {
+		if requestID := RequestID(ctx); requestID != "" {
+			record.AddAttrs(slog.String("request_id", requestID))
+		}
+		if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+			record.AddAttrs(
+				slog.String("trace_id", spanContext.TraceID().String()),
+				slog.String("span_id", spanContext.SpanID().String()),
+			)
+		}
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// sensitiveKeys nama field (huruf kecil, tanpa "-" dan "_") yang nilainya tidak boleh
+// masuk log. Group dengan nama ini (contoh "payer") diredaksi seluruh isinya.
+var sensitiveKeys = map[string]bool{
+	"signature":     true,
+	"xsignature":    true,
+	"secret":        true,
+	"hmacsecret":    true,
+	"password":      true,
+	"authorization": true,
+	"token":         true,
+	"payer":         true,
+	"payerinfo":     true,
+	"customerpan":   true,
+	"customername":  true,
+	"rrn":           true,
+	"approvalcode":  true,
+}
+
+// IsSensitive true jika field dengan nama key harus diredaksi
+func IsSensitive(key string) bool {
+	normalized := strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(key))
+	if sensitiveKeys[normalized] {
+		return true
+	}
+	for _, suffix := range []string{"secret", "password", "signature", "token"} {
+		if strings.HasSuffix(normalized, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func redact(groups []string, attr slog.Attr) slog.Attr {
+	if IsSensitive(attr.Key) {
+		return slog.String(attr.Key, Redacted)
+	}
+	for _, group := range groups {
+		if IsSensitive(group) {
+			return slog.String(attr.Key, Redacted)
+		}
+	}
+	return attr
+}
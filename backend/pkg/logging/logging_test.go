@@ -0,0 +1,87 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func decodeRecord(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("invalid JSON log %q: %v", buf.String(), err)
+	}
+	return record
+}
+
+func TestRedactsSensitiveFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(Options{Level: slog.LevelInfo, Output: &buf})
+
+	logger.Info("callback received",
+		"reference_no", "A0000000001",
+		"X-Signature", "abc123",
+		"hmac_secret", "secret",
+		slog.Group("payer", "customer_name", "Budi", "issuer_name", "Bank A"),
+		"customer_pan", "936008**********1234",
+	)
+
+	record := decodeRecord(t, &buf)
+	if record["reference_no"] != "A0000000001" {
+		t.Errorf("reference_no = %v, should not be redacted", record["reference_no"])
+	}
+	for _, key := range []string{"X-Signature", "hmac_secret", "customer_pan"} {
+		if record[key] != Redacted {
+			t.Errorf("%s = %v, want %s", key, record[key], Redacted)
+		}
+	}
+	payer, _ := record["payer"].(map[string]any)
+	if payer["customer_name"] != Redacted || payer["issuer_name"] != Redacted {
+		t.Errorf("payer = %v, want all fields redacted", payer)
+	}
+	for _, leaked := range []string{"abc123", "Budi", "1234"} {
+		if strings.Contains(buf.String(), leaked) {
+			t.Errorf("log contains sensitive value %q: %s", leaked, buf.String())
+		}
+	}
+}
+
+func TestAddsRequestAndTraceIDFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(Options{Level: slog.LevelInfo, Output: &buf})
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}))
+	ctx = WithRequestID(ctx, "req-1")
+
+	logger.With("component", "test").InfoContext(ctx, "hello")
+
+	record := decodeRecord(t, &buf)
+	if record["request_id"] != "req-1" || record["trace_id"] != traceID.String() || record["span_id"] != spanID.String() {
+		t.Fatalf("record = %v, want request_id, trace_id and span_id", record)
+	}
+	if record["component"] != "test" {
+		t.Fatalf("component = %v, attrs from With should be kept", record["component"])
+	}
+}
+
+func TestLevelFiltersRecords(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(Options{Level: slog.LevelWarn, Output: &buf})
+
+	logger.Info("ignored")
+	if buf.Len() != 0 {
+		t.Fatalf("info record written at warn level: %s", buf.String())
+	}
+	logger.Warn("kept")
+	if decodeRecord(t, &buf)["msg"] != "kept" {
+		t.Fatalf("warn record missing: %s", buf.String())
+	}
+}
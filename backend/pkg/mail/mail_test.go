@@ -0,0 +1,149 @@
+package mail
+
+import (
+	"bufio"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+// fakeSMTP server SMTP minimal yang menerima satu email dan menyimpan isinya
+type fakeSMTP struct {
+	listener   net.Listener
+	from       string
+	recipients []string
+	data       string
+	done       chan struct{}
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	server := &fakeSMTP{listener: listener, done: make(chan struct{})}
+	go server.serve()
+	t.Cleanup(func() { listener.Close() })
+	return server
+}
+
+func (f *fakeSMTP) serve() {
+	defer close(f.done)
+	conn, err := f.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+	reply("220 fake.smtp ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 fake.smtp")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			f.from = strings.Trim(strings.TrimPrefix(command, "MAIL FROM:"), "<>")
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			f.recipients = append(f.recipients, strings.Trim(strings.TrimPrefix(command, "RCPT TO:"), "<>"))
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			f.data = data.String()
+			reply("250 OK queued")
+		case command == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func TestSMTPSenderSendsMultipartMail(t *testing.T) {
+	server := newFakeSMTP(t)
+	sender := NewSMTPSender(server.listener.Addr().String(), "", "", "reports@qr-service.local")
+
+	err := sender.Send(Message{
+		To:      []string{"owner@merchant.test"},
+		Subject: "Laporan Harian M001 - 2025-09-21",
+		HTML:    "<h1>Rekap</h1>",
+		Attachments: []Attachment{
+			{Filename: "M001_2025-09-21.csv", ContentType: "text/csv", Data: []byte("status,count\nPAID,2\n")},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	<-server.done
+
+	if server.from != "reports@qr-service.local" || strings.Join(server.recipients, ",") != "owner@merchant.test" {
+		t.Fatalf("envelope from=%q to=%v", server.from, server.recipients)
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(server.data))
+	if err != nil {
+		t.Fatalf("ReadMessage returned error: %v", err)
+	}
+	subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if subject != "Laporan Harian M001 - 2025-09-21" {
+		t.Fatalf("subject = %q", subject)
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("content type = %q, %v", mediaType, err)
+	}
+
+	// Bagian pertama HTML, bagian kedua lampiran CSV dalam base64
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	var parts []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("NextPart returned error: %v", err)
+		}
+		parts = append(parts, part.Header.Get("Content-Type")+"|"+part.FileName())
+		if part.FileName() != "" {
+			data, _ := io.ReadAll(base64.NewDecoder(base64.StdEncoding, part))
+			if string(data) != "status,count\nPAID,2\n" {
+				t.Fatalf("attachment = %q", data)
+			}
+		}
+	}
+	if strings.Join(parts, ";") != "text/html; charset=utf-8|;text/csv|M001_2025-09-21.csv" {
+		t.Fatalf("parts = %v", parts)
+	}
+}
+
+func TestSMTPSenderRequiresRecipient(t *testing.T) {
+	sender := NewSMTPSender("127.0.0.1:1", "", "", "reports@qr-service.local")
+	if err := sender.Send(Message{Subject: "x"}); err == nil {
+		t.Fatal("expected error without recipients")
+	}
+}
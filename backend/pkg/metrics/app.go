@@ -0,0 +1,89 @@
+// Package metrics metric aplikasi di registry default Prometheus (client_golang). GET /metrics
+// menampilkannya lewat promhttp.Handler bersama collector proses dan runtime Go bawaan.
+package metrics
+
+import (
+	"database/sql"
+
+	"qr-service/pkg/apperror"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// DefaultBuckets bucket latency request HTTP dalam detik
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+var (
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "qr_http_request_duration_seconds",
+		Help:    "Latency request HTTP per route dan status.",
+		Buckets: DefaultBuckets,
+	}, []string{"method", "route", "status"})
+
+	QRGenerateTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "qr_generate_total",
+		Help: "Jumlah request generate QR per merchant dan outcome (success atau kode error).",
+	}, []string{"merchant_id", "outcome"})
+
+	PaymentCallbackTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "qr_payment_callback_total",
+		Help: "Jumlah callback pembayaran per merchant dan outcome (success atau kode error).",
+	}, []string{"merchant_id", "outcome"})
+
+	SignatureFailuresTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "qr_signature_failures_total",
+		Help: "Jumlah request dengan X-Signature hilang atau tidak valid.",
+	}, []string{"reason"})
+
+	StatusTransitionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "qr_transaction_status_transitions_total",
+		Help: "Jumlah perubahan status transaksi.",
+	}, []string{"from", "to"})
+
+	RateLimitedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "qr_rate_limited_total",
+		Help: "Jumlah request yang ditolak rate limit per group route.",
+	}, []string{"group"})
+
+	TimeToPayment = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "qr_time_to_payment_seconds",
+		Help:    "Waktu dari QR dibuat sampai transaksi PAID.",
+		Buckets: []float64{5, 15, 30, 60, 120, 300, 600, 1800, 3600, 21600, 86400},
+	})
+)
+
+// Label merchant_id untuk request yang transaksinya tidak ditemukan atau belum tersimpan,
+// agar merchantId dari client yang belum tervalidasi tidak menjadi seri baru
+const UnknownMerchant = "unknown"
+
+// Outcome nilai label outcome: "success" atau kode apperror (contoh "INVALID_AMOUNT")
+func Outcome(err error) string {
+	if err == nil {
+		return "success"
+	}
+	return string(apperror.CodeOf(err))
+}
+
+// RegisterDBStats menampilkan statistik pool koneksi database/sql
+func RegisterDBStats(db *sql.DB) {
+	gauge := func(name string, help string, fn func(sql.DBStats) float64) {
+		promauto.NewGaugeFunc(prometheus.GaugeOpts{Name: name, Help: help}, func() float64 { return fn(db.Stats()) })
+	}
+	counter := func(name string, help string, fn func(sql.DBStats) float64) {
+		promauto.NewCounterFunc(prometheus.CounterOpts{Name: name, Help: help}, func() float64 { return fn(db.Stats()) })
+	}
+
+	gauge("qr_db_open_connections", "Jumlah koneksi database terbuka (in use + idle).",
+		func(s sql.DBStats) float64 { return float64(s.OpenConnections) })
+	gauge("qr_db_in_use_connections", "Jumlah koneksi database yang sedang dipakai.",
+		func(s sql.DBStats) float64 { return float64(s.InUse) })
+	gauge("qr_db_idle_connections", "Jumlah koneksi database idle.",
+		func(s sql.DBStats) float64 { return float64(s.Idle) })
+	gauge("qr_db_max_open_connections", "Batas maksimal koneksi database terbuka (0 = tanpa batas).",
+		func(s sql.DBStats) float64 { return float64(s.MaxOpenConnections) })
+	counter("qr_db_wait_count_total", "Jumlah total request yang menunggu koneksi database.",
+		func(s sql.DBStats) float64 { return float64(s.WaitCount) })
+	counter("qr_db_wait_duration_seconds_total", "Total waktu menunggu koneksi database.",
+		func(s sql.DBStats) float64 { return s.WaitDuration.Seconds() })
+}
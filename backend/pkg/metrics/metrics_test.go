@@ -0,0 +1,20 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+
+	"qr-service/pkg/apperror"
+)
+
+func TestOutcome(t *testing.T) {
+	if got := Outcome(nil); got != "success" {
+		t.Fatalf("Outcome(nil) = %q", got)
+	}
+	if got := Outcome(apperror.New(apperror.InvalidAmount, "amount must be greater than 0")); got != "INVALID_AMOUNT" {
+		t.Fatalf("Outcome(apperror) = %q", got)
+	}
+	if got := Outcome(errors.New("boom")); got != "INTERNAL" {
+		t.Fatalf("Outcome(error) = %q", got)
+	}
+}
@@ -0,0 +1,260 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Nama file migration: 0001_create_transactions.up.sql / 0001_create_transactions.down.sql
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// ErrNoMigrations dikembalikan jika tidak ada file migration sama sekali
+var ErrNoMigrations = errors.New("no migrations found")
+
+// Migration satu versi schema beserta SQL up dan down-nya
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status kondisi satu migration di database
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+// appliedMigration baris di tabel schema_migrations
+type appliedMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (appliedMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrator menjalankan migration berurutan dan mencatat versinya di schema_migrations
+type Migrator struct {
+	DB         *gorm.DB
+	Migrations []Migration
+}
+
+// Load membaca pasangan file up/down dari dir di fsys, diurutkan berdasarkan versi
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", entry.Name())
+		}
+
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both up and down files", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// New membuat Migrator dari file migration di dir
+func New(db *gorm.DB, fsys fs.FS, dir string) (*Migrator, error) {
+	migrations, err := Load(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(migrations) == 0 {
+		return nil, ErrNoMigrations
+	}
+	return &Migrator{DB: db, Migrations: migrations}, nil
+}
+
+// WithContext Migrator yang query-nya memakai ctx (batas waktu, tracing, request ID)
+func (m *Migrator) WithContext(ctx context.Context) *Migrator {
+	return &Migrator{DB: m.DB.WithContext(ctx), Migrations: m.Migrations}
+}
+
+// Latest versi schema yang diharapkan oleh binary ini
+func (m *Migrator) Latest() int64 {
+	if len(m.Migrations) == 0 {
+		return 0
+	}
+	return m.Migrations[len(m.Migrations)-1].Version
+}
+
+// Version versi schema di database (0 jika belum ada migration yang dijalankan)
+func (m *Migrator) Version() (int64, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return 0, err
+	}
+	if len(applied) == 0 {
+		return 0, nil
+	}
+	return applied[len(applied)-1].Version, nil
+}
+
+// Up menjalankan semua migration yang belum diterapkan, masing-masing dalam satu transaction
+func (m *Migrator) Up() ([]Migration, error) {
+	current, err := m.Version()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range m.Migrations {
+		if migration.Version <= current {
+			continue
+		}
+		err := m.DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(migration.Up).Error; err != nil {
+				return err
+			}
+			return tx.Create(&appliedMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: time.Now(),
+			}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Down membatalkan sejumlah steps migration terakhir
+func (m *Migrator) Down(steps int) ([]Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(applied) - 1; i >= 0 && len(done) < steps; i-- {
+		migration, ok := m.find(applied[i].Version)
+		if !ok {
+			return done, fmt.Errorf("migration %d is applied but its files are missing", applied[i].Version)
+		}
+		err := m.DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(migration.Down).Error; err != nil {
+				return err
+			}
+			return tx.Delete(&appliedMigration{}, "version = ?", migration.Version).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("rollback %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Status daftar semua migration beserta waktu diterapkannya
+func (m *Migrator) Status() ([]Status, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	appliedAt := make(map[int64]time.Time, len(applied))
+	for _, a := range applied {
+		appliedAt[a.Version] = a.AppliedAt
+	}
+
+	statuses := make([]Status, 0, len(m.Migrations))
+	for _, migration := range m.Migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if at, ok := appliedAt[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &at
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// CheckVersion memastikan versi schema di database sama dengan yang diharapkan binary.
+// Dipanggil saat startup agar aplikasi tidak jalan di atas schema yang salah.
+func (m *Migrator) CheckVersion() error {
+	current, err := m.Version()
+	if err != nil {
+		return err
+	}
+	latest := m.Latest()
+	switch {
+	case current < latest:
+		return fmt.Errorf("database schema version %d is behind expected version %d, run `qr-service migrate up`", current, latest)
+	case current > latest:
+		return fmt.Errorf("database schema version %d is newer than expected version %d, deploy a newer build", current, latest)
+	}
+	return nil
+}
+
+// applied membaca schema_migrations (membuat tabelnya jika belum ada), urut dari versi terkecil.
+// Hanya tabel pencatat ini yang dibuat lewat GORM agar tipe kolomnya cocok di Postgres maupun SQLite.
+func (m *Migrator) applied() ([]appliedMigration, error) {
+	if err := m.DB.AutoMigrate(&appliedMigration{}); err != nil {
+		return nil, fmt.Errorf("failed to prepare schema_migrations: %w", err)
+	}
+
+	var applied []appliedMigration
+	if err := m.DB.Order("version ASC").Find(&applied).Error; err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	return applied, nil
+}
+
+func (m *Migrator) find(version int64) (Migration, bool) {
+	for _, migration := range m.Migrations {
+		if migration.Version == version {
+			return migration, true
+		}
+	}
+	return Migration{}, false
+}
@@ -0,0 +1,121 @@
+package migrate_test
+
+import (
+	"path/filepath"
+	"qr-service/pkg/migrate"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var files = fstest.MapFS{
+	"migrations/0001_create_items.up.sql":     {Data: []byte("CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT NOT NULL);")},
+	"migrations/0001_create_items.down.sql":   {Data: []byte("DROP TABLE items;")},
+	"migrations/0002_add_item_price.up.sql":   {Data: []byte("ALTER TABLE items ADD COLUMN price NUMERIC;\nCREATE INDEX idx_items_price ON items (price);")},
+	"migrations/0002_add_item_price.down.sql": {Data: []byte("DROP INDEX idx_items_price;\nALTER TABLE items DROP COLUMN price;")},
+}
+
+func newMigrator(t *testing.T, fsys fstest.MapFS) (*migrate.Migrator, *gorm.DB) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "migrate.db")), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	migrator, err := migrate.New(db, fsys, "migrations")
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	return migrator, db
+}
+
+func TestUpDownStatus(t *testing.T) {
+	migrator, db := newMigrator(t, files)
+
+	if err := migrator.CheckVersion(); err == nil || !strings.Contains(err.Error(), "migrate up") {
+		t.Fatalf("CheckVersion on empty database = %v, want behind error", err)
+	}
+
+	done, err := migrator.Up()
+	if err != nil || len(done) != 2 {
+		t.Fatalf("Up() = %d migrations, %v; want 2, nil", len(done), err)
+	}
+	if err := migrator.CheckVersion(); err != nil {
+		t.Fatalf("CheckVersion after up returned error: %v", err)
+	}
+	if err := db.Exec("INSERT INTO items (name, price) VALUES ('kopi', 15000)").Error; err != nil {
+		t.Fatalf("schema not applied: %v", err)
+	}
+
+	// Up kedua kali tidak menjalankan apa-apa
+	if done, err := migrator.Up(); err != nil || len(done) != 0 {
+		t.Fatalf("second Up() = %d migrations, %v; want 0, nil", len(done), err)
+	}
+
+	done, err = migrator.Down(1)
+	if err != nil || len(done) != 1 || done[0].Version != 2 {
+		t.Fatalf("Down(1) = %+v, %v", done, err)
+	}
+	if version, _ := migrator.Version(); version != 1 {
+		t.Fatalf("version after down = %d, want 1", version)
+	}
+
+	statuses, err := migrator.Status()
+	if err != nil {
+		t.Fatalf("Status returned error: %v", err)
+	}
+	if len(statuses) != 2 || !statuses[0].Applied || statuses[0].AppliedAt == nil || statuses[1].Applied {
+		t.Fatalf("unexpected status: %+v", statuses)
+	}
+}
+
+func TestFailedMigrationIsNotRecorded(t *testing.T) {
+	broken := fstest.MapFS{}
+	for name, file := range files {
+		broken[name] = file
+	}
+	broken["migrations/0002_add_item_price.up.sql"] = &fstest.MapFile{Data: []byte("ALTER TABLE missing ADD COLUMN price NUMERIC;")}
+
+	migrator, _ := newMigrator(t, broken)
+	done, err := migrator.Up()
+	if err == nil || len(done) != 1 {
+		t.Fatalf("Up() = %d migrations, %v; want 1 and an error", len(done), err)
+	}
+	if version, _ := migrator.Version(); version != 1 {
+		t.Fatalf("version after failed migration = %d, want 1", version)
+	}
+}
+
+func TestNewerSchemaIsRejected(t *testing.T) {
+	migrator, _ := newMigrator(t, files)
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("Up returned error: %v", err)
+	}
+
+	// Binary lama yang hanya mengenal migration 0001
+	older := &migrate.Migrator{DB: migrator.DB, Migrations: migrator.Migrations[:1]}
+	if err := older.CheckVersion(); err == nil || !strings.Contains(err.Error(), "newer") {
+		t.Fatalf("CheckVersion = %v, want newer schema error", err)
+	}
+}
+
+func TestLoadRejectsInvalidFiles(t *testing.T) {
+	cases := map[string]fstest.MapFS{
+		"missing down": {"migrations/0001_a.up.sql": {Data: []byte("SELECT 1;")}},
+		"bad name":     {"migrations/create.sql": {Data: []byte("SELECT 1;")}},
+	}
+	for name, fsys := range cases {
+		if _, err := migrate.Load(fsys, "migrations"); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
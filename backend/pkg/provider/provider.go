@@ -0,0 +1,133 @@
+// Package provider abstraksi acquirer/payment gateway. Setiap adapter menentukan cara membuat
+// QR, mengautentikasi dan mem-parse callback menjadi PaymentEvent, serta menanyakan status
+// transaksi. TransactionService hanya bekerja dengan PaymentEvent, jadi acquirer baru cukup
+// ditambahkan sebagai adapter dan didaftarkan di Registry.
+package provider
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+)
+
+// DefaultName provider bawaan: gateway ID.CO.MANJO.WWW lewat adapter SNAP
+const DefaultName = "manjo"
+
+// ErrInquiryNotSupported provider tidak punya (atau belum dikonfigurasi) endpoint inquiry status
+var ErrInquiryNotSupported = errors.New("status inquiry not supported by provider")
+
+// Payer data pembayar dari issuer, PAN bisa belum di-mask
+type Payer struct {
+	IssuerID     string
+	IssuerName   string
+	CustomerPAN  string
+	CustomerName string
+	RRN          string
+	ApprovalCode string
+}
+
+// PaymentEvent hasil callback atau inquiry dalam bentuk yang sama untuk semua provider
+type PaymentEvent struct {
+	Provider           string
+	ReferenceNo        string
+	PartnerReferenceNo string
+	// Status mentah dari provider (kode atau deskripsi), dipetakan lewat status_mapping provider
+	Status string
+	// StatusDesc deskripsi status untuk response, contoh "Success"
+	StatusDesc string
+	Amount     string // contoh "10000.00"
+	Currency   string
+	PaidTime   string // RFC3339, wajib jika status dipetakan ke PAID
+	Payer      Payer
+}
+
+// QRRequest data transaksi yang di-encode ke QR
+type QRRequest struct {
+	MerchantID  string
+	ReferenceNo string
+	TerminalID  string
+}
+
+// InquiryRequest transaksi yang statusnya ditanyakan ke provider
+type InquiryRequest struct {
+	ReferenceNo        string
+	PartnerReferenceNo string
+}
+
+type Provider interface {
+	Name() string
+	// BuildQR payload QR (EMVCo) untuk transaksi
+	BuildQR(req QRRequest) string
+	// AuthenticateCallback memverifikasi bahwa callback benar dari provider. header mengembalikan
+	// nilai header request, kosong jika tidak ada.
+	AuthenticateCallback(header func(key string) string, body []byte) error
+	// ParseCallback mengubah body callback menjadi PaymentEvent
+	ParseCallback(body []byte) (PaymentEvent, error)
+	// InquireStatus menanyakan status transaksi ke provider, ErrInquiryNotSupported jika tidak bisa
+	InquireStatus(ctx context.Context, req InquiryRequest) (PaymentEvent, error)
+}
+
+// Registry provider yang aktif dan routing merchant ke provider
+type Registry struct {
+	providers   map[string]Provider
+	defaultName string
+	merchants   map[string]string
+}
+
+// NewRegistry provider pertama menjadi default
+func NewRegistry(providers ...Provider) *Registry {
+	r := &Registry{providers: make(map[string]Provider), merchants: make(map[string]string)}
+	for _, p := range providers {
+		r.Register(p)
+	}
+	return r
+}
+
+// Register menambahkan provider; panic jika nama sudah dipakai
+func (r *Registry) Register(p Provider) {
+	if _, exists := r.providers[p.Name()]; exists {
+		panic("provider: duplicate provider " + p.Name())
+	}
+	r.providers[p.Name()] = p
+	if r.defaultName == "" {
+		r.defaultName = p.Name()
+	}
+}
+
+// SetDefault provider untuk merchant tanpa routing dan transaksi lama tanpa provider
+func (r *Registry) SetDefault(name string) {
+	r.defaultName = name
+}
+
+// Route mengarahkan transaksi baru merchant ke provider
+func (r *Registry) Route(merchantID string, name string) {
+	r.merchants[merchantID] = name
+}
+
+// Default provider default
+func (r *Registry) Default() Provider {
+	return r.providers[r.defaultName]
+}
+
+// Get provider berdasarkan nama; nama kosong (transaksi sebelum ada provider) berarti default
+func (r *Registry) Get(name string) (Provider, bool) {
+	if name == "" {
+		name = r.defaultName
+	}
+	p, ok := r.providers[name]
+	return p, ok
+}
+
+// ForMerchant provider untuk transaksi baru merchant
+func (r *Registry) ForMerchant(merchantID string) Provider {
+	if p, ok := r.providers[r.merchants[merchantID]]; ok {
+		return p
+	}
+	return r.Default()
+}
+
+// Names nama semua provider, terurut
+func (r *Registry) Names() []string {
+	return slices.Sorted(maps.Keys(r.providers))
+}
@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"qr-service/pkg/apperror"
+	"qr-service/pkg/metrics"
+	"qr-service/pkg/snap"
+	"qr-service/pkg/tracing"
+	"qr-service/pkg/util"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// TypeSNAP adapter acquirer yang mengikuti SNAP BI QRIS MPM: callback JSON Payment Notify
+// dengan X-Signature HMAC-SHA256 dari snap.StringToSign (X-TIMESTAMP, X-EXTERNAL-ID dan body),
+// dan inquiry lewat Query Payment.
+const TypeSNAP = "snap"
+
+// channelID CHANNEL-ID yang dikirim saat inquiry
+const channelID = "95221"
+
+// SNAPOptions konfigurasi satu acquirer SNAP
+type SNAPOptions struct {
+	Name       string
+	HMACSecret string
+	QRTemplate string // kosong: util.DefaultQRTemplate
+	InquiryURL string // kosong: inquiry tidak didukung
+	PartnerID  string // X-PARTNER-ID saat inquiry
+	Client     *http.Client
+}
+
+type snapProvider struct {
+	opts SNAPOptions
+	qr   util.QRGenerator
+}
+
+func NewSNAP(opts SNAPOptions) Provider {
+	if opts.QRTemplate == "" {
+		opts.QRTemplate = util.DefaultQRTemplate
+	}
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &snapProvider{opts: opts, qr: util.NewQRGeneratorWithTemplate(opts.QRTemplate)}
+}
+
+// snapAmount amount SNAP {value, currency}
+type snapAmount struct {
+	Value    string `json:"value"`
+	Currency string `json:"currency"`
+}
+
+type snapAdditionalInfo struct {
+	IssuerID     string `json:"issuerId"`
+	IssuerName   string `json:"issuerName"`
+	CustomerPAN  string `json:"customerPan"`
+	CustomerName string `json:"customerName"`
+	RRN          string `json:"rrn"`
+	ApprovalCode string `json:"approvalCode"`
+}
+
+// snapPayment body Payment Notify dan response Query Payment
+type snapPayment struct {
+	ResponseCode               string              `json:"responseCode"`
+	ResponseMessage            string              `json:"responseMessage"`
+	OriginalReferenceNo        string              `json:"originalReferenceNo"`
+	OriginalPartnerReferenceNo string              `json:"originalPartnerReferenceNo"`
+	LatestTransactionStatus    string              `json:"latestTransactionStatus"`
+	TransactionStatusDesc      string              `json:"transactionStatusDesc"`
+	PaidTime                   string              `json:"paidTime"`
+	Amount                     snapAmount          `json:"amount"`
+	AdditionalInfo             *snapAdditionalInfo `json:"additionalInfo"`
+}
+
+func (p *snapProvider) Name() string {
+	return p.opts.Name
+}
+
+func (p *snapProvider) BuildQR(req QRRequest) string {
+	return p.qr.GenerateQRContent(req.MerchantID, req.ReferenceNo, req.TerminalID)
+}
+
+func (p *snapProvider) AuthenticateCallback(header func(key string) string, body []byte) error {
+	signature := header("X-Signature")
+	if signature == "" {
+		metrics.SignatureFailuresTotal.WithLabelValues("missing").Inc()
+		return apperror.New(apperror.Unauthorized, "Signature header missing")
+	}
+	data := snap.StringToSign(header(snap.HeaderTimestamp), header(snap.HeaderExternalID), body)
+	if !util.ValidateHMACSHA256(p.opts.HMACSecret, signature, data) {
+		metrics.SignatureFailuresTotal.WithLabelValues("invalid").Inc()
+		return apperror.New(apperror.Unauthorized, "Invalid Signature Hash")
+	}
+	return nil
+}
+
+func (p *snapProvider) ParseCallback(body []byte) (PaymentEvent, error) {
+	var payment snapPayment
+	if err := json.Unmarshal(body, &payment); err != nil {
+		return PaymentEvent{}, apperror.Wrap(err, apperror.BadRequest, "Invalid request body format")
+	}
+
+	// Field wajib Payment Notify, urutan sama dengan validasi model.PaymentCallbackRequest
+	for _, field := range []struct{ name, value string }{
+		{"originalReferenceNo", payment.OriginalReferenceNo},
+		{"originalPartnerReferenceNo", payment.OriginalPartnerReferenceNo},
+		{"transactionStatusDesc", payment.TransactionStatusDesc},
+		{"paidTime", payment.PaidTime},
+		{"amount.value", payment.Amount.Value},
+		{"amount.currency", payment.Amount.Currency},
+	} {
+		if field.value == "" {
+			return PaymentEvent{}, apperror.Field(apperror.InvalidMandatoryField, field.name)
+		}
+	}
+	return p.event(payment), nil
+}
+
+func (p *snapProvider) event(payment snapPayment) PaymentEvent {
+	event := PaymentEvent{
+		Provider:           p.opts.Name,
+		ReferenceNo:        payment.OriginalReferenceNo,
+		PartnerReferenceNo: payment.OriginalPartnerReferenceNo,
+		Status:             payment.TransactionStatusDesc,
+		StatusDesc:         payment.TransactionStatusDesc,
+		Amount:             payment.Amount.Value,
+		Currency:           payment.Amount.Currency,
+		PaidTime:           payment.PaidTime,
+	}
+	// Kode latestTransactionStatus (00-07) lebih pasti daripada deskripsi bebas
+	if payment.LatestTransactionStatus != "" {
+		event.Status = payment.LatestTransactionStatus
+	}
+	if info := payment.AdditionalInfo; info != nil {
+		event.Payer = Payer(*info)
+	}
+	return event
+}
+
+// InquireStatus memanggil Query Payment SNAP (service code 51) yang ditandatangani HMAC
+func (p *snapProvider) InquireStatus(ctx context.Context, req InquiryRequest) (event PaymentEvent, err error) {
+	if p.opts.InquiryURL == "" {
+		return PaymentEvent{}, ErrInquiryNotSupported
+	}
+
+	// Client span untuk request ke provider; trace context ikut dikirim lewat header traceparent
+	ctx, span := tracing.StartClient(ctx, "Provider.InquireStatus",
+		attribute.String("provider.name", p.opts.Name), attribute.String("transaction.reference_no", req.ReferenceNo))
+	defer func() { tracing.End(span, err) }()
+
+	body, err := json.Marshal(map[string]string{
+		"originalReferenceNo":        req.ReferenceNo,
+		"originalPartnerReferenceNo": req.PartnerReferenceNo,
+		"serviceCode":                snap.QueryPayment.Code,
+	})
+	if err != nil {
+		return PaymentEvent{}, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.opts.InquiryURL, bytes.NewReader(body))
+	if err != nil {
+		return PaymentEvent{}, err
+	}
+	now := time.Now()
+	timestamp := now.In(util.WIB).Format(time.RFC3339)
+	externalID := strconv.FormatInt(now.UnixNano(), 10)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("X-Signature", util.GenerateHMACSHA256(p.opts.HMACSecret, snap.StringToSign(timestamp, externalID, body)))
+	httpReq.Header.Set(snap.HeaderTimestamp, timestamp)
+	httpReq.Header.Set(snap.HeaderPartnerID, p.opts.PartnerID)
+	httpReq.Header.Set(snap.HeaderExternalID, externalID)
+	httpReq.Header.Set(snap.HeaderChannelID, channelID)
+	tracing.Inject(ctx, propagation.HeaderCarrier(httpReq.Header))
+
+	resp, err := p.opts.Client.Do(httpReq)
+	if err != nil {
+		return PaymentEvent{}, fmt.Errorf("provider %s inquiry: %w", p.opts.Name, err)
+	}
+	defer resp.Body.Close()
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+
+	var payment snapPayment
+	if err := json.NewDecoder(resp.Body).Decode(&payment); err != nil {
+		return PaymentEvent{}, fmt.Errorf("provider %s inquiry: HTTP %d, invalid response: %w", p.opts.Name, resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(payment.ResponseCode, "200") {
+		return PaymentEvent{}, fmt.Errorf("provider %s inquiry: HTTP %d, %s %s", p.opts.Name, resp.StatusCode, payment.ResponseCode, payment.ResponseMessage)
+	}
+
+	// Response boleh tidak mengulang reference dari request
+	if payment.OriginalReferenceNo == "" {
+		payment.OriginalReferenceNo = req.ReferenceNo
+	}
+	if payment.OriginalPartnerReferenceNo == "" {
+		payment.OriginalPartnerReferenceNo = req.PartnerReferenceNo
+	}
+	return p.event(payment), nil
+}
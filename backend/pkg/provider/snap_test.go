@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"qr-service/pkg/apperror"
+	"qr-service/pkg/snap"
+	"qr-service/pkg/util"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const testSecret = "provider-secret"
+
+func TestSNAPBuildQRMatchesDefaultTemplate(t *testing.T) {
+	p := NewSNAP(SNAPOptions{Name: DefaultName})
+	want := util.NewQRGenerator().GenerateQRContent("M001", "A001", "T01")
+	if got := p.BuildQR(QRRequest{MerchantID: "M001", ReferenceNo: "A001", TerminalID: "T01"}); got != want {
+		t.Fatalf("BuildQR = %q, want %q", got, want)
+	}
+}
+
+func TestSNAPCallback(t *testing.T) {
+	p := NewSNAP(SNAPOptions{Name: "acme", HMACSecret: testSecret})
+	body := []byte(`{"originalReferenceNo":"A001","originalPartnerReferenceNo":"P001","latestTransactionStatus":"00","transactionStatusDesc":"Success","paidTime":"2025-09-21T10:00:00+07:00","amount":{"value":"10000.00","currency":"IDR"},"additionalInfo":{"issuerName":"BCA","rrn":"123456789012"}}`)
+
+	headers := map[string]string{snap.HeaderTimestamp: "2025-09-21T10:00:00+07:00", snap.HeaderExternalID: "1001"}
+	headers["X-Signature"] = util.GenerateHMACSHA256(testSecret, snap.StringToSign(headers[snap.HeaderTimestamp], "1001", body))
+	if err := p.AuthenticateCallback(func(key string) string { return headers[key] }, body); err != nil {
+		t.Fatalf("AuthenticateCallback returned error: %v", err)
+	}
+	headers[snap.HeaderExternalID] = "1002"
+	if err := p.AuthenticateCallback(func(key string) string { return headers[key] }, body); apperror.CodeOf(err) != apperror.Unauthorized {
+		t.Fatalf("AuthenticateCallback with unsigned X-EXTERNAL-ID = %v, want Unauthorized", err)
+	}
+	headers["X-Signature"] = util.GenerateHMACSHA256("other-secret", snap.StringToSign(headers[snap.HeaderTimestamp], "1002", body))
+	if err := p.AuthenticateCallback(func(key string) string { return headers[key] }, body); apperror.CodeOf(err) != apperror.Unauthorized {
+		t.Fatalf("AuthenticateCallback with wrong secret = %v, want UNAUTHORIZED", err)
+	}
+
+	event, err := p.ParseCallback(body)
+	if err != nil {
+		t.Fatalf("ParseCallback returned error: %v", err)
+	}
+	want := PaymentEvent{
+		Provider: "acme", ReferenceNo: "A001", PartnerReferenceNo: "P001", Status: "00", StatusDesc: "Success",
+		Amount: "10000.00", Currency: "IDR", PaidTime: "2025-09-21T10:00:00+07:00",
+		Payer: Payer{IssuerName: "BCA", RRN: "123456789012"},
+	}
+	if event != want {
+		t.Fatalf("ParseCallback = %+v, want %+v", event, want)
+	}
+
+	if _, err := p.ParseCallback([]byte(`{"originalReferenceNo":"A001"}`)); err == nil || err.Error() != "Invalid Mandatory Field originalPartnerReferenceNo" {
+		t.Fatalf("ParseCallback without mandatory field = %v", err)
+	}
+}
+
+func TestSNAPInquireStatus(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled,
+	}))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		var body map[string]string
+		if err := json.Unmarshal(raw, &body); err != nil || body["originalReferenceNo"] != "A001" || body["serviceCode"] != snap.QueryPayment.Code {
+			t.Errorf("unexpected inquiry body %v (%v)", body, err)
+		}
+		data := snap.StringToSign(r.Header.Get(snap.HeaderTimestamp), r.Header.Get(snap.HeaderExternalID), raw)
+		if !util.ValidateHMACSHA256(testSecret, r.Header.Get("X-Signature"), data) || r.Header.Get(snap.HeaderPartnerID) != "QR-SERVICE" {
+			t.Errorf("unexpected inquiry headers %v", r.Header)
+		}
+		if !strings.Contains(r.Header.Get("traceparent"), traceID.String()) {
+			t.Errorf("traceparent = %q, want trace %s", r.Header.Get("traceparent"), traceID)
+		}
+		w.Write([]byte(`{"responseCode":"2005100","responseMessage":"Successful","latestTransactionStatus":"03","transactionStatusDesc":"Pending","amount":{"value":"10000.00","currency":"IDR"}}`))
+	}))
+	defer server.Close()
+
+	p := NewSNAP(SNAPOptions{Name: "acme", HMACSecret: testSecret, InquiryURL: server.URL, PartnerID: "QR-SERVICE"})
+	event, err := p.InquireStatus(ctx, InquiryRequest{ReferenceNo: "A001", PartnerReferenceNo: "P001"})
+	if err != nil {
+		t.Fatalf("InquireStatus returned error: %v", err)
+	}
+	if event.Status != "03" || event.ReferenceNo != "A001" || event.PartnerReferenceNo != "P001" || event.PaidTime != "" {
+		t.Fatalf("InquireStatus = %+v", event)
+	}
+
+	if _, err := NewSNAP(SNAPOptions{Name: "acme"}).InquireStatus(context.Background(), InquiryRequest{}); !errors.Is(err, ErrInquiryNotSupported) {
+		t.Fatalf("InquireStatus without URL = %v, want ErrInquiryNotSupported", err)
+	}
+}
+
+func TestRegistryRouting(t *testing.T) {
+	registry := NewRegistry(NewSNAP(SNAPOptions{Name: DefaultName}), NewSNAP(SNAPOptions{Name: "acme"}))
+	registry.Route("M002", "acme")
+
+	if registry.ForMerchant("M001").Name() != DefaultName || registry.ForMerchant("M002").Name() != "acme" {
+		t.Fatal("unexpected merchant routing")
+	}
+	if p, ok := registry.Get(""); !ok || p.Name() != DefaultName {
+		t.Fatal("empty provider should resolve to default")
+	}
+	if _, ok := registry.Get("unknown"); ok {
+		t.Fatal("unknown provider should not resolve")
+	}
+}
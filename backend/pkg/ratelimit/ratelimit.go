@@ -0,0 +1,108 @@
+// Package ratelimit rate limit token bucket dengan store yang bisa diganti: memori untuk
+// satu instance, atau database (lihat repository.RateLimitRepository) agar budget
+// dibagi oleh semua instance.
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"time"
+)
+
+// Siapa yang berbagi satu bucket dalam group
+const (
+	KeyPartner  = "partner"  // header X-PARTNER-ID
+	KeyMerchant = "merchant" // merchantId di path, query atau body
+	KeyIP       = "ip"
+)
+
+// Rule budget satu bucket: Burst token penuh, diisi ulang Rate token per detik
+type Rule struct {
+	Rate  float64
+	Burst int
+}
+
+// PerMinute rule dengan rata-rata requests request per menit dan burst maksimal
+func PerMinute(requests float64, burst int) Rule {
+	return Rule{Rate: requests / 60, Burst: burst}
+}
+
+// FullRefill waktu bucket kosong terisi penuh. Bucket yang tidak dipakai selama ini
+// sama dengan bucket baru, jadi aman dihapus.
+func (r Rule) FullRefill() time.Duration {
+	return time.Duration(float64(r.Burst) / r.Rate * float64(time.Second))
+}
+
+// Result hasil pengambilan satu token
+type Result struct {
+	Allowed    bool
+	Limit      int           // Burst rule
+	Remaining  int           // token utuh yang tersisa
+	RetryAfter time.Duration // waktu sampai satu token tersedia, hanya jika ditolak
+}
+
+// Store menyimpan state bucket. Take harus atomic per key.
+type Store interface {
+	Take(ctx context.Context, key string, rule Rule, now time.Time) (Result, error)
+	// DeleteIdle menghapus bucket yang terakhir dipakai sebelum before
+	DeleteIdle(ctx context.Context, before time.Time) error
+}
+
+// Refill jumlah token bucket pada now, dari tokens pada updatedAt
+func Refill(rule Rule, tokens float64, updatedAt time.Time, now time.Time) float64 {
+	if elapsed := now.Sub(updatedAt); elapsed > 0 {
+		tokens += elapsed.Seconds() * rule.Rate
+	}
+	return math.Min(tokens, float64(rule.Burst))
+}
+
+// NewResult membuat Result dari sisa token setelah Take
+func NewResult(rule Rule, tokens float64, allowed bool) Result {
+	result := Result{Allowed: allowed, Limit: rule.Burst, Remaining: int(math.Floor(tokens))}
+	if !allowed {
+		result.RetryAfter = time.Duration((1 - tokens) / rule.Rate * float64(time.Second))
+	}
+	return result
+}
+
+// Limiter menerapkan rule per group route (contoh "qr", "transactions") di atas Store
+type Limiter struct {
+	Store Store
+	Rules map[string]Rule
+}
+
+func NewLimiter(store Store, rules map[string]Rule) *Limiter {
+	return &Limiter{Store: store, Rules: rules}
+}
+
+// Allow mengambil satu token dari bucket key di group. Group tanpa rule selalu diizinkan.
+func (l *Limiter) Allow(ctx context.Context, group string, key string) (Result, error) {
+	rule, ok := l.Rules[group]
+	if !ok {
+		return Result{Allowed: true}, nil
+	}
+	return l.Store.Take(ctx, group+":"+key, rule, time.Now())
+}
+
+// RunCleanup menghapus bucket yang sudah terisi penuh setiap interval sampai ctx dibatalkan,
+// agar bucket per IP tidak menumpuk.
+func (l *Limiter) RunCleanup(ctx context.Context, interval time.Duration) {
+	var maxRefill time.Duration
+	for _, rule := range l.Rules {
+		maxRefill = max(maxRefill, rule.FullRefill())
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := l.Store.DeleteIdle(ctx, now.Add(-maxRefill)); err != nil {
+				slog.WarnContext(ctx, "Failed to delete idle rate limit buckets", "error", err)
+			}
+		}
+	}
+}
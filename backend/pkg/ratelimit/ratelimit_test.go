@@ -0,0 +1,61 @@
+package ratelimit_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"qr-service/pkg/ratelimit"
+	"qr-service/pkg/ratelimit/ratelimittest"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ratelimittest.RunStoreTests(t, func(t *testing.T) ratelimit.Store {
+		return ratelimit.NewMemoryStore()
+	})
+}
+
+func TestMemoryStoreConcurrentTakes(t *testing.T) {
+	store := ratelimit.NewMemoryStore()
+	rule := ratelimit.PerMinute(1, 10)
+	now := time.Now()
+
+	var allowed atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if result, _ := store.Take(context.Background(), "k", rule, now); result.Allowed {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if allowed.Load() != 10 {
+		t.Fatalf("allowed = %d, want exactly burst 10", allowed.Load())
+	}
+}
+
+func TestLimiterGroups(t *testing.T) {
+	store := ratelimit.NewMemoryStore()
+	limiter := ratelimit.NewLimiter(store, map[string]ratelimit.Rule{"qr": ratelimit.PerMinute(60, 1)})
+
+	if result, _ := limiter.Allow(context.Background(), "qr", "partner:P1"); !result.Allowed {
+		t.Fatal("first request should be allowed")
+	}
+	if result, _ := limiter.Allow(context.Background(), "qr", "partner:P1"); result.Allowed {
+		t.Fatal("second request should exceed burst")
+	}
+	// Group tanpa rule tidak dibatasi dan tidak membuat bucket
+	for i := 0; i < 5; i++ {
+		if result, _ := limiter.Allow(context.Background(), "reports", "ip:1.2.3.4"); !result.Allowed {
+			t.Fatal("group without rule should not be limited")
+		}
+	}
+	if store.Len() != 1 {
+		t.Fatalf("buckets = %d, want 1", store.Len())
+	}
+}
@@ -0,0 +1,115 @@
+// Package ratelimittest suite test bersama untuk implementasi ratelimit.Store
+package ratelimittest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"qr-service/pkg/ratelimit"
+)
+
+// NewStoreFunc membuat store kosong untuk satu subtest
+type NewStoreFunc func(t *testing.T) ratelimit.Store
+
+// RunStoreTests menjalankan seluruh suite terhadap implementasi store
+func RunStoreTests(t *testing.T, newStore NewStoreFunc) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, store ratelimit.Store)
+	}{
+		{"BurstThenReject", testBurstThenReject},
+		{"Refill", testRefill},
+		{"RefillCappedAtBurst", testRefillCappedAtBurst},
+		{"KeysAreIndependent", testKeysAreIndependent},
+		{"DeleteIdle", testDeleteIdle},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run(t, newStore(t))
+		})
+	}
+}
+
+// rule 60 request per menit (1 token per detik), burst 3
+var rule = ratelimit.PerMinute(60, 3)
+
+var start = time.Date(2025, 9, 21, 10, 0, 0, 0, time.UTC)
+
+func take(t *testing.T, store ratelimit.Store, key string, now time.Time) ratelimit.Result {
+	t.Helper()
+	result, err := store.Take(context.Background(), key, rule, now)
+	if err != nil {
+		t.Fatalf("Take(%s) returned error: %v", key, err)
+	}
+	return result
+}
+
+func testBurstThenReject(t *testing.T, store ratelimit.Store) {
+	for i := 0; i < rule.Burst; i++ {
+		result := take(t, store, "k", start)
+		if !result.Allowed || result.Remaining != rule.Burst-1-i || result.Limit != rule.Burst {
+			t.Fatalf("take %d = %+v, want allowed with %d remaining", i, result, rule.Burst-1-i)
+		}
+	}
+
+	result := take(t, store, "k", start)
+	if result.Allowed || result.Remaining != 0 {
+		t.Fatalf("take after burst = %+v, want rejected", result)
+	}
+	if result.RetryAfter <= 900*time.Millisecond || result.RetryAfter > time.Second {
+		t.Fatalf("RetryAfter = %s, want about 1s", result.RetryAfter)
+	}
+}
+
+func testRefill(t *testing.T, store ratelimit.Store) {
+	for i := 0; i < rule.Burst; i++ {
+		take(t, store, "k", start)
+	}
+	if result := take(t, store, "k", start.Add(500*time.Millisecond)); result.Allowed {
+		t.Fatalf("take after 0.5s = %+v, want rejected", result)
+	}
+	if result := take(t, store, "k", start.Add(time.Second)); !result.Allowed {
+		t.Fatalf("take after 1s = %+v, want allowed", result)
+	}
+	if result := take(t, store, "k", start.Add(time.Second)); result.Allowed {
+		t.Fatalf("second take after 1s = %+v, want rejected", result)
+	}
+}
+
+func testRefillCappedAtBurst(t *testing.T, store ratelimit.Store) {
+	take(t, store, "k", start)
+	result := take(t, store, "k", start.Add(time.Hour))
+	if !result.Allowed || result.Remaining != rule.Burst-1 {
+		t.Fatalf("take after idle hour = %+v, want %d remaining", result, rule.Burst-1)
+	}
+}
+
+func testKeysAreIndependent(t *testing.T, store ratelimit.Store) {
+	for i := 0; i < rule.Burst; i++ {
+		take(t, store, "a", start)
+	}
+	if result := take(t, store, "b", start); !result.Allowed {
+		t.Fatalf("take on other key = %+v, want allowed", result)
+	}
+}
+
+func testDeleteIdle(t *testing.T, store ratelimit.Store) {
+	for i := 0; i < rule.Burst; i++ {
+		take(t, store, "old", start)
+	}
+	take(t, store, "recent", start.Add(time.Minute))
+
+	if err := store.DeleteIdle(context.Background(), start.Add(time.Second)); err != nil {
+		t.Fatalf("DeleteIdle returned error: %v", err)
+	}
+
+	// Bucket lama terhapus: kembali penuh walaupun waktunya tidak bergerak
+	if result := take(t, store, "old", start); !result.Allowed || result.Remaining != rule.Burst-1 {
+		t.Fatalf("take on deleted bucket = %+v, want fresh bucket", result)
+	}
+	if result := take(t, store, "recent", start.Add(time.Minute)); result.Remaining != rule.Burst-2 {
+		t.Fatalf("take on kept bucket = %+v, want %d remaining", result, rule.Burst-2)
+	}
+}
@@ -0,0 +1,123 @@
+package settlement
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVColumns nama header kolom di file CSV settlement
+type CSVColumns struct {
+	ReferenceNo        string
+	PartnerReferenceNo string
+	Amount             string
+	Currency           string
+	Status             string
+	PaidTime           string
+}
+
+// DefaultCSVColumns header default file settlement CSV
+var DefaultCSVColumns = CSVColumns{
+	ReferenceNo:        "reference_no",
+	PartnerReferenceNo: "partner_reference_no",
+	Amount:             "amount",
+	Currency:           "currency",
+	Status:             "status",
+	PaidTime:           "paid_time",
+}
+
+type csvParser struct {
+	columns CSVColumns
+}
+
+// NewCSVParser membuat parser CSV dengan mapping header tertentu.
+// Hanya kolom reference_no dan amount yang wajib ada.
+func NewCSVParser(columns CSVColumns) Parser {
+	return &csvParser{columns: columns}
+}
+
+func (p *csvParser) Parse(r io.Reader) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("settlement file is empty")
+		}
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	index := map[string]int{}
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+
+	col := func(name string) int {
+		if i, ok := index[strings.ToLower(name)]; ok {
+			return i
+		}
+		return -1
+	}
+	refCol, amountCol := col(p.columns.ReferenceNo), col(p.columns.Amount)
+	if refCol < 0 || amountCol < 0 {
+		return nil, fmt.Errorf("missing required column %q or %q", p.columns.ReferenceNo, p.columns.Amount)
+	}
+	partnerCol, currencyCol := col(p.columns.PartnerReferenceNo), col(p.columns.Currency)
+	statusCol, paidCol := col(p.columns.Status), col(p.columns.PaidTime)
+
+	var records []Record
+	line := 1
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		field := func(i int) string {
+			if i < 0 || i >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[i])
+		}
+
+		if field(refCol) == "" {
+			continue
+		}
+
+		amount, err := strconv.ParseFloat(field(amountCol), 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid amount %q", line, field(amountCol))
+		}
+
+		record := Record{
+			Line:               line,
+			ReferenceNo:        field(refCol),
+			PartnerReferenceNo: field(partnerCol),
+			Amount:             amount,
+			Currency:           field(currencyCol),
+			Status:             field(statusCol),
+		}
+
+		if paid := field(paidCol); paid != "" {
+			paidTime, err := time.Parse(time.RFC3339, paid)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid paid_time %q, use RFC3339", line, paid)
+			}
+			record.PaidTime = &paidTime
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
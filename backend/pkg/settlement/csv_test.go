@@ -0,0 +1,78 @@
+package settlement
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCSVParser(t *testing.T) {
+	paidTime := time.Date(2025, 9, 21, 3, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name    string
+		content string
+		want    []Record
+		wantErr string
+	}{
+		{
+			name:    "default columns",
+			content: "reference_no,partner_reference_no,amount,currency,status,paid_time\nA001,P001,10000.00,IDR,SUCCESS,2025-09-21T10:00:00+07:00\n",
+			want:    []Record{{Line: 2, ReferenceNo: "A001", PartnerReferenceNo: "P001", Amount: 10000, Currency: "IDR", Status: "SUCCESS", PaidTime: &paidTime}},
+		},
+		{
+			name:    "bom, header case and column order",
+			content: "\ufeffAmount, Reference_No\n 5000.5, A002\n",
+			want:    []Record{{Line: 2, ReferenceNo: "A002", Amount: 5000.5}},
+		},
+		{
+			name:    "rows without reference are skipped",
+			content: "reference_no,amount,status\n,1000\nA003,2000\n",
+			want:    []Record{{Line: 3, ReferenceNo: "A003", Amount: 2000}},
+		},
+		{
+			name:    "duplicate lines are kept",
+			content: "reference_no,amount\nA001,1000\nA001,1000\n",
+			want:    []Record{{Line: 2, ReferenceNo: "A001", Amount: 1000}, {Line: 3, ReferenceNo: "A001", Amount: 1000}},
+		},
+		{name: "empty file", content: "", wantErr: "settlement file is empty"},
+		{name: "missing amount column", content: "reference_no,status\nA001,SUCCESS\n", wantErr: `missing required column "reference_no" or "amount"`},
+		{name: "invalid amount", content: "reference_no,amount\nA001,Rp10000\n", wantErr: `line 2: invalid amount "Rp10000"`},
+		{name: "invalid paid time", content: "reference_no,amount,paid_time\nA001,1000,21-09-2025\n", wantErr: `line 2: invalid paid_time "21-09-2025"`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			records, err := NewCSVParser(DefaultCSVColumns).Parse(strings.NewReader(tc.content))
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse returned error: %v", err)
+			}
+			if len(records) != len(tc.want) {
+				t.Fatalf("records = %+v, want %+v", records, tc.want)
+			}
+			for i, want := range tc.want {
+				got := records[i]
+				if got.PaidTime != nil && want.PaidTime != nil && got.PaidTime.Equal(*want.PaidTime) {
+					got.PaidTime = want.PaidTime
+				}
+				if got != want {
+					t.Fatalf("record %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGetUnknownFormat(t *testing.T) {
+	if _, err := Get("bca"); err == nil || err.Error() != "unsupported settlement format: bca" {
+		t.Fatalf("Get(bca) error = %v", err)
+	}
+	if formats := Formats(); len(formats) == 0 || formats[0] != "csv" {
+		t.Fatalf("Formats() = %v, want csv registered", formats)
+	}
+}
@@ -0,0 +1,64 @@
+package settlement
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Record satu baris transaksi di file settlement dari acquirer/bank
+type Record struct {
+	Line               int
+	ReferenceNo        string
+	PartnerReferenceNo string
+	Amount             float64
+	Currency           string
+	Status             string
+	PaidTime           *time.Time
+}
+
+// Parser mengubah file settlement format tertentu menjadi daftar Record
+type Parser interface {
+	Parse(r io.Reader) ([]Record, error)
+}
+
+var (
+	parsersMu sync.RWMutex
+	parsers   = map[string]Parser{}
+)
+
+// Register mendaftarkan parser untuk sebuah format (misal "csv", "bca", "mandiri")
+func Register(format string, parser Parser) {
+	parsersMu.Lock()
+	defer parsersMu.Unlock()
+	parsers[format] = parser
+}
+
+// Get mengambil parser berdasarkan nama format
+func Get(format string) (Parser, error) {
+	parsersMu.RLock()
+	defer parsersMu.RUnlock()
+	parser, ok := parsers[format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported settlement format: %s", format)
+	}
+	return parser, nil
+}
+
+// Formats mengembalikan semua format yang terdaftar
+func Formats() []string {
+	parsersMu.RLock()
+	defer parsersMu.RUnlock()
+	formats := make([]string, 0, len(parsers))
+	for format := range parsers {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+func init() {
+	Register("csv", NewCSVParser(DefaultCSVColumns))
+}
@@ -0,0 +1,58 @@
+package snap
+
+import (
+	"sync"
+	"time"
+
+	"qr-service/pkg/util"
+)
+
+// ExternalIDStore mencatat X-EXTERNAL-ID yang sudah dipakai. SNAP mewajibkan
+// X-EXTERNAL-ID unik per partner dalam satu hari (WIB).
+//
+// Catatan disimpan di memori per instance dan dibuang saat hari berganti: keunikan hanya
+// berlaku di satu instance dan hilang saat restart. Jika service dijalankan lebih dari satu
+// replica, request satu partner harus diarahkan ke instance yang sama. Replay antar instance
+// tetap dibatasi jendela TimestampSkew karena X-TIMESTAMP ikut ditandatangani.
+type ExternalIDStore struct {
+	mu   sync.Mutex
+	day  string
+	seen map[string]struct{}
+}
+
+func NewExternalIDStore() *ExternalIDStore {
+	return &ExternalIDStore{seen: make(map[string]struct{})}
+}
+
+// Reserve mencatat externalID milik partnerID pada waktu at.
+// Mengembalikan false jika ID yang sama sudah dipakai hari itu.
+func (s *ExternalIDStore) Reserve(partnerID string, externalID string, at time.Time) bool {
+	day := at.In(util.WIB).Format("2006-01-02")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if day != s.day {
+		s.day = day
+		s.seen = make(map[string]struct{})
+	}
+	key := partnerID + "|" + externalID
+	if _, ok := s.seen[key]; ok {
+		return false
+	}
+	s.seen[key] = struct{}{}
+	return true
+}
+
+// Release membatalkan Reserve untuk externalID milik partnerID, dipakai saat request gagal
+// di sisi server (5xx) agar partner boleh mengulang dengan X-EXTERNAL-ID yang sama
+func (s *ExternalIDStore) Release(partnerID string, externalID string, at time.Time) {
+	day := at.In(util.WIB).Format("2006-01-02")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if day == s.day {
+		delete(s.seen, partnerID+"|"+externalID)
+	}
+}
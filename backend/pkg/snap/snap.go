@@ -0,0 +1,67 @@
+// Package snap registry service code SNAP BI per endpoint dan utilitas header standar SNAP.
+// Response code SNAP = HTTP status (3 digit) + service code (2 digit) + case code (2 digit);
+// case code ada di katalog pkg/apperror.
+package snap
+
+import (
+	"fmt"
+	"time"
+)
+
+// Header wajib request SNAP
+const (
+	HeaderTimestamp  = "X-TIMESTAMP"
+	HeaderPartnerID  = "X-PARTNER-ID"
+	HeaderExternalID = "X-EXTERNAL-ID"
+	HeaderChannelID  = "CHANNEL-ID"
+)
+
+// TimestampSkew selisih maksimum X-TIMESTAMP terhadap jam server. Request di luar jendela ini
+// ditolak sehingga signature lama tidak bisa diputar ulang setelah catatan X-EXTERNAL-ID dibuang.
+const TimestampSkew = 5 * time.Minute
+
+// StringToSign data yang ditandatangani X-Signature (HMAC-SHA256): X-TIMESTAMP, X-EXTERNAL-ID
+// dan body dipisah ":", agar header anti-replay tidak bisa diganti tanpa secret
+func StringToSign(timestamp string, externalID string, body []byte) string {
+	return timestamp + ":" + externalID + ":" + string(body)
+}
+
+// Service satu layanan SNAP beserta service code 2 digitnya
+type Service struct {
+	Code string
+	Name string
+}
+
+var (
+	GenerateQR    = Service{Code: "47", Name: "QRIS MPM Generate QR"}
+	QueryPayment  = Service{Code: "51", Name: "QRIS MPM Query Payment"}
+	NotifyPayment = Service{Code: "52", Name: "QRIS MPM Payment Notify"}
+
+	// Internal service code untuk endpoint non-SNAP (dashboard, merchant, settlement, ...)
+	Internal = Service{Code: "00", Name: "Internal API"}
+)
+
+// Services registry endpoint SNAP; endpoint yang tidak terdaftar memakai Internal
+var Services = map[string]Service{
+	"POST /api/v1/qr/generate":         GenerateQR,
+	"POST /api/v1/qr/payment":          NotifyPayment,
+	"POST /api/v1/callbacks/:provider": NotifyPayment,
+}
+
+// ServiceFor service SNAP untuk method dan path route, Internal jika bukan endpoint SNAP
+func ServiceFor(method string, path string) Service {
+	if service, ok := Services[method+" "+path]; ok {
+		return service
+	}
+	return Internal
+}
+
+// ResponseCode response code 7 digit, contoh GenerateQR.ResponseCode(200, "00") = "2004700"
+func (s Service) ResponseCode(status int, caseCode string) string {
+	return fmt.Sprintf("%d%s%s", status, s.Code, caseCode)
+}
+
+// SuccessCode response code sukses layanan, contoh "2004700"
+func (s Service) SuccessCode() string {
+	return s.ResponseCode(200, "00")
+}
@@ -0,0 +1,42 @@
+package snap
+
+import (
+	"testing"
+	"time"
+
+	"qr-service/pkg/util"
+)
+
+func TestServiceCodes(t *testing.T) {
+	if got := GenerateQR.SuccessCode(); got != "2004700" {
+		t.Fatalf("GenerateQR.SuccessCode() = %q", got)
+	}
+	if got := ServiceFor("POST", "/api/v1/qr/payment").ResponseCode(404, "01"); got != "4045201" {
+		t.Fatalf("notify not found = %q", got)
+	}
+	if got := ServiceFor("GET", "/api/v1/transactions"); got != Internal {
+		t.Fatalf("ServiceFor non-SNAP = %+v", got)
+	}
+}
+
+func TestExternalIDStoreUniquePerPartnerPerDay(t *testing.T) {
+	store := NewExternalIDStore()
+	morning := time.Date(2025, 9, 21, 8, 0, 0, 0, util.WIB)
+
+	if !store.Reserve("P1", "123", morning) {
+		t.Fatal("first reserve should succeed")
+	}
+	if store.Reserve("P1", "123", morning.Add(time.Hour)) {
+		t.Fatal("same partner and external ID on the same day should be rejected")
+	}
+	if !store.Reserve("P2", "123", morning) {
+		t.Fatal("other partner may reuse the external ID")
+	}
+	if !store.Reserve("P1", "123", morning.AddDate(0, 0, 1)) {
+		t.Fatal("external ID may be reused on the next day")
+	}
+	store.Release("P1", "123", morning.AddDate(0, 0, 1))
+	if !store.Reserve("P1", "123", morning.AddDate(0, 0, 1)) {
+		t.Fatal("released external ID should be reservable again")
+	}
+}
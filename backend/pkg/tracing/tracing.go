@@ -0,0 +1,94 @@
+// Package tracing setup OpenTelemetry (OTLP/HTTP exporter, propagator W3C trace context)
+// dan helper span yang dipakai handler, service, repository (lewat plugin GORM) dan hub.
+package tracing
+
+import (
+	"context"
+	"net/url"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "qr-service"
+
+// TraceparentHeader header W3C trace context
+const TraceparentHeader = "traceparent"
+
+// Options konfigurasi Setup (diisi dari config.TracingConfig)
+type Options struct {
+	Endpoint    string // URL OTLP/HTTP collector, kosong = span tidak diekspor
+	ServiceName string
+	SampleRatio float64
+}
+
+// Setup memasang propagator W3C trace context dan, jika Endpoint diisi, TracerProvider dengan
+// exporter OTLP/HTTP. Fungsi yang dikembalikan mem-flush span tersisa saat shutdown.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if opts.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporterOpts := []otlptracehttp.Option{otlptracehttp.WithEndpointURL(opts.Endpoint)}
+	if u, err := url.Parse(opts.Endpoint); err == nil && u.Scheme == "http" {
+		exporterOpts = append(exporterOpts, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", opts.ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Start membuat span baru sebagai child dari span di ctx
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartClient membuat span client untuk request keluar ke sistem lain (contoh provider),
+// pasangkan dengan Inject agar trace berlanjut di sisi penerima
+func StartClient(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+// End menandai span error (jika ada) lalu menutupnya
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Extract membaca trace context dari header request masuk (traceparent/tracestate)
+func Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
+
+// Inject menulis trace context ctx ke carrier, contoh header request keluar
+func Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+}
+
+// Traceparent nilai header traceparent untuk span di ctx, kosong jika tidak ada trace.
+// Dipakai untuk menyertakan trace context di message WebSocket.
+func Traceparent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return carrier.Get(TraceparentHeader)
+}
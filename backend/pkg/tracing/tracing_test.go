@@ -0,0 +1,77 @@
+package tracing
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestGormPluginCreatesChildSpans(t *testing.T) {
+	recorder := newRecorder(t)
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "tracing.db")), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Use(GormPlugin{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Tanpa span di ctx: tidak ada span query
+	var n int
+	db.Raw("SELECT 1").Scan(&n)
+
+	ctx, parent := Start(context.Background(), "parent")
+	if err := db.WithContext(ctx).Raw("SELECT 1").Scan(&n).Error; err != nil {
+		t.Fatal(err)
+	}
+	parent.End()
+
+	var query sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if strings.HasPrefix(span.Name(), "gorm.") {
+			if query != nil {
+				t.Fatalf("expected one gorm span, got another %s", span.Name())
+			}
+			query = span
+		}
+	}
+	if query == nil {
+		t.Fatal("no gorm span recorded")
+	}
+	if query.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Fatalf("gorm span parent = %s, want %s", query.Parent().SpanID(), parent.SpanContext().SpanID())
+	}
+}
+
+func TestTraceparent(t *testing.T) {
+	newRecorder(t)
+
+	if got := Traceparent(context.Background()); got != "" {
+		t.Fatalf("Traceparent without span = %q", got)
+	}
+
+	ctx, span := Start(context.Background(), "op")
+	defer span.End()
+	want := "00-" + span.SpanContext().TraceID().String() + "-" + span.SpanContext().SpanID().String() + "-01"
+	if got := Traceparent(ctx); got != want {
+		t.Fatalf("Traceparent = %q, want %q", got, want)
+	}
+}
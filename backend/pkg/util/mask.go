@@ -0,0 +1,20 @@
+package util
+
+import "strings"
+
+// MaskPAN menyamarkan PAN pembayar: 6 digit awal dan 4 digit akhir tetap terlihat.
+// PAN yang sudah di-mask issuer (mengandung '*' atau 'X') dikembalikan apa adanya.
+func MaskPAN(pan string) string {
+	pan = strings.TrimSpace(pan)
+	if pan == "" || strings.ContainsAny(pan, "*Xx") {
+		return pan
+	}
+	if len(pan) <= 10 {
+		// Terlalu pendek untuk menyisakan 6+4 digit, tampilkan 4 digit akhir saja
+		if len(pan) <= 4 {
+			return strings.Repeat("*", len(pan))
+		}
+		return strings.Repeat("*", len(pan)-4) + pan[len(pan)-4:]
+	}
+	return pan[:6] + strings.Repeat("*", len(pan)-10) + pan[len(pan)-4:]
+}
@@ -2,20 +2,60 @@ package util
 
 import "fmt"
 
+// Terminal label default jika transaksi tidak terikat ke terminal tertentu
+const defaultTerminalLabel = "111"
+
 type QRGenerator interface {
-	GenerateQRContent(merchantID, referenceNo string) string
+	GenerateQRContent(merchantID, referenceNo, terminalID string) string
 }
 
 type qrGenerator struct {
 	template string
 }
 
+// DefaultQRTemplate payload gateway ID.CO.MANJO.WWW sampai tag 61; %s diisi merchant ID.
+// Tag 62 (additional data) dan CRC dibangun dinamis.
+const DefaultQRTemplate = "00020101021226620015ID.CO.MANJO.WWW01189360085801751859910210%s0303UMI51530014ID.CO.QRIS.WWW0215ID102106515192304121.0.21.09.255204481653033605502015802ID5904OLDI6013JAKARTA BARAT610511470"
+
 func NewQRGenerator() QRGenerator {
-	return &qrGenerator{
-		template: "00020101021226620015ID.CO.MANJO.WWW01189360085801751859910210%s0303UMI51530014ID.CO.QRIS.WWW0215ID102106515192304121.0.21.09.255204481653033605502015802ID5904OLDI6013JAKARTA BARAT61051147062460525%s07031110806'ASPI663040FAD",
+	return NewQRGeneratorWithTemplate(DefaultQRTemplate)
+}
+
+// NewQRGeneratorWithTemplate generator untuk acquirer lain; template berisi payload sampai
+// tag 61 dengan satu %s untuk merchant ID
+func NewQRGeneratorWithTemplate(template string) QRGenerator {
+	return &qrGenerator{template: template}
+}
+
+func (g *qrGenerator) GenerateQRContent(merchantID, referenceNo, terminalID string) string {
+	if terminalID == "" {
+		terminalID = defaultTerminalLabel
 	}
+
+	// Tag 62: 05 = reference label, 07 = terminal label, 08 = purpose of transaction
+	additionalData := tlv("05", referenceNo) + tlv("07", terminalID) + tlv("08", "'ASPI6")
+
+	payload := fmt.Sprintf(g.template, merchantID) + tlv("62", additionalData) + "6304"
+	return payload + fmt.Sprintf("%04X", crc16CCITT(payload))
 }
 
-func (g *qrGenerator) GenerateQRContent(merchantID, referenceNo string) string {
-	return fmt.Sprintf(g.template, merchantID, referenceNo)
+// tlv meng-encode satu data object EMVCo: ID (2 digit) + panjang (2 digit) + value
+func tlv(id, value string) string {
+	return fmt.Sprintf("%s%02d%s", id, len(value), value)
+}
+
+// crc16CCITT menghitung CRC-16/CCITT-FALSE (poly 0x1021, init 0xFFFF) sesuai spesifikasi QRIS
+func crc16CCITT(data string) uint16 {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
 }
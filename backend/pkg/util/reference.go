@@ -2,25 +2,129 @@ package util
 
 import (
 	"fmt"
+	"strconv"
+	"sync"
 	"time"
 )
 
+// Layout ID 63 bit ala Snowflake: 41 bit milidetik sejak referenceEpoch (~69 tahun),
+// 10 bit node dan 12 bit counter per milidetik.
+const (
+	referenceNodeBits    = 10
+	referenceCounterBits = 12
+
+	MaxReferenceNodeID  = 1<<referenceNodeBits - 1
+	maxReferenceCounter = 1<<referenceCounterBits - 1
+
+	// Jumlah digit bagian numerik, cukup untuk 2^63-1
+	referenceDigits = 19
+)
+
+// referenceEpoch titik nol timestamp di ID, jangan diubah setelah production
+var referenceEpoch = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ReferenceScope konteks transaksi untuk memilih prefix ReferenceNo
+type ReferenceScope struct {
+	MerchantID string
+	ChannelID  string // header CHANNEL-ID
+}
+
 type ReferenceGenerator interface {
-	GenerateReferenceNo() string
+	GenerateReferenceNo(scope ReferenceScope) string
+}
+
+// ReferenceOptions konfigurasi ReferenceGenerator
+type ReferenceOptions struct {
+	// NodeID harus unik per instance yang berjalan bersamaan (0..MaxReferenceNodeID)
+	NodeID int
+	// Prefix default, contoh "A"
+	Prefix string
+	// Prefix per merchant, didahulukan dari prefix per channel
+	MerchantPrefixes map[string]string
+	// Prefix per CHANNEL-ID
+	ChannelPrefixes map[string]string
+	// CheckDigit menambahkan digit Luhn di akhir agar salah ketik terdeteksi
+	CheckDigit bool
 }
 
 type referenceGenerator struct {
-	prefix string
+	opts ReferenceOptions
+	now  func() time.Time
+
+	mu          sync.Mutex
+	lastMillis  int64
+	lastCounter int64
+}
+
+func NewReferenceGenerator(opts ReferenceOptions) ReferenceGenerator {
+	return &referenceGenerator{opts: opts, now: time.Now}
+}
+
+// GenerateReferenceNo format: prefix + 19 digit (timestamp, node, counter) + check digit opsional,
+// contoh A0000581937281024001. Urutan ID mengikuti waktu pembuatan per node.
+func (g *referenceGenerator) GenerateReferenceNo(scope ReferenceScope) string {
+	digits := fmt.Sprintf("%0*d", referenceDigits, g.nextID())
+	if g.opts.CheckDigit {
+		digits += strconv.Itoa(LuhnCheckDigit(digits))
+	}
+	return g.prefix(scope) + digits
+}
+
+func (g *referenceGenerator) prefix(scope ReferenceScope) string {
+	if prefix, ok := g.opts.MerchantPrefixes[scope.MerchantID]; ok {
+		return prefix
+	}
+	if prefix, ok := g.opts.ChannelPrefixes[scope.ChannelID]; ok {
+		return prefix
+	}
+	return g.opts.Prefix
+}
+
+// nextID tidak pernah mengembalikan ID yang sama dalam satu proses. Jika jam mundur atau
+// counter habis dalam satu milidetik, timestamp logis dilanjutkan dari ID terakhir
+// alih-alih menunggu jam.
+func (g *referenceGenerator) nextID() int64 {
+	millis := g.now().Sub(referenceEpoch).Milliseconds()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if millis > g.lastMillis {
+		g.lastMillis, g.lastCounter = millis, 0
+	} else if g.lastCounter < maxReferenceCounter {
+		g.lastCounter++
+	} else {
+		g.lastMillis, g.lastCounter = g.lastMillis+1, 0
+	}
+	return g.lastMillis<<(referenceNodeBits+referenceCounterBits) | int64(g.opts.NodeID)<<referenceCounterBits | g.lastCounter
 }
 
-func NewReferenceGenerator(prefix string) ReferenceGenerator {
-	return &referenceGenerator{prefix: prefix}
+// LuhnCheckDigit digit Luhn (mod 10) untuk string angka
+func LuhnCheckDigit(digits string) int {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		// Digit paling kanan sebelum check digit dikalikan dua
+		if (len(digits)-1-i)%2 == 0 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return (10 - sum%10) % 10
 }
 
-func (g *referenceGenerator) GenerateReferenceNo() string {
-	// Format: A0000000577
-	// Menggunakan kombinasi timestamp dan sequence
-	timestamp := time.Now().UnixNano() / int64(time.Millisecond)
-	sequence := timestamp % 10000000000
-	return g.prefix + fmt.Sprintf("%010d", sequence)
+// ValidLuhn memeriksa angka yang diakhiri check digit Luhn
+func ValidLuhn(number string) bool {
+	if len(number) < 2 {
+		return false
+	}
+	for i := 0; i < len(number); i++ {
+		if number[i] < '0' || number[i] > '9' {
+			return false
+		}
+	}
+	return LuhnCheckDigit(number[:len(number)-1]) == int(number[len(number)-1]-'0')
 }
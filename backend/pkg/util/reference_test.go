@@ -0,0 +1,110 @@
+package util
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReferenceNoUniqueUnderConcurrency(t *testing.T) {
+	const workers, perWorker = 8, 125_000 // 1 juta ID
+	gen := NewReferenceGenerator(ReferenceOptions{NodeID: 7, Prefix: "A"})
+
+	results := make([][]string, workers)
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ids := make([]string, perWorker)
+			for i := range ids {
+				ids[i] = gen.GenerateReferenceNo(ReferenceScope{})
+			}
+			results[w] = ids
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[string]struct{}, workers*perWorker)
+	for _, ids := range results {
+		for _, id := range ids {
+			if _, dup := seen[id]; dup {
+				t.Fatalf("duplicate reference %s", id)
+			}
+			seen[id] = struct{}{}
+		}
+	}
+}
+
+func TestReferenceNoFrozenAndBackwardClock(t *testing.T) {
+	clock := referenceEpoch.Add(time.Hour)
+	gen := NewReferenceGenerator(ReferenceOptions{Prefix: "A"}).(*referenceGenerator)
+	gen.now = func() time.Time { return clock }
+
+	seen := make(map[string]struct{})
+	last := ""
+	for i := 0; i < 3*(maxReferenceCounter+1); i++ {
+		if i == maxReferenceCounter {
+			clock = clock.Add(-time.Minute) // jam mundur (NTP)
+		}
+		id := gen.GenerateReferenceNo(ReferenceScope{})
+		if _, dup := seen[id]; dup {
+			t.Fatalf("duplicate reference %s at %d", id, i)
+		}
+		if id <= last {
+			t.Fatalf("reference %s not after %s", id, last)
+		}
+		seen[id], last = struct{}{}, id
+	}
+}
+
+func TestReferenceNoNodesDoNotCollide(t *testing.T) {
+	clock := referenceEpoch.Add(time.Hour)
+	a := NewReferenceGenerator(ReferenceOptions{NodeID: 1}).(*referenceGenerator)
+	b := NewReferenceGenerator(ReferenceOptions{NodeID: 2}).(*referenceGenerator)
+	a.now = func() time.Time { return clock }
+	b.now = a.now
+
+	if idA, idB := a.GenerateReferenceNo(ReferenceScope{}), b.GenerateReferenceNo(ReferenceScope{}); idA == idB {
+		t.Fatalf("nodes generated the same reference %s", idA)
+	}
+}
+
+func TestReferenceNoPrefixAndCheckDigit(t *testing.T) {
+	gen := NewReferenceGenerator(ReferenceOptions{
+		NodeID:           MaxReferenceNodeID,
+		Prefix:           "A",
+		MerchantPrefixes: map[string]string{"M001": "MX"},
+		ChannelPrefixes:  map[string]string{"95221": "B"},
+		CheckDigit:       true,
+	})
+
+	tests := []struct {
+		scope  ReferenceScope
+		prefix string
+	}{
+		{ReferenceScope{MerchantID: "M001", ChannelID: "95221"}, "MX"},
+		{ReferenceScope{MerchantID: "M002", ChannelID: "95221"}, "B"},
+		{ReferenceScope{MerchantID: "M002", ChannelID: "1"}, "A"},
+	}
+	for _, tt := range tests {
+		ref := gen.GenerateReferenceNo(tt.scope)
+		digits, ok := strings.CutPrefix(ref, tt.prefix)
+		if !ok || len(digits) != referenceDigits+1 {
+			t.Fatalf("reference %q: want prefix %q and %d digits", ref, tt.prefix, referenceDigits+1)
+		}
+		if !ValidLuhn(digits) {
+			t.Fatalf("reference %q has invalid check digit", ref)
+		}
+	}
+}
+
+func TestLuhnCheckDigit(t *testing.T) {
+	if got := LuhnCheckDigit("7992739871"); got != 3 {
+		t.Fatalf("LuhnCheckDigit = %d, want 3", got)
+	}
+	if !ValidLuhn("79927398713") || ValidLuhn("79927398710") || ValidLuhn("7992739871x") {
+		t.Fatal("ValidLuhn mismatch")
+	}
+}
@@ -4,15 +4,11 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
-	"os"
 )
 
 // GenerateHMACSHA256 menghasilkan signature dari data (body request)
-// menggunakan secret key dari environment variable HMAC_SECRET.
-func GenerateHMACSHA256(data string) string {
-	// Ambil secret key dari environment variable yang diset di docker-compose.yml
-	secret := os.Getenv("HMAC_SECRET")
-
+// menggunakan secret key (config.Security.HMACSecret).
+func GenerateHMACSHA256(secret string, data string) string {
 	// Inisialisasi HMAC dengan SHA256 dan secret key
 	h := hmac.New(sha256.New, []byte(secret))
 
@@ -25,8 +21,8 @@ func GenerateHMACSHA256(data string) string {
 
 // ValidateHMACSHA256 memvalidasi signature yang diterima (header)
 // terhadap signature yang diharapkan (dihitung dari body).
-func ValidateHMACSHA256(signature string, data string) bool {
-	expectedSignature := GenerateHMACSHA256(data)
+func ValidateHMACSHA256(secret string, signature string, data string) bool {
+	expectedSignature := GenerateHMACSHA256(secret, data)
 
 	// Membandingkan signature yang diterima dengan yang dihitung
 	return signature == expectedSignature
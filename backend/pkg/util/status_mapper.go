@@ -1,59 +1,107 @@
 // pkg/util/status_mapper.go
 package util
 
+import (
+	"maps"
+	"strings"
+)
+
+// Status internal transaksi
+const (
+	StatusPending = "PENDING"
+	StatusPaid    = "PAID"
+	StatusFailed  = "FAILED"
+	StatusExpired = "EXPIRED"
+)
+
+var InternalStatuses = []string{StatusPending, StatusPaid, StatusFailed, StatusExpired}
+
+// DefaultGateway tabel yang dipakai gateway tanpa tabel sendiri, filter status dan
+// file rekonsiliasi
+const DefaultGateway = "default"
+
+// StatusMapping tabel status eksternal -> status internal. Key dibandingkan tanpa
+// membedakan huruf besar/kecil dan spasi di tepi.
+type StatusMapping map[string]string
+
+// DefaultStatusMapping nama status umum dan kode latestTransactionStatus SNAP.
+// 04 (Refunded) dan 07 (Not Found) sengaja tidak dipetakan karena tidak ada padanan
+// status internalnya; callback dengan kode tersebut ditolak dan dicatat untuk ditinjau.
+var DefaultStatusMapping = StatusMapping{
+	"SUCCESS":   StatusPaid,
+	"PAID":      StatusPaid,
+	"SETTLED":   StatusPaid,
+	"FAILED":    StatusFailed,
+	"CANCELED":  StatusFailed,
+	"CANCELLED": StatusFailed,
+	"PENDING":   StatusPending,
+	"INITIATED": StatusPending,
+	"PAYING":    StatusPending,
+	"EXPIRED":   StatusExpired,
+
+	// latestTransactionStatus SNAP
+	"00": StatusPaid,    // Success
+	"01": StatusPending, // Initiated
+	"02": StatusPending, // Paying
+	"03": StatusPending, // Pending
+	"05": StatusFailed,  // Canceled
+	"06": StatusFailed,  // Failed
+}
+
 type StatusMapper interface {
-	MapTransactionStatus(transactionStatusDesc string) string
-	GetInternalStatus(transactionStatusDesc string) string
-	IsValidStatus(transactionStatusDesc string) bool
+	// Map memetakan status dari gateway ke status internal. ok false jika status tidak dikenal,
+	// tidak ada fallback diam-diam ke PENDING.
+	Map(gateway, external string) (status string, ok bool)
+	// Mappings tabel yang berlaku per gateway, termasuk DefaultGateway
+	Mappings() map[string]StatusMapping
 }
 
 type statusMapper struct {
-	statusMapping map[string]string
-	validStatuses []string
-}
-
-func NewStatusMapper() StatusMapper {
-	// Define mapping dari external status ke internal status
-	mapping := map[string]string{
-		"Success": "PAID",
-		"Failed":  "FAILED",
-		"Pending": "PENDING",
-		"Expired": "EXPIRED",
-		"Paid":    "PAID",
-		"PAID":    "PAID",
-		"SUCCESS": "PAID",
-		"FAILED":  "FAILED",
-		"PENDING": "PENDING",
-		"EXPIRED": "EXPIRED",
-	}
-
-	validStatuses := []string{"Success", "Failed", "Pending", "Expired", "Paid", "PAID", "SUCCESS", "FAILED", "PENDING", "EXPIRED"}
+	gateways map[string]StatusMapping
+}
 
-	return &statusMapper{
-		statusMapping: mapping,
-		validStatuses: validStatuses,
+// NewStatusMapper membuat mapper dari tabel per gateway (key: nama payment provider).
+// Tabel gateway melengkapi dan bisa menimpa DefaultStatusMapping.
+func NewStatusMapper(gateways map[string]StatusMapping) StatusMapper {
+	// Tabel bernama DefaultGateway mengubah default untuk semua gateway, jadi dibangun lebih dulu
+	base := normalizeMapping(normalizeMapping(nil, DefaultStatusMapping), gateways[DefaultGateway])
+	m := &statusMapper{gateways: map[string]StatusMapping{DefaultGateway: base}}
+	for gateway, mapping := range gateways {
+		if gateway != DefaultGateway {
+			m.gateways[gateway] = normalizeMapping(base, mapping)
+		}
 	}
+	return m
 }
 
-// MapTransactionStatus memetakan external status ke internal status
-func (m *statusMapper) MapTransactionStatus(transactionStatusDesc string) string {
-	if internalStatus, exists := m.statusMapping[transactionStatusDesc]; exists {
-		return internalStatus
+func normalizeMapping(base StatusMapping, mapping StatusMapping) StatusMapping {
+	result := maps.Clone(base)
+	if result == nil {
+		result = make(StatusMapping, len(mapping))
+	}
+	for external, internal := range mapping {
+		result[normalizeStatus(external)] = internal
 	}
-	return "PENDING" // default fallback
+	return result
 }
 
-// GetInternalStatus alias untuk MapTransactionStatus
-func (m *statusMapper) GetInternalStatus(transactionStatusDesc string) string {
-	return m.MapTransactionStatus(transactionStatusDesc)
+func normalizeStatus(status string) string {
+	return strings.ToUpper(strings.TrimSpace(status))
 }
 
-// IsValidStatus mengecek apakah status yang diberikan valid
-func (m *statusMapper) IsValidStatus(transactionStatusDesc string) bool {
-	for _, validStatus := range m.validStatuses {
-		if validStatus == transactionStatusDesc {
-			return true
-		}
+func (m *statusMapper) Map(gateway, external string) (string, bool) {
+	mapping, ok := m.gateways[gateway]
+	if !ok {
+		mapping = m.gateways[DefaultGateway]
+	}
+	status, ok := mapping[normalizeStatus(external)]
+	return status, ok
+}
+
+func (m *statusMapper) Mappings() map[string]StatusMapping {
+	result := make(map[string]StatusMapping, len(m.gateways))
+	for gateway, mapping := range m.gateways {
+		result[gateway] = maps.Clone(mapping)
 	}
-	return false
+	return result
 }
@@ -0,0 +1,44 @@
+package util
+
+import "testing"
+
+func TestStatusMapper(t *testing.T) {
+	mapper := NewStatusMapper(map[string]StatusMapping{
+		DefaultGateway: {"Lunas": StatusPaid},
+		"GW-1":         {"00": StatusFailed, "PROCESSING": StatusPending},
+	})
+
+	tests := []struct {
+		gateway, external string
+		want              string
+		ok                bool
+	}{
+		{"", "Success", StatusPaid, true},
+		{"", " settled ", StatusPaid, true},
+		{"", "00", StatusPaid, true},
+		{"", "06", StatusFailed, true},
+		{"", "04", "", false}, // Refunded tidak dipetakan
+		{"", "LUNAS", StatusPaid, true},
+		{"", "PROCESSING", "", false},
+		{"GW-1", "00", StatusFailed, true}, // tabel gateway menimpa default
+		{"GW-1", "processing", StatusPending, true},
+		{"GW-1", "lunas", StatusPaid, true}, // override default berlaku untuk semua gateway
+		{"GW-2", "Expired", StatusExpired, true},
+		{"GW-2", "UNKNOWN", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := mapper.Map(tt.gateway, tt.external)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Map(%q, %q) = %q, %v; want %q, %v", tt.gateway, tt.external, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	mappings := mapper.Mappings()
+	if len(mappings) != 2 || mappings["GW-1"]["PROCESSING"] != StatusPending {
+		t.Fatalf("Mappings() = %v", mappings)
+	}
+	mappings[DefaultGateway]["UNKNOWN"] = StatusPaid
+	if _, ok := mapper.Map("", "UNKNOWN"); ok {
+		t.Fatal("Mappings() must return a copy")
+	}
+}
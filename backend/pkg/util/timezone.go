@@ -0,0 +1,27 @@
+package util
+
+import "time"
+
+// WIB adalah zona waktu operasional (Asia/Jakarta, UTC+7).
+// Fallback ke fixed zone jika tzdata tidak tersedia di container.
+var WIB = loadWIB()
+
+func loadWIB() *time.Location {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		return time.FixedZone("WIB", 7*60*60)
+	}
+	return loc
+}
+
+// StartOfDay mengembalikan jam 00:00 WIB dari hari t
+func StartOfDay(t time.Time) time.Time {
+	t = t.In(WIB)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, WIB)
+}
+
+// StartOfMonth mengembalikan tanggal 1 jam 00:00 WIB dari bulan t
+func StartOfMonth(t time.Time) time.Time {
+	t = t.In(WIB)
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, WIB)
+}
@@ -1,41 +1,113 @@
 package websocket
 
 import (
+	"context"
 	"encoding/json"
-	"log"
+	"errors"
+	"log/slog"
 	"sync"
+	"sync/atomic"
 )
 
+// Subscription menentukan transaksi mana yang ingin diterima client.
+// Field kosong berarti wildcard (terima semua).
+type Subscription struct {
+	MerchantID string `json:"merchant_id"`
+	OutletID   string `json:"outlet_id"`
+	TerminalID string `json:"terminal_id"`
+}
+
+// Matches mengecek apakah scope sebuah message cocok dengan subscription
+func (s Subscription) Matches(scope Subscription) bool {
+	if s.MerchantID != "" && s.MerchantID != scope.MerchantID {
+		return false
+	}
+	if s.OutletID != "" && s.OutletID != scope.OutletID {
+		return false
+	}
+	if s.TerminalID != "" && s.TerminalID != scope.TerminalID {
+		return false
+	}
+	return true
+}
+
 type Client struct {
 	ID   string
 	Send chan []byte
+
+	subscription Subscription
+	subMu        sync.RWMutex
+}
+
+// Subscribe mengganti filter subscription milik client
+func (c *Client) Subscribe(sub Subscription) {
+	c.subMu.Lock()
+	c.subscription = sub
+	c.subMu.Unlock()
+}
+
+// Subscription mengembalikan filter subscription client saat ini
+func (c *Client) Subscription() Subscription {
+	c.subMu.RLock()
+	defer c.subMu.RUnlock()
+	return c.subscription
+}
+
+// message adalah payload broadcast beserta scope merchant/outlet/terminal-nya.
+// Message tanpa scope (Broadcast biasa) dikirim ke semua client.
+type message struct {
+	data   []byte
+	scope  Subscription
+	scoped bool
 }
 
 type Hub struct {
 	clients    map[*Client]bool
-	broadcast  chan []byte
+	broadcast  chan message
 	register   chan *Client
 	unregister chan *Client
+	ping       chan chan struct{}
 	mu         sync.RWMutex
+
+	dropped atomic.Uint64 // message yang dibuang karena buffer client penuh
+
+	quit     chan struct{} // ditutup saat shutdown dimulai
+	stopped  chan struct{} // ditutup setelah Run selesai
+	stopOnce sync.Once
 }
 
 func NewHub() *Hub {
 	return &Hub{
-		broadcast:  make(chan []byte),
+		broadcast:  make(chan message),
 		register:   make(chan *Client),
 		unregister: make(chan *Client),
+		ping:       make(chan chan struct{}),
 		clients:    make(map[*Client]bool),
+		quit:       make(chan struct{}),
+		stopped:    make(chan struct{}),
 	}
 }
 
 func (h *Hub) Run() {
+	defer close(h.stopped)
 	for {
 		select {
+		case <-h.quit:
+			// Tutup Send semua client; writePump lalu mengirim close frame ke client
+			h.mu.Lock()
+			for client := range h.clients {
+				close(client.Send)
+				delete(h.clients, client)
+			}
+			h.mu.Unlock()
+			slog.Info("WebSocket hub stopped")
+			return
+
 		case client := <-h.register:
 			h.mu.Lock()
 			h.clients[client] = true
 			h.mu.Unlock()
-			log.Printf("Client registered: %s, total clients: %d", client.ID, len(h.clients))
+			slog.Debug("WebSocket client registered", "client_id", client.ID, "total_clients", len(h.clients))
 
 		case client := <-h.unregister:
 			h.mu.Lock()
@@ -44,48 +116,114 @@ func (h *Hub) Run() {
 				close(client.Send)
 			}
 			h.mu.Unlock()
-			log.Printf("Client unregistered: %s, total clients: %d", client.ID, len(h.clients))
+			slog.Debug("WebSocket client unregistered", "client_id", client.ID, "total_clients", len(h.clients))
 
-		case message := <-h.broadcast:
-			h.mu.RLock()
+		case msg := <-h.broadcast:
+			h.mu.Lock()
 			for client := range h.clients {
+				if msg.scoped && !client.Subscription().Matches(msg.scope) {
+					continue
+				}
 				select {
-				case client.Send <- message:
+				case client.Send <- msg.data:
 				default:
+					// Buffer client penuh: message dibuang dan client diputus
+					h.dropped.Add(1)
 					close(client.Send)
 					delete(h.clients, client)
 				}
 			}
-			h.mu.RUnlock()
+			h.mu.Unlock()
+
+		case reply := <-h.ping:
+			close(reply)
 		}
 	}
 }
 
+// Stop menghentikan Run dan menutup Send semua client, lalu menunggu Run selesai.
+// Setelah Stop, Register langsung menutup Send client baru dan Broadcast diabaikan.
+func (h *Hub) Stop() {
+	h.stopOnce.Do(func() { close(h.quit) })
+	<-h.stopped
+}
+
+// Stopping channel yang ditutup saat hub mulai shutdown
+func (h *Hub) Stopping() <-chan struct{} {
+	return h.quit
+}
+
+// ErrHubStopped dikembalikan Ping setelah hub dihentikan
+var ErrHubStopped = errors.New("websocket hub stopped")
+
+// Ping memastikan goroutine Run masih memproses event: request dikirim lewat loop yang
+// sama dengan register dan broadcast, jadi hub yang macet atau mati tidak membalas.
+func (h *Hub) Ping(ctx context.Context) error {
+	reply := make(chan struct{})
+	select {
+	case h.ping <- reply:
+	case <-h.quit:
+		return ErrHubStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-reply:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // Export method untuk register client
 func (h *Hub) Register(client *Client) {
-	h.register <- client
+	select {
+	case h.register <- client:
+	case <-h.quit:
+		close(client.Send)
+	}
 }
 
 // Export method untuk unregister client
 func (h *Hub) Unregister(client *Client) {
-	h.unregister <- client
+	select {
+	case h.unregister <- client:
+	case <-h.quit:
+	}
 }
 
 // Export method untuk broadcast message
-func (h *Hub) Broadcast(message []byte) {
-	h.broadcast <- message
+func (h *Hub) Broadcast(data []byte) {
+	h.send(message{data: data})
+}
+
+// BroadcastScoped hanya mengirim message ke client yang subscription-nya cocok dengan scope
+func (h *Hub) BroadcastScoped(data []byte, scope Subscription) {
+	h.send(message{data: data, scope: scope, scoped: true})
+}
+
+func (h *Hub) send(msg message) {
+	select {
+	case h.broadcast <- msg:
+	case <-h.quit:
+	}
 }
 
 // BroadcastJSON mengirim data JSON ke semua clients
 func (h *Hub) BroadcastJSON(data interface{}) error {
-	message, err := json.Marshal(data)
+	payload, err := json.Marshal(data)
 	if err != nil {
 		return err
 	}
-	h.broadcast <- message
+	h.Broadcast(payload)
 	return nil
 }
 
+// DroppedMessages jumlah message yang dibuang karena client terlalu lambat
+func (h *Hub) DroppedMessages() uint64 {
+	return h.dropped.Load()
+}
+
 func (h *Hub) GetClientCount() int {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
@@ -0,0 +1,57 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHubStopClosesClients(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+
+	client := &Client{ID: "c1", Send: make(chan []byte, 1)}
+	hub.Register(client)
+	hub.Stop()
+
+	select {
+	case _, ok := <-client.Send:
+		if ok {
+			t.Fatal("expected Send to be closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Send not closed after Stop")
+	}
+
+	// Setelah Stop tidak ada yang boleh blocking
+	late := &Client{ID: "c2", Send: make(chan []byte, 1)}
+	hub.Register(late)
+	if _, ok := <-late.Send; ok {
+		t.Fatal("expected late client Send to be closed")
+	}
+	hub.Broadcast([]byte("ignored"))
+	hub.Unregister(client)
+	hub.Stop()
+}
+
+func TestHubPing(t *testing.T) {
+	hub := NewHub()
+
+	// Run belum jalan: tidak ada yang membalas ping
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := hub.Ping(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Ping without Run = %v, want deadline exceeded", err)
+	}
+
+	go hub.Run()
+	if err := hub.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping = %v", err)
+	}
+
+	hub.Stop()
+	if err := hub.Ping(context.Background()); !errors.Is(err, ErrHubStopped) {
+		t.Fatalf("Ping after Stop = %v, want ErrHubStopped", err)
+	}
+}
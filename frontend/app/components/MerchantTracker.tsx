@@ -381,7 +381,7 @@ export default function MerchantTracker() {
             const data: ApiResponse = await response.json();
             console.log('📦 API response:', data);
 
-            if (data.responseCode === '200') {
+            if (data.responseCode?.startsWith('200')) {
                 const normalizedData = data.data.map((transaction: any) => ({
                     ...transaction,
                     referenceNo: transaction.referenceNo || transaction.reference_no,
@@ -5,7 +5,7 @@ import { ArrowLeft, Search, Calendar, User, DollarSign, Hash, CreditCard, Plus,
 import { useState } from "react";
 import crypto from 'crypto';
 import { toast, ToastContainer } from 'react-toastify';  // Import toastify
-import { generateSignature, generateSignatureAlt } from "@/lib/signature";
+import { generateSignature, generateSignatureAlt, snapHeaders, stringToSign } from "@/lib/signature";
 
 export default function GeneratePage() {
     const [formData, setFormData] = useState({
@@ -60,18 +60,19 @@ export default function GeneratePage() {
             };
 
             const requestBody = JSON.stringify(qrRequestData);
-            // Generate X-Signature
+            // Generate X-Signature dari header SNAP dan body
+            const headers = snapHeaders();
             const secretKey: string = process.env.NEXT_PUBLIC_API_SECRET_KEY || '';
             let signature: string;
 
             try {
                 // Coba menggunakan Web Crypto API pertama
-                signature = await generateSignature(requestBody, secretKey);
+                signature = await generateSignature(stringToSign(headers, requestBody), secretKey);
             } catch (webCryptoError) {
                 console.warn('Web Crypto tidak tersedia, menggunakan fallback:', webCryptoError);
                 try {
                     // Fallback ke crypto-js
-                    signature = await generateSignatureAlt(requestBody, secretKey);
+                    signature = await generateSignatureAlt(stringToSign(headers, requestBody), secretKey);
                 } catch (cryptoJSError) {
                     console.error('Kedua metode signature gagal:', cryptoJSError);
                     throw new Error('Tidak dapat generate signature');
@@ -84,7 +85,8 @@ export default function GeneratePage() {
                 method: 'POST',
                 headers: {
                     'Content-Type': 'application/json',
-                    'X-Signature': signature
+                    'X-Signature': signature,
+                    ...headers
                 },
                 body: requestBody
             });
@@ -5,7 +5,7 @@ import { Card, CardHeader, CardBody, Button, Divider, Chip, Spinner } from '@her
 import { ArrowLeft, Download, Copy, CheckCircle, Clock, QrCode, DollarSign, Calendar, User, Hash, CreditCard, XCircle } from 'lucide-react';
 import { useParams } from 'next/navigation';
 import { toast, ToastContainer } from 'react-toastify';
-import { generateSignature } from '@/lib/signature';
+import { generateSignature, snapHeaders, stringToSign } from '@/lib/signature';
 
 // Fungsi untuk decode Base64 URL-safe
 const decodeFromBase64URL = (base64url: string): any => {
@@ -152,10 +152,11 @@ export default function PaymentPage() {
             const requestBody = JSON.stringify(paymentRequestData);
 
             const secretKey: string = process.env.NEXT_PUBLIC_API_SECRET_KEY || ''
+            const headers = snapHeaders();
             let signature: string;
 
             try {
-                signature = await generateSignature(requestBody, secretKey);
+                signature = await generateSignature(stringToSign(headers, requestBody), secretKey);
             } catch (sigError) {
                 console.error('Error generating signature:', sigError);
                 throw new Error('Gagal menghasilkan signature untuk otentikasi');
@@ -166,9 +167,10 @@ export default function PaymentPage() {
                 method: 'POST',
                 headers: {
                     'Content-Type': 'application/json',
-                    'X-Signature': signature
+                    'X-Signature': signature,
+                    ...headers
                 },
-                body: JSON.stringify(paymentRequestData)
+                body: requestBody
             });
 
             if (!response.ok) {
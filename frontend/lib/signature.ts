@@ -28,5 +28,27 @@ const generateSignatureAlt = async (data: string, secret: string): Promise<strin
     return CryptoJS.enc.Base64.stringify(hash);
 };
 
+// Header wajib SNAP untuk endpoint /qr: X-TIMESTAMP (ISO 8601 dengan offset),
+// X-PARTNER-ID, X-EXTERNAL-ID (numerik, unik per hari) dan CHANNEL-ID
+const snapHeaders = (): Record<string, string> => {
+    const now = new Date();
+    const offset = -now.getTimezoneOffset();
+    const pad = (n: number) => String(Math.floor(Math.abs(n))).padStart(2, '0');
+    const local = new Date(now.getTime() + offset * 60000).toISOString().slice(0, 19);
+    const timestamp = `${local}${offset >= 0 ? '+' : '-'}${pad(offset / 60)}:${pad(offset % 60)}`;
+    const externalId = `${Date.now()}${Math.floor(Math.random() * 1e6).toString().padStart(6, '0')}`;
 
-export { generateSignature, generateSignatureAlt };
\ No newline at end of file
+    return {
+        'X-TIMESTAMP': timestamp,
+        'X-PARTNER-ID': process.env.NEXT_PUBLIC_PARTNER_ID || 'DEMO',
+        'X-EXTERNAL-ID': externalId,
+        'CHANNEL-ID': '95221',
+    };
+};
+
+// Data yang ditandatangani X-Signature: X-TIMESTAMP, X-EXTERNAL-ID dan body dipisah ":"
+// (sama dengan snap.StringToSign di backend), jadi header SNAP dibuat sebelum signature
+const stringToSign = (headers: Record<string, string>, body: string): string =>
+    `${headers['X-TIMESTAMP']}:${headers['X-EXTERNAL-ID']}:${body}`;
+
+export { generateSignature, generateSignatureAlt, snapHeaders, stringToSign };
\ No newline at end of file